@@ -0,0 +1,364 @@
+/*
+Package ai parses and formats GS1 element strings: Application Identifiers
+(AIs) followed by their data, as carried by GS1-128, GS1 DataBar and 2D
+symbols.
+
+Element strings come in two forms. The human readable form puts the AIs in
+parentheses,
+
+	(01)09506000134352(17)251231(10)ABC123
+
+while the raw form, as scanned, separates variable length fields with the
+group separator GS, which stands in for FNC1 in the symbol:
+
+	010950600013435210ABC123<GS>17251231
+
+Fields of predefined length, such as the GTIN and dates, need no separator.
+*/
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// GS is the group separator that ends variable length fields in raw
+// element strings
+const GS = '\x1d'
+
+var (
+	// ErrSyntax is returned for malformed element strings
+	ErrSyntax = errors.New("ai: invalid element string")
+
+	// ErrUnknown is returned for AIs not in the table of this package
+	ErrUnknown = errors.New("ai: unknown application identifier")
+)
+
+// spec is the format of the data of an AI
+type spec struct {
+	length   int // Digits of the AI
+	min, max int // Length of the data
+	numeric  bool
+	check    bool // The last digit is a GS1 check digit
+	date     bool // YYMMDD
+}
+
+// specs of the AIs in use, by AI or, for AI families such as 310n, by the
+// first three digits
+var specs = map[string]spec{
+	"00":   {2, 18, 18, true, true, false},
+	"01":   {2, 14, 14, true, true, false},
+	"02":   {2, 14, 14, true, true, false},
+	"03":   {2, 14, 14, true, true, false},
+	"10":   {2, 1, 20, false, false, false},
+	"11":   {2, 6, 6, true, false, true},
+	"12":   {2, 6, 6, true, false, true},
+	"13":   {2, 6, 6, true, false, true},
+	"15":   {2, 6, 6, true, false, true},
+	"16":   {2, 6, 6, true, false, true},
+	"17":   {2, 6, 6, true, false, true},
+	"20":   {2, 2, 2, true, false, false},
+	"21":   {2, 1, 20, false, false, false},
+	"22":   {2, 1, 20, false, false, false},
+	"235":  {3, 1, 28, false, false, false},
+	"240":  {3, 1, 30, false, false, false},
+	"241":  {3, 1, 30, false, false, false},
+	"242":  {3, 1, 6, true, false, false},
+	"243":  {3, 1, 20, false, false, false},
+	"250":  {3, 1, 30, false, false, false},
+	"251":  {3, 1, 30, false, false, false},
+	"253":  {3, 13, 30, false, false, false},
+	"254":  {3, 1, 20, false, false, false},
+	"255":  {3, 13, 25, true, false, false},
+	"30":   {2, 1, 8, true, false, false},
+	"37":   {2, 1, 8, true, false, false},
+	"390":  {4, 1, 15, true, false, false},
+	"391":  {4, 4, 18, true, false, false},
+	"392":  {4, 1, 15, true, false, false},
+	"393":  {4, 4, 18, true, false, false},
+	"394":  {4, 4, 4, true, false, false},
+	"395":  {4, 6, 6, true, false, false},
+	"400":  {3, 1, 30, false, false, false},
+	"401":  {3, 1, 30, false, false, false},
+	"402":  {3, 17, 17, true, true, false},
+	"403":  {3, 1, 30, false, false, false},
+	"420":  {3, 1, 20, false, false, false},
+	"421":  {3, 4, 12, false, false, false},
+	"422":  {3, 3, 3, true, false, false},
+	"423":  {3, 3, 15, true, false, false},
+	"424":  {3, 3, 3, true, false, false},
+	"425":  {3, 3, 15, true, false, false},
+	"426":  {3, 3, 3, true, false, false},
+	"427":  {3, 1, 3, false, false, false},
+	"700":  {4, 1, 30, false, false, false},
+	"7001": {4, 13, 13, true, false, false},
+	"7003": {4, 10, 10, true, false, false},
+	"7004": {4, 1, 4, true, false, false},
+	"703":  {4, 3, 30, false, false, false},
+	"8001": {4, 14, 14, true, false, false},
+	"8002": {4, 1, 20, false, false, false},
+	"8003": {4, 14, 30, false, false, false},
+	"8004": {4, 1, 30, false, false, false},
+	"8005": {4, 6, 6, true, false, false},
+	"8006": {4, 18, 18, true, false, false},
+	"8007": {4, 1, 34, false, false, false},
+	"8008": {4, 8, 12, true, false, false},
+	"8010": {4, 1, 30, false, false, false},
+	"8011": {4, 1, 12, true, false, false},
+	"8012": {4, 1, 20, false, false, false},
+	"8013": {4, 1, 25, false, false, false},
+	"8017": {4, 18, 18, true, true, false},
+	"8018": {4, 18, 18, true, true, false},
+	"8019": {4, 1, 10, true, false, false},
+	"8020": {4, 1, 25, false, false, false},
+	"8026": {4, 18, 18, true, false, false},
+	"8110": {4, 1, 70, false, false, false},
+	"8111": {4, 4, 4, true, false, false},
+	"8112": {4, 1, 70, false, false, false},
+	"8200": {4, 1, 70, false, false, false},
+	"90":   {2, 1, 30, false, false, false},
+}
+
+func init() {
+	// Trade measures, 310n to 369n, and company internal information
+	for n := 310; n <= 369; n++ {
+		specs[fmt.Sprint(n)] = spec{4, 6, 6, true, false, false}
+	}
+	for n := 410; n <= 417; n++ {
+		specs[fmt.Sprint(n)] = spec{3, 13, 13, true, true, false}
+	}
+	for n := 710; n <= 715; n++ {
+		specs[fmt.Sprint(n)] = spec{3, 1, 20, false, false, false}
+	}
+	for n := 91; n <= 99; n++ {
+		specs[fmt.Sprint(n)] = spec{2, 1, 90, false, false, false}
+	}
+}
+
+// lookup returns the AI at the start of s and its spec
+func lookup(s string) (string, spec, error) {
+	for n := 4; n >= 2; n-- {
+		if len(s) < n {
+			continue
+		}
+		if sp, ok := specs[s[:n]]; ok && len(s) >= sp.length {
+			return s[:sp.length], sp, nil
+		}
+	}
+	return "", spec{}, fmt.Errorf("%w at %.4q", ErrUnknown, s)
+}
+
+// predefined reports if AIs starting with these digits have a predefined
+// length and so never need a separator
+func predefined(ai string) bool {
+	switch ai[:2] {
+	case "00", "01", "02", "03", "04", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20",
+		"31", "32", "33", "34", "35", "36", "41":
+		return true
+	}
+	return false
+}
+
+// cset82 is the GS1 AI encodable character set 82
+const cset82 = "!\"%&'()*+,-./0123456789:;<=>?ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+// Element is an AI with its data
+type Element struct {
+	AI    string
+	Value string
+}
+
+// specOf returns the spec of the AI
+func specOf(ai string) (spec, bool) {
+	sp, ok := specs[ai]
+	if !ok && len(ai) == 4 {
+		sp, ok = specs[ai[:3]]
+	}
+	return sp, ok && sp.length == len(ai)
+}
+
+// Format returns the length range of the data of the AI, and whether it's
+// numeric
+func Format(ai string) (min, max int, numeric bool, err error) {
+	sp, ok := specOf(ai)
+	if !ok {
+		return 0, 0, false, fmt.Errorf("%w (%s)", ErrUnknown, ai)
+	}
+	return sp.min, sp.max, sp.numeric, nil
+}
+
+// Validate checks the value against the format of the AI
+func (e Element) Validate() error {
+
+	sp, ok := specOf(e.AI)
+	if !ok {
+		return fmt.Errorf("%w (%s)", ErrUnknown, e.AI)
+	}
+	if len(e.Value) < sp.min || len(e.Value) > sp.max {
+		return fmt.Errorf("(%s) %w %d", e.AI, gtin.ErrLength, len(e.Value))
+	}
+	for n := 0; n < len(e.Value); n++ {
+		c := e.Value[n]
+		if sp.numeric && (c < '0' || c > '9') {
+			return fmt.Errorf("(%s) %w", e.AI, &gtin.PositionError{Err: gtin.ErrDigit, Char: c, Pos: n})
+		}
+		if strings.IndexByte(cset82, c) < 0 {
+			return fmt.Errorf("(%s) %w", e.AI, &gtin.PositionError{Err: gtin.ErrCharacter, Char: c, Pos: n})
+		}
+	}
+	if sp.check {
+		digits := make([]uint8, len(e.Value))
+		for n := range e.Value {
+			digits[n] = e.Value[n] - '0'
+		}
+		if (gtin.GS1Mod10{}).CheckDigit(digits[:len(digits)-1]) != digits[len(digits)-1] {
+			return fmt.Errorf("(%s) %w", e.AI, gtin.ErrCheckDigit)
+		}
+	}
+	if sp.date {
+		month, day := e.Value[2:4], e.Value[4:6]
+		if month < "01" || month > "12" || day > "31" {
+			return fmt.Errorf("%w: (%s) %s is not a date", ErrSyntax, e.AI, e.Value)
+		}
+	}
+	return nil
+}
+
+// Elements is an element string
+type Elements []Element
+
+// Parse parses and validates an element string in raw or human readable
+// form. A leading GS, for an FNC1 in first position, is ignored.
+func Parse(s string) (Elements, error) {
+	if strings.HasPrefix(s, "(") {
+		return parseHRI(s)
+	}
+	return parseRaw(strings.TrimPrefix(s, string(GS)))
+}
+
+// parseRaw parses an element string with variable length fields ended by
+// GS
+func parseRaw(s string) (Elements, error) {
+
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty", ErrSyntax)
+	}
+	var es Elements
+	for s != "" {
+		ai, sp, err := lookup(s)
+		if err != nil {
+			return nil, err
+		}
+		s = s[len(ai):]
+
+		var value string
+		if predefined(ai) {
+			if len(s) < sp.max {
+				return nil, fmt.Errorf("(%s) %w %d", ai, gtin.ErrLength, len(s))
+			}
+			value, s = s[:sp.max], s[sp.max:]
+			s = strings.TrimPrefix(s, string(GS))
+		} else {
+			end := strings.IndexByte(s, GS)
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[min(end+1, len(s)):]
+		}
+
+		e := Element{ai, value}
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+	return es, nil
+}
+
+// parseHRI parses an element string with AIs in parentheses
+func parseHRI(s string) (Elements, error) {
+
+	var es Elements
+	for s != "" {
+		end := strings.IndexByte(s, ')')
+		if s[0] != '(' || end < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrSyntax, s)
+		}
+		ai := s[1:end]
+		s = s[end+1:]
+
+		// Values may contain parentheses, so only a parenthesized number
+		// starts the next element
+		next := len(s)
+		for n := strings.IndexByte(s, '('); n >= 0; {
+			if close := strings.IndexByte(s[n:], ')'); close > 2 && isDigits(s[n+1:n+close]) {
+				next = n
+				break
+			}
+			m := strings.IndexByte(s[n+1:], '(')
+			if m < 0 {
+				break
+			}
+			n += m + 1
+		}
+
+		e := Element{ai, s[:next]}
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+		s = s[next:]
+	}
+	if es == nil {
+		return nil, fmt.Errorf("%w: empty", ErrSyntax)
+	}
+	return es, nil
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// String returns the human readable form, e.g. (01)09506000134352(10)ABC
+func (es Elements) String() string {
+	var s strings.Builder
+	for _, e := range es {
+		s.WriteString("(" + e.AI + ")" + e.Value)
+	}
+	return s.String()
+}
+
+// Raw returns the raw form, with GS after every field without predefined
+// length but the last
+func (es Elements) Raw() string {
+	var s strings.Builder
+	for n, e := range es {
+		s.WriteString(e.AI + e.Value)
+		if n < len(es)-1 && !predefined(e.AI) {
+			s.WriteByte(GS)
+		}
+	}
+	return s.String()
+}
+
+// Get returns the value of the first element with the AI
+func (es Elements) Get(ai string) (string, bool) {
+	for _, e := range es {
+		if e.AI == ai {
+			return e.Value, true
+		}
+	}
+	return "", false
+}
+
+// GTIN returns the GTIN of AI 01, or ErrSyntax if there is none
+func (es Elements) GTIN() (gtin.GTIN, error) {
+	v, ok := es.Get(gtin.AIGTIN)
+	if !ok {
+		return gtin.GTIN{}, fmt.Errorf("%w: no (%s)", ErrSyntax, gtin.AIGTIN)
+	}
+	return gtin.Parse(v)
+}
@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestParse(t *testing.T) {
+
+	want := Elements{{"01", "09506000134352"}, {"10", "ABC123"}, {"17", "251231"}, {"3103", "000750"}, {"21", "S/N(1)"}}
+	for _, s := range []string{
+		"(01)09506000134352(10)ABC123(17)251231(3103)000750(21)S/N(1)",
+		"010950600013435210ABC123\x1d17251231310300075021S/N(1)",
+		"\x1d010950600013435210ABC123\x1d17251231\x1d310300075021S/N(1)",
+	} {
+		es, err := Parse(s)
+		if err != nil {
+			t.Fatalf("%q: %v", s, err)
+		}
+		if es.String() != want.String() || len(es) != len(want) {
+			t.Errorf("%q: got %v", s, es)
+		}
+	}
+	if raw := want.Raw(); raw != "010950600013435210ABC123\x1d17251231310300075021S/N(1)" {
+		t.Errorf("got raw %q", raw)
+	}
+	if gt, err := want.GTIN(); err != nil || gt.String() != "09506000134352" {
+		t.Errorf("got %v, %v", gt, err)
+	}
+	if v, ok := want.Get("17"); !ok || v != "251231" {
+		t.Errorf("got %q", v)
+	}
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrSyntax},
+		{"0109506000134353", gtin.ErrCheckDigit},
+		{"01095060001343", gtin.ErrLength},
+		{"17251331", ErrSyntax},
+		{"9906000134352", nil},
+		{"89123", ErrUnknown},
+		{"(01)09506000134352(10)", gtin.ErrLength},
+		{"(01)0950600013435A", gtin.ErrDigit},
+		{"10AB\tC", gtin.ErrCharacter},
+		{"01)09506000134352", gtin.ErrDigit},
+		{"(01", ErrSyntax},
+		{"(5)1", ErrUnknown},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("%q: wanted %v, got %v", tt.input, tt.want, err)
+		}
+	}
+
+	var pe *gtin.PositionError
+	if _, err := Parse("10AB\tC"); !errors.As(err, &pe) || pe.Pos != 2 {
+		t.Errorf("wanted position 2, got %v", err)
+	}
+	if _, err := (Elements{}).GTIN(); !errors.Is(err, ErrSyntax) {
+		t.Errorf("wanted ErrSyntax, got %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+
+	tests := []struct {
+		ai       string
+		min, max int
+		numeric  bool
+	}{
+		{"01", 14, 14, true},
+		{"10", 1, 20, false},
+		{"3103", 6, 6, true},
+		{"8200", 1, 70, false},
+	}
+	for _, tt := range tests {
+		min, max, numeric, err := Format(tt.ai)
+		if err != nil || min != tt.min || max != tt.max || numeric != tt.numeric {
+			t.Errorf("%s: got %d, %d, %v, %v", tt.ai, min, max, numeric, err)
+		}
+	}
+	for _, ai := range []string{"310", "0", "999", "01234"} {
+		if _, _, _, err := Format(ai); !errors.Is(err, ErrUnknown) {
+			t.Errorf("%s: wanted ErrUnknown, got %v", ai, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package gtin
+
+import "io"
+
+// AuditWriter is an io.Writer that passes data through unchanged while
+// counting the candidate GTINs in it, see ScanGTINs, in Stats. Export jobs
+// use it to audit files as they are written, without reading them back:
+//
+//	a := gtin.NewAuditWriter(f)
+//	export(a)
+//	a.Close()
+//	report := a.Stats().Report(10)
+type AuditWriter struct {
+	w     io.Writer
+	stats Stats
+	run   []byte // Digits at the end of the data so far
+	long  bool   // The run is longer than a GTIN
+}
+
+// NewAuditWriter returns an AuditWriter writing to w
+func NewAuditWriter(w io.Writer) *AuditWriter {
+	return &AuditWriter{w: w, run: make([]byte, 0, GTIN_LENGTH)}
+}
+
+// Write writes p to the underlying writer and scans the bytes written
+func (a *AuditWriter) Write(p []byte) (int, error) {
+	n, err := a.w.Write(p)
+	for _, b := range p[:n] {
+		switch {
+		case !isDigit(b):
+			a.end()
+		case len(a.run) == GTIN_LENGTH:
+			a.long = true
+		default:
+			a.run = append(a.run, b)
+		}
+	}
+	return n, err
+}
+
+// end counts the run of digits, if it's a candidate
+func (a *AuditWriter) end() {
+	if !a.long && isCandidate(len(a.run)) {
+		a.stats.Add(Parse(string(a.run)))
+	}
+	a.run, a.long = a.run[:0], false
+}
+
+// Close counts the candidate at the end of the data, if any. It does not
+// close the underlying writer.
+func (a *AuditWriter) Close() error {
+	a.end()
+	return nil
+}
+
+// Stats returns the statistics of the candidates written so far
+func (a *AuditWriter) Stats() *Stats {
+	return &a.stats
+}
@@ -0,0 +1,48 @@
+package gtin
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAuditWriter(t *testing.T) {
+
+	input := "sku,gtin\nA1,4006381333931\nA2,4006381333932\nA3,00614141000012345\nA4,614141000012"
+	var out bytes.Buffer
+	a := NewAuditWriter(&out)
+
+	// Candidates span writes
+	if _, err := io.Copy(a, iotest.OneByteReader(strings.NewReader(input))); err != nil {
+		t.Fatal(err)
+	}
+	a.Close()
+	if out.String() != input {
+		t.Errorf("data changed: %q", out.String())
+	}
+	report := a.Stats().Report(0)
+	if report.Total != 3 || report.Valid != 2 || report.Errors[ErrCheckDigit.(*Error).Code()] != 1 {
+		t.Errorf("got %+v", report)
+	}
+
+	// Only bytes written are scanned
+	w := NewAuditWriter(shortWriter(14))
+	n, err := w.Write([]byte("4006381333931 96385074"))
+	w.Close()
+	if n != 14 || !errors.Is(err, io.ErrShortWrite) || w.Stats().Report(0).Total != 1 {
+		t.Errorf("got %d, %v, %+v", n, err, w.Stats().Report(0))
+	}
+}
+
+// shortWriter writes at most its number of bytes
+type shortWriter int
+
+func (w shortWriter) Write(p []byte) (int, error) {
+	if len(p) > int(w) {
+		return int(w), io.ErrShortWrite
+	}
+	return len(p), nil
+}
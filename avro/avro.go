@@ -0,0 +1,130 @@
+/*
+Package avro defines the Avro logical type gtin, so pipelines governed by a
+schema registry carry validated GTINs instead of raw strings.
+
+The logical type annotates either a string, holding the GTIN-14 digits, or
+a fixed of 6 bytes, holding the key of gtin.GTIN.Key:
+
+	{"type": "string", "logicalType": "gtin"}
+	{"type": "fixed", "name": "gtin", "size": 6, "logicalType": "gtin"}
+
+The package doesn't import an Avro implementation. A Codec converts GTINs
+to and from the native values Avro libraries such as goavro use, string
+and []byte, and reads and writes the Avro binary encoding directly.
+Decoding always validates.
+*/
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+)
+
+// LogicalType is the name of the logical type
+const LogicalType = "gtin"
+
+// Schemas of the logical type
+const (
+	StringSchema = `{"type":"string","logicalType":"gtin"}`
+	FixedSchema  = `{"type":"fixed","name":"gtin","size":6,"logicalType":"gtin"}`
+)
+
+// ErrNative is returned when decoding a native value of the wrong Go type
+var ErrNative = errors.New("avro: wrong native type for gtin")
+
+// Codec encodes and decodes the logical type
+type Codec struct {
+	Fixed  bool         // fixed[6] instead of string
+	Policy *gtin.Policy // Validates decoded GTINs, gtin.DefaultPolicy() if nil
+}
+
+// Schema returns the schema of the codec's underlying type
+func (c Codec) Schema() string {
+	if c.Fixed {
+		return FixedSchema
+	}
+	return StringSchema
+}
+
+// Native returns the GTIN as a string of 14 digits, or the 6-byte key for
+// fixed
+func (c Codec) Native(gt gtin.GTIN) any {
+	if c.Fixed {
+		key := gt.Key()
+		return key[:]
+	}
+	return gt.String()
+}
+
+// FromNative returns the validated GTIN of a native string or []byte.
+// Strings may be any GTIN form, fixed values decode to GTIN-14s.
+func (c Codec) FromNative(native any) (gtin.GTIN, error) {
+
+	var (
+		gt  gtin.GTIN
+		err error
+	)
+	switch v := native.(type) {
+	case string:
+		if c.Fixed {
+			return gtin.GTIN{}, fmt.Errorf("%w: string", ErrNative)
+		}
+		gt, err = gtin.Atog(v)
+	case []byte:
+		if !c.Fixed {
+			return gtin.GTIN{}, fmt.Errorf("%w: []byte", ErrNative)
+		}
+		gt, err = gtin.FromKey(v)
+	default:
+		return gtin.GTIN{}, fmt.Errorf("%w: %T", ErrNative, native)
+	}
+	if err != nil {
+		return gtin.GTIN{}, err
+	}
+
+	policy := c.Policy
+	if policy == nil {
+		p := gtin.DefaultPolicy()
+		policy = &p
+	}
+	if report := policy.Validate(gt); !report.OK() {
+		return gtin.GTIN{}, errors.Join(report.Errors...)
+	}
+	return gt, nil
+}
+
+// AppendBinary appends the Avro binary encoding of the GTIN: a zig-zag
+// varint length and the digits for string, the 6 bytes for fixed
+func (c Codec) AppendBinary(dst []byte, gt gtin.GTIN) []byte {
+	if c.Fixed {
+		key := gt.Key()
+		return append(dst, key[:]...)
+	}
+	s := gt.String()
+	dst = binary.AppendVarint(dst, int64(len(s)))
+	return append(dst, s...)
+}
+
+// ReadBinary decodes and validates a GTIN at the start of b and returns
+// the number of bytes read
+func (c Codec) ReadBinary(b []byte) (gtin.GTIN, int, error) {
+
+	if c.Fixed {
+		if len(b) < gtin.KEY_SIZE {
+			return gtin.GTIN{}, 0, fmt.Errorf("%w %d of fixed", gtin.ErrLength, len(b))
+		}
+		gt, err := c.FromNative(b[:gtin.KEY_SIZE])
+		return gt, gtin.KEY_SIZE, err
+	}
+
+	length, n := binary.Varint(b)
+	if n <= 0 || length < 0 || int64(len(b)-n) < length {
+		return gtin.GTIN{}, 0, fmt.Errorf("%w of string", gtin.ErrLength)
+	}
+	end := n + int(length)
+	gt, err := c.FromNative(string(b[n:end]))
+	return gt, end, err
+}
@@ -0,0 +1,63 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestSchemas(t *testing.T) {
+	for _, schema := range []string{StringSchema, FixedSchema} {
+		var v map[string]any
+		if err := json.Unmarshal([]byte(schema), &v); err != nil || v["logicalType"] != LogicalType {
+			t.Errorf("%s: got %v, %v", schema, v, err)
+		}
+	}
+}
+
+func TestNative(t *testing.T) {
+
+	gt := gtin.MustParse("4006381333931")
+	for _, c := range []Codec{{}, {Fixed: true}} {
+		back, err := c.FromNative(c.Native(gt))
+		if err != nil || !gtin.Equal(back, gt) {
+			t.Errorf("%+v: got %v, %v", c, back, err)
+		}
+	}
+
+	if gt, err := (Codec{}).FromNative("614141000012"); err != nil || gt.Type() != gtin.GTIN12 {
+		t.Errorf("got %v, %v", gt, err)
+	}
+	if _, err := (Codec{}).FromNative("4006381333932"); !errors.Is(err, gtin.ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+	if _, err := (Codec{Fixed: true}).FromNative("4006381333931"); !errors.Is(err, ErrNative) {
+		t.Errorf("wanted ErrNative, got %v", err)
+	}
+	if _, err := (Codec{}).FromNative(42); !errors.Is(err, ErrNative) {
+		t.Errorf("wanted ErrNative, got %v", err)
+	}
+}
+
+func TestBinary(t *testing.T) {
+
+	gt := gtin.MustParse("4006381333931")
+
+	b := Codec{}.AppendBinary(nil, gt)
+	if want := append([]byte{28}, "04006381333931"...); !bytes.Equal(b, want) {
+		t.Errorf("wanted %x, got %x", want, b)
+	}
+	for _, c := range []Codec{{}, {Fixed: true}} {
+		b := c.AppendBinary([]byte{0xff}, gt)
+		back, n, err := c.ReadBinary(b[1:])
+		if err != nil || n != len(b)-1 || !gtin.Equal(back, gt) {
+			t.Errorf("%+v: got %v, %d, %v", c, back, n, err)
+		}
+		if _, _, err := c.ReadBinary(b[1 : len(b)-1]); !errors.Is(err, gtin.ErrLength) {
+			t.Errorf("%+v: wanted ErrLength for a short buffer, got %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+// Aztec is the symbology of Aztec Code symbols
+const Aztec = "Aztec Code"
+
+// Aztec Code encodation modes, of the characters used here
+const (
+	aztecUpper = iota
+	aztecLower
+	aztecDigit
+)
+
+// Codes of Aztec Code latches and shifts
+const (
+	aztecPS  = 0  // Punctuation shift, in all modes
+	aztecLL  = 28 // Lower latch, from upper
+	aztecUS  = 28 // Upper shift, from lower
+	aztecDL  = 30 // Digit latch, from upper and lower
+	aztecBS  = 31 // Binary shift, from upper and lower
+	aztecUL  = 14 // Upper latch, from digit
+	aztecFLG = 0  // FLG(n) in punctuation mode, FNC1 for n = 0
+)
+
+// Punctuation mode characters, from code 6
+const aztecPunct = "!\"#$%&'()*+,-./:;<=>?[]{}"
+
+// aztecBits returns the bit string of s, with GS as FNC1. Digits are
+// encoded in digit mode, letters in upper and lower mode, punctuation with
+// a shift and anything else in binary shifts.
+func aztecBits(s string) bits {
+
+	var b bits
+	mode := aztecUpper
+	width := func() int {
+		if mode == aztecDigit {
+			return 4
+		}
+		return 5
+	}
+	toUpperOrLower := func() {
+		if mode == aztecDigit {
+			b.add(aztecUL, 4)
+			mode = aztecUpper
+		}
+	}
+	upper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	lower := func(c byte) bool { return c >= 'a' && c <= 'z' }
+	digit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	for n := 0; n < len(s); n++ {
+		c := s[n]
+		switch {
+		case c == ' ':
+			b.add(1, width())
+		case c == ai.GS:
+			b.add(aztecPS, width())
+			b.add(aztecFLG, 5)
+			b.add(0, 3)
+		case digit(c) || mode == aztecDigit && (c == ',' || c == '.'):
+			if mode != aztecDigit {
+				b.add(aztecDL, 5)
+				mode = aztecDigit
+			}
+			switch c {
+			case ',':
+				b.add(12, 4)
+			case '.':
+				b.add(13, 4)
+			default:
+				b.add(int(c-'0')+2, 4)
+			}
+		case upper(c):
+			switch mode {
+			case aztecLower:
+				if n+1 < len(s) && upper(s[n+1]) {
+					b.add(aztecDL, 5)
+					b.add(aztecUL, 4)
+					mode = aztecUpper
+				} else {
+					b.add(aztecUS, 5)
+				}
+			case aztecDigit:
+				b.add(aztecUL, 4)
+				mode = aztecUpper
+			}
+			b.add(int(c-'A')+2, 5)
+		case lower(c):
+			toUpperOrLower()
+			if mode == aztecUpper {
+				b.add(aztecLL, 5)
+				mode = aztecLower
+			}
+			b.add(int(c-'a')+2, 5)
+		case strings.IndexByte(aztecPunct, c) >= 0:
+			b.add(aztecPS, width())
+			b.add(strings.IndexByte(aztecPunct, c)+6, 5)
+		default:
+			// Binary shift the run of characters without another encoding
+			end := n
+			for end < len(s) && end-n < 31+2047 && !digit(s[end]) && !upper(s[end]) && !lower(s[end]) &&
+				s[end] != ' ' && s[end] != ai.GS && strings.IndexByte(aztecPunct, s[end]) < 0 {
+				end++
+			}
+			toUpperOrLower()
+			b.add(aztecBS, 5)
+			if end-n <= 31 {
+				b.add(end-n, 5)
+			} else {
+				b.add(0, 5)
+				b.add(end-n-31, 11)
+			}
+			for ; n < end; n++ {
+				b.add(int(s[n]), 8)
+			}
+			n--
+		}
+	}
+	return b
+}
+
+// gf is a Galois field GF(2^m) for Reed-Solomon error correction
+type gf struct {
+	exp, log []int
+}
+
+// newGF returns the field of the primitive polynomial
+func newGF(poly, size int) gf {
+	f := gf{make([]int, size), make([]int, size)}
+	x := 1
+	for n := 0; n < size; n++ {
+		f.exp[n] = x
+		x <<= 1
+		if x >= size {
+			x ^= poly
+		}
+	}
+	for n := 0; n < size-1; n++ {
+		f.log[f.exp[n]] = n
+	}
+	return f
+}
+
+func (f gf) mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[(f.log[a]+f.log[b])%(len(f.exp)-1)]
+}
+
+// Fields of the Aztec Code codewords, by codeword size
+var aztecFields = map[int]gf{
+	4:  newGF(0x13, 16),
+	6:  newGF(0x43, 64),
+	8:  newGF(0x12d, 256),
+	10: newGF(0x409, 1024),
+	12: newGF(0x1069, 4096),
+}
+
+// reedSolomon returns the n check words of the data, for a generator
+// polynomial with the roots a^1 to a^n
+func (f gf) reedSolomon(data []int, n int) []int {
+
+	g := []int{1}
+	for i := 1; i <= n; i++ {
+		next := make([]int, len(g)+1)
+		for j, c := range g {
+			next[j] ^= c
+			next[j+1] ^= f.mul(c, f.exp[i])
+		}
+		g = next
+	}
+
+	check := make([]int, n)
+	for _, d := range data {
+		factor := d ^ check[0]
+		copy(check, check[1:])
+		check[n-1] = 0
+		for j := range check {
+			check[j] ^= f.mul(g[j+1], factor)
+		}
+	}
+	return check
+}
+
+// aztecWordSize returns the codeword size of a symbol with that many layers
+func aztecWordSize(layers int) int {
+	switch {
+	case layers <= 2:
+		return 6
+	case layers <= 8:
+		return 8
+	case layers <= 22:
+		return 10
+	}
+	return 12
+}
+
+// stuff splits the bits into codewords, adding a bit to words that would
+// be all zeros or all ones, and pads the last word with ones
+func (b bits) stuff(size int) []int {
+
+	var words []int
+	mask := 1<<size - 2
+	for n := 0; n < len(b); n += size {
+		w := 0
+		for j := 0; j < size; j++ {
+			if n+j >= len(b) || b[n+j] {
+				w |= 1 << (size - 1 - j)
+			}
+		}
+		switch w & mask {
+		case mask:
+			words = append(words, w&mask)
+			n--
+		case 0:
+			words = append(words, w|1)
+			n--
+		default:
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// Minimum error correction of Aztec Code symbols, as a percentage of the
+// data and 3 codewords
+const aztecECC = 23
+
+// EncodeAztec returns the Aztec Code symbol of the data, such as a GS1
+// Digital Link URI, in the smallest size with at least 23% error
+// correction. The rows of the symbol are its rows of modules.
+func EncodeAztec(data string) (Barcode, error) {
+
+	return aztec(aztecBits(data), data)
+}
+
+// EncodeAztecGS1 returns the Aztec Code symbol of the element strings, with
+// FNC1 in first position
+func EncodeAztecGS1(es ai.Elements) (Barcode, error) {
+
+	if len(es) == 0 {
+		return Barcode{}, fmt.Errorf("barcode: %w", ai.ErrSyntax)
+	}
+	for _, e := range es {
+		if err := e.Validate(); err != nil {
+			return Barcode{}, fmt.Errorf("barcode: %w", err)
+		}
+	}
+	return aztec(aztecBits(string(ai.GS)+es.Raw()), es.String())
+}
+
+// aztec returns the symbol of the bit string, in compact symbols of 1 to 4
+// layers or full range symbols of 4 to 32 layers
+func aztec(b bits, text string) (Barcode, error) {
+
+	for i := 0; i <= 32; i++ {
+		compact := i < 4
+		layers := i
+		if compact {
+			layers = i + 1
+		}
+		capacity := (88 + 16*layers) * layers
+		if !compact {
+			capacity = (112 + 16*layers) * layers
+		}
+		size := aztecWordSize(layers)
+		words := b.stuff(size)
+		total := capacity / size
+		if compact && len(words) > 64 || len(words) > 2048 {
+			continue
+		}
+		if len(words)+(len(words)*aztecECC+99)/100+3 > total {
+			continue
+		}
+
+		all := append(words, aztecFields[size].reedSolomon(words, total-len(words))...)
+		var message bits
+		message.add(0, capacity%size)
+		for _, w := range all {
+			message.add(w, size)
+		}
+		return Barcode{Symbology: Aztec, Text: text, Rows: aztecMatrix(message, compact, layers, len(words))}, nil
+	}
+	return Barcode{}, fmt.Errorf("barcode: %d bits for %s: %w", len(b), Aztec, gtin.ErrLength)
+}
+
+// aztecMatrix places the message, mode message, finder pattern and
+// reference grid of a symbol
+func aztecMatrix(message bits, compact bool, layers, words int) []Row {
+
+	base := 14 + 4*layers
+	if compact {
+		base = 11 + 4*layers
+	}
+	size := base
+	align := make([]int, base)
+	for n := range align {
+		align[n] = n
+	}
+	if !compact {
+		// Full range symbols have a line of the reference grid every 16
+		// modules from the center
+		size = base + 1 + 2*((base/2-1)/15)
+		orig, center := base/2, size/2
+		for n := 0; n < orig; n++ {
+			offset := n + n/15
+			align[orig-n-1] = center - offset - 1
+			align[orig+n] = center + offset + 1
+		}
+	}
+	m := make([][]bool, size)
+	for n := range m {
+		m[n] = make([]bool, size)
+	}
+	set := func(x, y int) { m[y][x] = true }
+
+	// The layers spiral inwards counterclockwise from the top left, in
+	// dominoes of two modules
+	offset := 0
+	for i := 0; i < layers; i++ {
+		rowSize := 4*(layers-i) + 12
+		if compact {
+			rowSize = 4*(layers-i) + 9
+		}
+		for j := 0; j < rowSize; j++ {
+			for k := 0; k < 2; k++ {
+				at := offset + 2*j + k
+				if message[at] {
+					set(align[2*i+k], align[2*i+j])
+				}
+				if message[at+2*rowSize] {
+					set(align[2*i+j], align[base-1-2*i-k])
+				}
+				if message[at+4*rowSize] {
+					set(align[base-1-2*i-k], align[base-1-2*i-j])
+				}
+				if message[at+6*rowSize] {
+					set(align[base-1-2*i-j], align[2*i+k])
+				}
+			}
+		}
+		offset += 8 * rowSize
+	}
+
+	// The mode message holds the size of the symbol and its message
+	var mode bits
+	center := size / 2
+	if compact {
+		mode.add(layers-1, 2)
+		mode.add(words-1, 6)
+		mode = aztecModeMessage(mode, 7)
+		for n := 0; n < 7; n++ {
+			at := center - 3 + n
+			if mode[n] {
+				set(at, center-5)
+			}
+			if mode[n+7] {
+				set(center+5, at)
+			}
+			if mode[20-n] {
+				set(at, center+5)
+			}
+			if mode[27-n] {
+				set(center-5, at)
+			}
+		}
+	} else {
+		mode.add(layers-1, 5)
+		mode.add(words-1, 11)
+		mode = aztecModeMessage(mode, 10)
+		for n := 0; n < 10; n++ {
+			at := center - 5 + n + n/5
+			if mode[n] {
+				set(at, center-7)
+			}
+			if mode[n+10] {
+				set(center+7, at)
+			}
+			if mode[29-n] {
+				set(at, center+7)
+			}
+			if mode[39-n] {
+				set(center-7, at)
+			}
+		}
+	}
+
+	// The finder pattern of dark rings, with orientation patterns at the
+	// corners
+	rings := 5
+	if !compact {
+		rings = 7
+	}
+	for r := 0; r < rings; r += 2 {
+		for n := center - r; n <= center+r; n++ {
+			set(n, center-r)
+			set(n, center+r)
+			set(center-r, n)
+			set(center+r, n)
+		}
+	}
+	set(center-rings, center-rings)
+	set(center-rings+1, center-rings)
+	set(center-rings, center-rings+1)
+	set(center+rings, center-rings)
+	set(center+rings, center-rings+1)
+	set(center+rings, center+rings-1)
+
+	if !compact {
+		for i, j := 0, 0; i < base/2-1; i, j = i+15, j+16 {
+			for k := center & 1; k < size; k += 2 {
+				set(center-j, k)
+				set(center+j, k)
+				set(k, center-j)
+				set(k, center+j)
+			}
+		}
+	}
+
+	rows := make([]Row, size)
+	for n := range m {
+		rows[n] = Row{m[n], 1}
+	}
+	return rows
+}
+
+// aztecModeMessage returns the mode message with its check words, in
+// total words of 4 bits
+func aztecModeMessage(b bits, total int) bits {
+
+	words := b.stuffless(4)
+	all := append(words, aztecFields[4].reedSolomon(words, total-len(words))...)
+	var mode bits
+	for _, w := range all {
+		mode.add(w, 4)
+	}
+	return mode
+}
+
+// stuffless splits the bits into words without bit stuffing
+func (b bits) stuffless(size int) []int {
+	words := make([]int, len(b)/size)
+	for n, bit := range b {
+		if bit {
+			words[n/size] |= 1 << (size - 1 - n%size)
+		}
+	}
+	return words
+}
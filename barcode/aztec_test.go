@@ -0,0 +1,154 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+func TestAztecBits(t *testing.T) {
+
+	tests := []struct {
+		s    string
+		want string
+	}{
+		// A in upper mode, a digit latch and 1
+		{"A1", "00010" + "11110" + "0011"},
+		// A lower latch, a punctuation shift for the slash
+		{"a/b", "11100" + "00010" + "00000" + "10100" + "00011"},
+		// FNC1 in first position, then digits
+		{"\x1d01", "00000" + "00000" + "000" + "11110" + "0010" + "0011"},
+		// Binary shift from digit mode, through upper mode
+		{"1é", "11110" + "0011" + "1110" + "11111" + "00010" + "11000011" + "10101001"},
+	}
+	for _, tt := range tests {
+		if got := bitString(aztecBits(tt.s)); got != tt.want {
+			t.Errorf("%q: got %s, wanted %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestStuff(t *testing.T) {
+
+	// All ones and all zeros get a stuffed bit, the last word is padded
+	// with ones
+	b := bits{true, true, true, true, true, true, false, false, false, false, false, false}
+	got := b.stuff(6)
+	if want := []int{0b111110, 0b100000, 0b011111}; !slices.Equal(got, want) {
+		t.Errorf("got %b, wanted %b", got, want)
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+
+	// The check words make the syndromes zero
+	f := aztecFields[6]
+	data := []int{5, 17, 63, 0, 41}
+	all := append(data, f.reedSolomon(data, 7)...)
+	for i := 1; i <= 7; i++ {
+		v := 0
+		for _, c := range all {
+			v = f.mul(v, f.exp[i]) ^ c
+		}
+		if v != 0 {
+			t.Errorf("syndrome %d is %d", i, v)
+		}
+	}
+}
+
+// dark reports if the module at x, y is dark
+func dark(b Barcode, x, y int) bool {
+	return b.Rows[y].Bars[x]
+}
+
+func TestEncodeAztec(t *testing.T) {
+
+	tests := []struct {
+		data string
+		size int
+	}{
+		{"123", 15},
+		{"https://id.gs1.org/01/09501101530003/10/AB1", 23},
+		{strings.Repeat("GS1 Digital Link ", 20), 61},
+	}
+	for _, tt := range tests {
+		b, err := EncodeAztec(tt.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b.Rows) != tt.size || len(b.Rows[0].Bars) != tt.size || b.Symbology != Aztec {
+			t.Fatalf("%q: got %d rows of %d", tt.data, len(b.Rows), len(b.Rows[0].Bars))
+		}
+
+		// The bullseye of dark and light rings around a dark center
+		c := tt.size / 2
+		rings := 5
+		if tt.size > 27 {
+			rings = 7
+		}
+		for r := 0; r < rings; r++ {
+			if dark(b, c+r, c) != (r%2 == 0) || dark(b, c, c-r) != (r%2 == 0) {
+				t.Errorf("%q: ring %d", tt.data, r)
+			}
+		}
+		// Orientation patterns at the corners of the mode message
+		r := rings
+		if !dark(b, c-r, c-r) || !dark(b, c-r+1, c-r) || !dark(b, c+r, c-r+1) || !dark(b, c+r, c+r-1) || dark(b, c+r, c+r) || dark(b, c-r, c+r) {
+			t.Errorf("%q: orientation patterns", tt.data)
+		}
+	}
+
+	// The mode message of a compact symbol, clockwise from the top left,
+	// is 1 layer and 3 data words with 5 check words
+	b, _ := EncodeAztec("123")
+	var mode bits
+	for n := 0; n < 7; n++ {
+		mode = append(mode, dark(b, 4+n, 2))
+	}
+	for n := 0; n < 7; n++ {
+		mode = append(mode, dark(b, 12, 4+n))
+	}
+	for n := 0; n < 7; n++ {
+		mode = append(mode, dark(b, 10-n, 12))
+	}
+	for n := 0; n < 7; n++ {
+		mode = append(mode, dark(b, 2, 10-n))
+	}
+	words := mode.stuffless(4)
+	if words[0] != 0b0000 || words[1] != 0b0010 {
+		t.Errorf("mode message %v", words)
+	}
+	for i := 1; i <= 5; i++ {
+		v := 0
+		for _, w := range words {
+			v = aztecFields[4].mul(v, aztecFields[4].exp[i]) ^ w
+		}
+		if v != 0 {
+			t.Errorf("mode message syndrome %d is %d", i, v)
+		}
+	}
+
+	es, _ := ai.Parse("(01)09501101530003")
+	b, err := EncodeAztecGS1(es)
+	if err != nil || len(b.Rows) != 19 || b.Text != "(01)09501101530003" {
+		t.Errorf("got %d rows, %q, %v", len(b.Rows), b.Text, err)
+	}
+	// Rendered in rows, with the text escaped
+	b, _ = EncodeAztec("https://example.com/01/09501101530003?a=1&b=2")
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, b, WithHRI(HRINone)); err != nil || !strings.Contains(buf.String(), "&amp;b=2") {
+		t.Errorf("got %s, %v", buf.String(), err)
+	}
+
+	if _, err := EncodeAztecGS1(nil); !errors.Is(err, ai.ErrSyntax) {
+		t.Errorf("wanted ErrSyntax, got %v", err)
+	}
+	if _, err := EncodeAztec(strings.Repeat("\x00", 4000)); !errors.Is(err, gtin.ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Package barcode renders GTINs as EAN-13, EAN-8, UPC-A and ITF-14 symbols,
+and as GS1 DataBar.
+
+A GTIN is first encoded into a Barcode, a row of modules, which can then be
+written as SVG or PNG, or for prepress as EPS or PDF with bar width
+reduction. Label printers get EPL2 or IPL commands instead, and render the
+symbol themselves.
+
+Specification returns the physical limits of each symbology, such as the
+X-dimension range, quiet zones and minimum bar heights, so that artwork can
+be checked before it's printed. Calculate gives the printed size of a
+symbol at a magnification and printer resolution.
+
+EncodeDataBar encodes a GTIN as GS1 DataBar Omnidirectional or Stacked, the
+latter in rows. EncodeDataBarExpanded takes the element strings of package
+ai, for weight, dates and lots in the symbol.
+
+The element strings and GS1 Digital Link URIs can also be carried in 2D
+symbols: EncodeAztec gives an Aztec Code symbol in rows of modules, rendered
+like the others.
+
+VerifyGS1128 checks the raw Code 128 symbol characters of a scanned GS1-128
+symbol and parses its element string, see package ai. EncodeGS1128 does the
+reverse, giving the symbol characters for backends that draw Code 128.
+*/
+package barcode
+
+import (
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Auto selects the symbology from the GTIN's Carrier()
+const Auto = ""
+
+// Quiet zones, in modules, to the left and right of each symbology
+var quietZones = map[string][2]int{
+	gtin.EAN13: {11, 7},
+	gtin.EAN8:  {7, 7},
+	gtin.UPCA:  {9, 9},
+	gtin.ITF14: {10, 10},
+}
+
+// Barcode is a GTIN encoded as a row of modules, the narrowest bar or space
+type Barcode struct {
+	Symbology string
+	Text      string // The human readable interpretation
+	Bars      []bool // true for a bar module, false for a space
+	Guards    []bool // true for modules of guard patterns, drawn extended
+
+	// Rows of stacked symbols, from the top, in place of Bars
+	Rows []Row
+}
+
+// width returns the number of modules of the widest row
+func (b Barcode) width() int {
+	w := len(b.Bars)
+	for _, r := range b.Rows {
+		w = max(w, len(r.Bars))
+	}
+	return w
+}
+
+// QuietZone returns the number of blank modules needed to the left and
+// right of the symbol
+func (b Barcode) QuietZone() (left, right int) {
+	q := quietZones[b.Symbology]
+	return q[0], q[1]
+}
+
+// Encode returns the barcode of the GTIN in the given symbology, or in the
+// symbology of its Carrier() if Auto
+func Encode(gt gtin.GTIN, symbology string) (Barcode, error) {
+
+	if !gt.Valid() {
+		return Barcode{}, fmt.Errorf("barcode: %w", gtin.ErrCheckDigit)
+	}
+	if symbology == Auto {
+		symbology = gt.Carrier()
+	}
+
+	b := Barcode{Symbology: symbology, Text: gt.HRI()}
+	s := gt.String()
+	var err error
+	switch symbology {
+	case gtin.EAN13:
+		err = b.ean(s, 13)
+	case gtin.UPCA:
+		err = b.ean(s, 12)
+	case gtin.EAN8:
+		err = b.ean(s, 8)
+	case gtin.ITF14:
+		b.itf(s)
+	default:
+		return Barcode{}, fmt.Errorf("barcode: %s has no symbology: %w", gt, gtin.ErrCarrier)
+	}
+	if err != nil {
+		return Barcode{}, err
+	}
+	return b, nil
+}
+
+// add appends the pattern, a string of 0 and 1
+func (b *Barcode) add(pattern string, guard bool) {
+	for _, c := range pattern {
+		b.Bars = append(b.Bars, c == '1')
+		b.Guards = append(b.Guards, guard)
+	}
+}
+
+// Left-hand odd (L) patterns of EAN/UPC digits. Right-hand (R) patterns are
+// their complement and even (G) patterns the reverse of R.
+var eanL = [10]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+// Odd/even parity of the left half of an EAN-13, encoding the first digit
+var eanParity = [10]string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+func eanR(d byte) string {
+	r := []byte(eanL[d-'0'])
+	for n := range r {
+		r[n] ^= 1
+	}
+	return string(r)
+}
+
+func eanG(d byte) string {
+	r := []byte(eanR(d))
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// ean encodes the last length digits of the 14-digit s as EAN-13, UPC-A
+// or EAN-8. UPC-A is an EAN-13 with a leading 0.
+func (b *Barcode) ean(s string, length int) error {
+
+	for _, c := range s[:gtin.GTIN_LENGTH-length] {
+		if c != '0' {
+			return fmt.Errorf("barcode: %s does not fit %s: %w", s, b.Symbology, gtin.ErrNotConvertible)
+		}
+	}
+	if length == 12 {
+		length = 13
+	}
+	s = s[gtin.GTIN_LENGTH-length:]
+
+	parity := "LLLL"
+	if length == 13 {
+		parity = eanParity[s[0]-'0']
+		s = s[1:]
+	}
+	half := len(s) / 2
+
+	b.add("101", true)
+	for n := 0; n < half; n++ {
+		if parity[n] == 'G' {
+			b.add(eanG(s[n]), false)
+		} else {
+			b.add(eanL[s[n]-'0'], false)
+		}
+	}
+	b.add("01010", true)
+	for n := half; n < len(s); n++ {
+		b.add(eanR(s[n]), false)
+	}
+	b.add("101", true)
+	return nil
+}
+
+// Narrow (0) and wide (1) elements of ITF digits
+var itfDigits = [10]string{
+	"00110", "10001", "01001", "11000", "00101",
+	"10100", "01100", "00011", "10010", "01010",
+}
+
+// Width of a wide ITF element in modules, within the 2.25-3.0 range of
+// the GS1 specifications
+const itfWide = 3
+
+// itf encodes the 14 digits of s as ITF-14. Digits are interleaved in
+// pairs, the first on the bars and the second on the spaces.
+func (b *Barcode) itf(s string) {
+
+	element := func(wide, bar bool) {
+		width := 1
+		if wide {
+			width = itfWide
+		}
+		for ; width > 0; width-- {
+			b.Bars = append(b.Bars, bar)
+			b.Guards = append(b.Guards, false)
+		}
+	}
+
+	b.add("1010", false)
+	for n := 0; n < len(s); n += 2 {
+		bars, spaces := itfDigits[s[n]-'0'], itfDigits[s[n+1]-'0']
+		for e := 0; e < 5; e++ {
+			element(bars[e] == '1', true)
+			element(spaces[e] == '1', false)
+		}
+	}
+	element(true, true)
+	b.add("01", false)
+}
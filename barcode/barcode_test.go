@@ -0,0 +1,137 @@
+package barcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+// modules returns the bars as a string of 0 and 1
+func modules(b Barcode) string {
+	var s strings.Builder
+	for _, bar := range b.Bars {
+		if bar {
+			s.WriteByte('1')
+		} else {
+			s.WriteByte('0')
+		}
+	}
+	return s.String()
+}
+
+// decodeEAN reads back the digits of an EAN-13, UPC-A or EAN-8
+func decodeEAN(t *testing.T, m string) string {
+
+	var digits, parity string
+	m = m[3 : len(m)-3]
+	half := (len(m) - 5) / 2
+	for n := 0; n < len(m); n += 7 {
+		if n == half {
+			n -= 2
+			continue
+		}
+		found := false
+		for d := byte('0'); d <= '9'; d++ {
+			switch m[n : n+7] {
+			case eanL[d-'0']:
+				parity += "L"
+			case eanG(d):
+				parity += "G"
+			case eanR(d):
+			default:
+				continue
+			}
+			digits += string(d)
+			found = true
+			break
+		}
+		if !found {
+			t.Fatalf("no digit for %s", m[n:n+7])
+		}
+	}
+	if len(parity) == 6 {
+		for d, p := range eanParity {
+			if p == parity {
+				return string(rune('0'+d)) + digits
+			}
+		}
+	}
+	return digits
+}
+
+func TestEncodeEAN(t *testing.T) {
+
+	tests := []struct {
+		code, symbology, want string
+		length                int
+	}{
+		{"4006381333931", Auto, "4006381333931", 95},
+		{"5901234123457", gtin.EAN13, "5901234123457", 95},
+		{"614141000012", Auto, "0614141000012", 95},
+		{"96385074", Auto, "96385074", 67},
+		{"00614141000012", gtin.UPCA, "0614141000012", 95},
+	}
+
+	for _, test := range tests {
+		b, err := Encode(gtin.MustParse(test.code), test.symbology)
+		if err != nil {
+			t.Fatalf("%s: %v", test.code, err)
+		}
+		m := modules(b)
+		if len(m) != test.length || len(b.Guards) != test.length {
+			t.Errorf("%s: wanted %d modules, got %d", test.code, test.length, len(m))
+			continue
+		}
+		if got := decodeEAN(t, m); got != test.want {
+			t.Errorf("%s: decoded %s", test.code, got)
+		}
+	}
+}
+
+func TestEncodeITF14(t *testing.T) {
+
+	b, err := Encode(gtin.MustParse("50614141000994"), Auto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Symbology != gtin.ITF14 {
+		t.Fatalf("wanted ITF-14, got %s", b.Symbology)
+	}
+	m := modules(b)
+	if len(m) != 4+7*2*(3+2*itfWide)+itfWide+2 {
+		t.Errorf("got %d modules", len(m))
+	}
+	if !strings.HasPrefix(m, "1010") || !strings.HasSuffix(m, "11101") {
+		t.Errorf("wrong start or stop pattern %s", m)
+	}
+	// First pair 5 and 0: wide, narrow, wide, narrow, narrow bars
+	// interleaved with narrow, narrow, wide, wide, narrow spaces
+	if want := "111" + "0" + "1" + "0" + "111" + "000" + "1" + "000" + "1" + "0"; !strings.HasPrefix(m[4:], want) {
+		t.Errorf("wanted pair %s, got %s", want, m[4:4+len(want)])
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+
+	tests := []struct {
+		code, symbology string
+		want            error
+	}{
+		{"4006381333931", gtin.UPCA, gtin.ErrNotConvertible},
+		{"50614141000994", gtin.EAN13, gtin.ErrNotConvertible},
+		{"4006381333931", "QR", gtin.ErrCarrier},
+	}
+
+	for _, test := range tests {
+		_, err := Encode(gtin.MustParse(test.code), test.symbology)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s as %q: wanted %v, got %v", test.code, test.symbology, test.want, err)
+		}
+	}
+
+	if _, err := Encode(gtin.GTIN{}, Auto); err == nil {
+		t.Error("wanted error for zero GTIN")
+	}
+}
@@ -0,0 +1,264 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+// Code 128 symbol character values with special meaning
+const (
+	Code128Shift  = 98  // Next character from the other of code sets A and B
+	Code128CodeC  = 99  // Switch to code set C, from A or B
+	Code128CodeB  = 100 // Switch to code set B, from A or C
+	Code128CodeA  = 101 // Switch to code set A, from B or C
+	Code128FNC1   = 102
+	Code128StartA = 103
+	Code128StartB = 104
+	Code128StartC = 105
+	Code128Stop   = 106
+)
+
+var (
+	// ErrCode128 is returned for malformed Code 128 symbol characters
+	ErrCode128 = errors.New("barcode: invalid Code 128 symbol")
+
+	// ErrCheckCharacter is returned for symbols with a wrong check character
+	ErrCheckCharacter = errors.New("barcode: invalid symbol check character")
+
+	// ErrNotGS1 is returned for symbols without FNC1 in first position
+	ErrNotGS1 = errors.New("barcode: not a GS1 symbol")
+)
+
+// Code128CheckCharacter returns the symbol check character of the symbol
+// characters from the start character on
+func Code128CheckCharacter(symbols []byte) byte {
+	if len(symbols) == 0 {
+		return 0
+	}
+	sum := int(symbols[0])
+	for n, s := range symbols[1:] {
+		sum += (n + 1) * int(s)
+	}
+	return byte(sum % 103)
+}
+
+// DecodeCode128 returns the data of Code 128 symbol characters, as scanners
+// report them in raw mode: a start character, the data, the symbol check
+// character and optionally the stop character. FNC1 is returned as GS.
+// FNC2, FNC3 and FNC4 are not supported.
+func DecodeCode128(symbols []byte) (string, error) {
+
+	if len(symbols) > 0 && symbols[len(symbols)-1] == Code128Stop {
+		symbols = symbols[:len(symbols)-1]
+	}
+	if len(symbols) < 2 {
+		return "", fmt.Errorf("%w: %d symbol characters", ErrCode128, len(symbols))
+	}
+	data, check := symbols[:len(symbols)-1], symbols[len(symbols)-1]
+	if want := Code128CheckCharacter(data); check != want {
+		return "", fmt.Errorf("%w %d, wanted %d", ErrCheckCharacter, check, want)
+	}
+
+	var set byte
+	switch data[0] {
+	case Code128StartA:
+		set = 'A'
+	case Code128StartB:
+		set = 'B'
+	case Code128StartC:
+		set = 'C'
+	default:
+		return "", fmt.Errorf("%w: start character %d", ErrCode128, data[0])
+	}
+
+	var s strings.Builder
+	shift := false
+	for n, v := range data[1:] {
+		current := set
+		if shift {
+			current = 'A' + 'B' - set
+			shift = false
+		}
+		switch {
+		case v == Code128FNC1:
+			s.WriteByte(ai.GS)
+		case current == 'C' && v < 100:
+			s.WriteByte('0' + v/10)
+			s.WriteByte('0' + v%10)
+		case current != 'C' && v < 96:
+			c := v + ' '
+			if current == 'A' && v >= 64 {
+				c = v - 64
+			}
+			s.WriteByte(c)
+		case v == Code128Shift && current != 'C':
+			shift = true
+		case v == Code128CodeC && current != 'C':
+			set = 'C'
+		case v == Code128CodeB && current != 'B':
+			set = 'B'
+		case v == Code128CodeA && current != 'A':
+			set = 'A'
+		default:
+			return "", fmt.Errorf("%w: symbol character %d at %d in code set %c", ErrCode128, v, n+1, current)
+		}
+	}
+	return s.String(), nil
+}
+
+// VerifyGS1128 decodes the Code 128 symbol characters of a GS1-128 symbol,
+// checks FNC1 in first position and parses the element string
+func VerifyGS1128(symbols []byte) (ai.Elements, error) {
+
+	data, err := DecodeCode128(symbols)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(data, string(ai.GS)) {
+		return nil, ErrNotGS1
+	}
+	return ai.Parse(data)
+}
+
+// EncodeGS1128 returns the Code 128 symbol characters of the element
+// strings as a GS1-128 symbol, from the start character to the stop
+// character, for backends that draw Code 128 themselves. Code sets A, B
+// and C, and shifts between A and B, are chosen for the fewest characters.
+func EncodeGS1128(es ai.Elements) ([]byte, error) {
+
+	if len(es) == 0 {
+		return nil, fmt.Errorf("barcode: %w", ai.ErrSyntax)
+	}
+	for _, e := range es {
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("barcode: %w", err)
+		}
+	}
+	symbols, err := encodeCode128(string(ai.GS) + es.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("barcode: %w", err)
+	}
+	return append(symbols, Code128CheckCharacter(symbols), Code128Stop), nil
+}
+
+// Code 128 code sets, as indexes
+const (
+	code128A = iota
+	code128B
+	code128C
+)
+
+// code128Value returns the value of c in code set A or B, or -1
+func code128Value(c byte, set int) int {
+	switch {
+	case c == ai.GS:
+		return Code128FNC1
+	case c >= ' ' && c < '`':
+		return int(c - ' ')
+	case set == code128A && c < ' ':
+		return int(c) + 64
+	case set == code128B && c >= '`' && c < 128:
+		return int(c - ' ')
+	}
+	return -1
+}
+
+// encodeCode128 returns the start character and the symbol characters of
+// s, with FNC1 as GS. The fewest characters are found backwards from the
+// end: cost[n][set] is the number needed for s[n:] in that code set.
+func encodeCode128(s string) ([]byte, error) {
+
+	for n := range s {
+		if code128Value(s[n], code128A) < 0 && code128Value(s[n], code128B) < 0 {
+			return nil, &gtin.PositionError{Err: gtin.ErrCharacter, Char: s[n], Pos: n}
+		}
+	}
+
+	const none = 1 << 30
+	digit := func(n int) bool { return n < len(s) && s[n] >= '0' && s[n] <= '9' }
+
+	// step returns the characters consumed and the symbols needed to
+	// encode s[n:] in the code set, or 0 if it can't
+	step := func(n, set int) (int, int) {
+		switch {
+		case s[n] == ai.GS:
+			return 1, 1
+		case set == code128C:
+			if digit(n) && digit(n+1) {
+				return 2, 1
+			}
+		case code128Value(s[n], set) >= 0:
+			return 1, 1
+		case code128Value(s[n], 1-set) >= 0:
+			return 1, 2 // Shift
+		}
+		return 0, 0
+	}
+
+	cost := make([][3]int, len(s)+1)
+	for n := len(s) - 1; n >= 0; n-- {
+		for set := range cost[n] {
+			cost[n][set] = none
+			for to := range cost[n] {
+				chars, symbols := step(n, to)
+				if chars == 0 {
+					continue
+				}
+				if to != set {
+					symbols++
+				}
+				cost[n][set] = min(cost[n][set], symbols+cost[n+chars][to])
+			}
+		}
+	}
+
+	// Prefer code set C, then B, of equally short encodations
+	set := code128C
+	for _, to := range []int{code128B, code128A} {
+		if cost[0][to] < cost[0][set] {
+			set = to
+		}
+	}
+	symbols := []byte{byte(Code128StartA + set)}
+
+	switches := [3]byte{Code128CodeA, Code128CodeB, Code128CodeC}
+	for n := 0; n < len(s); {
+		// Stay in the current code set when it's as short
+		next, best := set, none
+		for _, to := range []int{set, code128C, code128B, code128A} {
+			chars, c := step(n, to)
+			if chars == 0 {
+				continue
+			}
+			if to != set {
+				c++
+			}
+			if c+cost[n+chars][to] < best {
+				next, best = to, c+cost[n+chars][to]
+			}
+		}
+		if next != set {
+			symbols = append(symbols, switches[next])
+			set = next
+		}
+		switch {
+		case s[n] == ai.GS:
+			symbols = append(symbols, Code128FNC1)
+			n++
+		case set == code128C:
+			symbols = append(symbols, (s[n]-'0')*10+s[n+1]-'0')
+			n += 2
+		case code128Value(s[n], set) >= 0:
+			symbols = append(symbols, byte(code128Value(s[n], set)))
+			n++
+		default:
+			symbols = append(symbols, Code128Shift, byte(code128Value(s[n], 1-set)))
+			n++
+		}
+	}
+	return symbols, nil
+}
@@ -0,0 +1,125 @@
+package barcode
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+func TestVerifyGS1128(t *testing.T) {
+
+	// (01)09501101530003 from the GS1 General Specifications
+	symbols := []byte{Code128StartC, Code128FNC1, 1, 9, 50, 11, 1, 53, 0, 3, 71, Code128Stop}
+	es, err := VerifyGS1128(symbols)
+	if err != nil || es.String() != "(01)09501101530003" {
+		t.Fatalf("got %v, %v", es, err)
+	}
+
+	// (01)09501101530003(10)AB1(21)x7, switching to code set B for the lot
+	// and back to C, and with a shifted character
+	data := []byte{Code128StartC, Code128FNC1, 1, 9, 50, 11, 1, 53, 0, 3, 10, Code128CodeB, 'A' - ' ', 'B' - ' ', '1' - ' ', Code128FNC1, '2' - ' ', '1' - ' ', 'x' - ' ', Code128Shift, '7' - ' '}
+	es, err = VerifyGS1128(append(data, Code128CheckCharacter(data)))
+	if err != nil || es.String() != "(01)09501101530003(10)AB1(21)x7" {
+		t.Errorf("got %v, %v", es, err)
+	}
+
+	tests := []struct {
+		symbols []byte
+		want    error
+	}{
+		{[]byte{Code128StartC, 1, 9, 50, 11, 1, 53, 0, 3}, ErrNotGS1},
+		{[]byte{1, Code128FNC1}, ErrCode128},
+		{[]byte{Code128StartC, Code128FNC1, Code128StartA}, ErrCode128},
+		{[]byte{Code128StartC, Code128FNC1, 1, 9, 50, 11, 1, 53, 0, 4}, gtin.ErrCheckDigit},
+	}
+	for _, tt := range tests {
+		symbols := append(tt.symbols, Code128CheckCharacter(tt.symbols))
+		if _, err := VerifyGS1128(symbols); !errors.Is(err, tt.want) {
+			t.Errorf("%v: wanted %v, got %v", symbols, tt.want, err)
+		}
+	}
+	for _, symbols := range [][]byte{{Code128StartC}, {Code128Stop}} {
+		if _, err := VerifyGS1128(symbols); !errors.Is(err, ErrCode128) {
+			t.Errorf("%v: wanted ErrCode128, got %v", symbols, err)
+		}
+	}
+	if _, err := VerifyGS1128([]byte{Code128StartC, Code128FNC1, 1, 9, 50, 11, 1, 53, 0, 3, 72}); !errors.Is(err, ErrCheckCharacter) {
+		t.Errorf("wanted ErrCheckCharacter, got %v", err)
+	}
+}
+
+func TestDecodeCode128(t *testing.T) {
+
+	// Code set A control characters and FNC4, which is not supported
+	data := []byte{Code128StartA, 'A' - ' ', 64 + 9, Code128CodeC, 12}
+	if s, err := DecodeCode128(append(data, Code128CheckCharacter(data))); err != nil || s != "A\t12" {
+		t.Errorf("got %q, %v", s, err)
+	}
+	data = []byte{Code128StartB, Code128CodeB}
+	if _, err := DecodeCode128(append(data, Code128CheckCharacter(data))); !errors.Is(err, ErrCode128) {
+		t.Errorf("wanted ErrCode128 for FNC4, got %v", err)
+	}
+	data = []byte{Code128StartB, 'a' - ' '}
+	if s, _ := DecodeCode128(append(data, Code128CheckCharacter(data), Code128Stop)); s != "a" {
+		t.Errorf("got %q", s)
+	}
+	if _, err := DecodeCode128(data); !errors.Is(err, ErrCheckCharacter) {
+		t.Errorf("wanted ErrCheckCharacter, got %v", err)
+	}
+}
+
+func TestEncodeGS1128(t *testing.T) {
+
+	tests := []struct {
+		elements string
+		want     []byte // Without the check and stop characters
+	}{
+		// All numeric in code set C
+		{"(01)09501101530003", []byte{Code128StartC, Code128FNC1, 1, 9, 50, 11, 1, 53, 0, 3}},
+		// An odd digit after the numeric pairs, in code set B
+		{"(10)123", []byte{Code128StartC, Code128FNC1, 10, 12, Code128CodeB, '3' - ' '}},
+		// A letter between digits, and back to code set C for the rest
+		{"(21)1234A5678", []byte{Code128StartC, Code128FNC1, 21, 12, 34, Code128CodeB, 'A' - ' ', Code128CodeC, 56, 78}},
+		// Lower case in code set B, starting in C when it's as short
+		{"(21)abc", []byte{Code128StartC, Code128FNC1, 21, Code128CodeB, 'a' - ' ', 'b' - ' ', 'c' - ' '}},
+		{"(21)abcd", []byte{Code128StartC, Code128FNC1, 21, Code128CodeB, 'a' - ' ', 'b' - ' ', 'c' - ' ', 'd' - ' '}},
+		// FNC1 after a variable length field, in code set C
+		{"(10)12(21)34", []byte{Code128StartC, Code128FNC1, 10, 12, Code128FNC1, 21, 34}},
+	}
+	for _, tt := range tests {
+		es, err := ai.Parse(tt.elements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		symbols, err := EncodeGS1128(es)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := append(tt.want, Code128CheckCharacter(tt.want), Code128Stop)
+		if !slices.Equal(symbols, want) {
+			t.Errorf("%s: got %v, wanted %v", tt.elements, symbols, want)
+		}
+		if got, err := VerifyGS1128(symbols); err != nil || got.String() != tt.elements {
+			t.Errorf("%s: read back %v, %v", tt.elements, got, err)
+		}
+	}
+
+	if _, err := EncodeGS1128(nil); !errors.Is(err, ai.ErrSyntax) {
+		t.Errorf("wanted ErrSyntax, got %v", err)
+	}
+	if _, err := encodeCode128("é"); !errors.Is(err, gtin.ErrCharacter) {
+		t.Errorf("wanted ErrCharacter, got %v", err)
+	}
+
+	// Control characters of code set A with a shift among lower case
+	symbols, err := encodeCode128("ab\tc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := DecodeCode128(append(symbols, Code128CheckCharacter(symbols))); s != "ab\tc" || len(symbols) != 6 {
+		t.Errorf("got %v, %q", symbols, s)
+	}
+}
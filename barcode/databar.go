@@ -0,0 +1,282 @@
+package barcode
+
+import (
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+)
+
+// GS1 DataBar symbologies
+const (
+	DataBar         = "GS1 DataBar Omnidirectional"
+	DataBarStacked  = "GS1 DataBar Stacked"
+	DataBarExpanded = "GS1 DataBar Expanded"
+)
+
+// Row is a row of a stacked symbol
+type Row struct {
+	Bars   []bool
+	Height int // In modules
+}
+
+// combins returns the number of combinations of r out of n
+func combins(n, r int) int {
+	minDenom, maxDenom := r, n-r
+	if n-r <= r {
+		minDenom, maxDenom = n-r, r
+	}
+	val, j := 1, 1
+	for i := n; i > maxDenom; i-- {
+		val *= i
+		if j <= minDenom {
+			val /= j
+			j++
+		}
+	}
+	for ; j <= minDenom; j++ {
+		val /= j
+	}
+	return val
+}
+
+// dataBarWidths returns the widths of the elements of the val'th
+// combination, in order, of n modules in that many elements, none wider
+// than maxWidth. Unless noNarrow is set, at least one element is a single
+// module wide. This is the getRSSwidths routine of ISO/IEC 24724.
+func dataBarWidths(val, n, elements, maxWidth int, noNarrow bool) []int {
+
+	widths := make([]int, elements)
+	narrowMask := 0
+	bar := 0
+	for ; bar < elements-1; bar++ {
+		var elmWidth, subVal int
+		narrowMask |= 1 << bar
+		for elmWidth = 1; ; elmWidth++ {
+			// All combinations of the remaining modules
+			subVal = combins(n-elmWidth-1, elements-bar-2)
+			// Less those without a single module element
+			if !noNarrow && narrowMask == 0 && n-elmWidth-(elements-bar-1) >= elements-bar-1 {
+				subVal -= combins(n-elmWidth-(elements-bar), elements-bar-2)
+			}
+			// Less those with elements wider than maxWidth
+			if elements-bar-1 > 1 {
+				lessVal := 0
+				for mxw := n - elmWidth - (elements - bar - 2); mxw > maxWidth; mxw-- {
+					lessVal += combins(n-elmWidth-mxw-1, elements-bar-3)
+				}
+				subVal -= lessVal * (elements - 1 - bar)
+			} else if n-elmWidth > maxWidth {
+				subVal--
+			}
+			val -= subVal
+			if val < 0 {
+				break
+			}
+			narrowMask &^= 1 << bar
+		}
+		val += subVal
+		n -= elmWidth
+		widths[bar] = elmWidth
+	}
+	widths[bar] = n
+	return widths
+}
+
+// dataBarGroup is a group of character values with the same distribution
+// of modules between odd and even elements
+type dataBarGroup struct {
+	min        int // Smallest value of the group
+	t          int // Combinations of the elements that vary fastest
+	odd, even  int // Modules of the odd and even elements
+	widestOdd  int
+	widestEven int
+}
+
+// Groups of the outer and inner characters of GS1 DataBar Omnidirectional
+var (
+	dataBarOuter = []dataBarGroup{
+		{0, 1, 12, 4, 8, 1},
+		{161, 10, 10, 6, 6, 3},
+		{961, 34, 8, 8, 4, 5},
+		{2015, 70, 6, 10, 3, 6},
+		{2715, 126, 4, 12, 1, 8},
+	}
+	dataBarInner = []dataBarGroup{
+		{0, 4, 5, 10, 2, 7},
+		{336, 20, 7, 8, 4, 5},
+		{1036, 48, 9, 6, 6, 3},
+		{1516, 81, 11, 4, 8, 1},
+	}
+)
+
+// group returns the group of the value
+func group(groups []dataBarGroup, v int) dataBarGroup {
+	g := groups[0]
+	for _, next := range groups[1:] {
+		if v >= next.min {
+			g = next
+		}
+	}
+	return g
+}
+
+// dataBarChar returns the 8 element widths of a GS1 DataBar Omnidirectional
+// data character, odd elements first. In outer characters the odd elements
+// vary slowest, in inner characters the even ones.
+func dataBarChar(v int, outer bool) [8]int {
+
+	var odd, even []int
+	if outer {
+		g := group(dataBarOuter, v)
+		vOdd, vEven := (v-g.min)/g.t, (v-g.min)%g.t
+		odd = dataBarWidths(vOdd, g.odd, 4, g.widestOdd, true)
+		even = dataBarWidths(vEven, g.even, 4, g.widestEven, false)
+	} else {
+		g := group(dataBarInner, v)
+		vEven, vOdd := (v-g.min)/g.t, (v-g.min)%g.t
+		odd = dataBarWidths(vOdd, g.odd, 4, g.widestOdd, false)
+		even = dataBarWidths(vEven, g.even, 4, g.widestEven, true)
+	}
+	var w [8]int
+	for n := 0; n < 4; n++ {
+		w[2*n], w[2*n+1] = odd[n], even[n]
+	}
+	return w
+}
+
+// Finder patterns of GS1 DataBar Omnidirectional
+var dataBarFinders = [9][5]int{
+	{3, 8, 2, 1, 1},
+	{3, 5, 5, 1, 1},
+	{3, 3, 7, 1, 1},
+	{3, 1, 9, 1, 1},
+	{2, 7, 4, 1, 1},
+	{2, 5, 6, 1, 1},
+	{2, 3, 8, 1, 1},
+	{1, 5, 7, 1, 1},
+	{1, 3, 9, 1, 1},
+}
+
+// Checksum weights of the elements of GS1 DataBar Omnidirectional
+var dataBarWeights = [32]int{
+	1, 3, 9, 27, 2, 6, 18, 54,
+	58, 72, 24, 8, 29, 36, 12, 4,
+	74, 51, 17, 32, 37, 65, 48, 16,
+	64, 34, 23, 69, 49, 68, 46, 59,
+}
+
+// dataBarElements returns the 46 element widths of a GS1 DataBar
+// Omnidirectional symbol of the first 13 digits of a GTIN-14, starting with
+// a space. A linked symbol has a 2D composite component above it.
+func dataBarElements(gt gtin.GTIN, linked bool) [46]int {
+
+	value := int(gt.Uint64() / 10)
+	if linked {
+		value += 10_000_000_000_000
+	}
+	left, right := value/4537077, value%4537077
+	chars := [4]int{left / 1597, left % 1597, right / 1597, right % 1597}
+
+	var widths [4][8]int
+	for n, c := range chars {
+		widths[n] = dataBarChar(c, n%2 == 0)
+	}
+
+	checksum := 0
+	for n := 0; n < 8; n++ {
+		for c := 0; c < 4; c++ {
+			checksum += dataBarWeights[n+8*c] * widths[c][n]
+		}
+	}
+	checksum %= 79
+	if checksum >= 8 {
+		checksum++
+	}
+	if checksum >= 72 {
+		checksum++
+	}
+	leftFinder, rightFinder := dataBarFinders[checksum/9], dataBarFinders[checksum%9]
+
+	// Guard, outer character, finder, inner character reversed, then the
+	// right half mirrored
+	var e [46]int
+	e[0], e[1], e[44], e[45] = 1, 1, 1, 1
+	for n := 0; n < 8; n++ {
+		e[n+2] = widths[0][n]
+		e[n+15] = widths[1][7-n]
+		e[n+23] = widths[3][n]
+		e[n+36] = widths[2][7-n]
+	}
+	for n := 0; n < 5; n++ {
+		e[n+10] = leftFinder[n]
+		e[n+31] = rightFinder[4-n]
+	}
+	return e
+}
+
+// modulesOf returns the modules of elements alternating between space and
+// bar, starting with a bar if bar is set
+func modulesOf(elements []int, bar bool) []bool {
+	var m []bool
+	for _, w := range elements {
+		for ; w > 0; w-- {
+			m = append(m, bar)
+		}
+		bar = !bar
+	}
+	return m
+}
+
+// Heights of GS1 DataBar rows, in modules
+const (
+	dataBarHeight     = 33
+	dataBarTopRow     = 5
+	dataBarBottomRow  = 7
+	dataBarSeparator  = 1
+	dataBarStackWidth = 50
+)
+
+// EncodeDataBar returns the GS1 DataBar Omnidirectional or Stacked symbol
+// of the GTIN. The human readable interpretation is the element string.
+func EncodeDataBar(gt gtin.GTIN, symbology string) (Barcode, error) {
+
+	if !gt.Valid() {
+		return Barcode{}, fmt.Errorf("barcode: %w", gtin.ErrCheckDigit)
+	}
+	b := Barcode{Symbology: symbology, Text: "(" + gtin.AIGTIN + ")" + gt.String()}
+	e := dataBarElements(gt, false)
+
+	switch symbology {
+	case DataBar:
+		b.Bars = modulesOf(e[:], false)
+		b.Guards = make([]bool, len(b.Bars))
+	case DataBarStacked:
+		// The left half ends with a bar and a space, the right half starts
+		// with them
+		top := append(modulesOf(e[:23], false), true, false)
+		bottom := append([]bool{true, false}, modulesOf(e[23:], true)...)
+		b.Rows = []Row{
+			{top, dataBarTopRow},
+			{stackSeparator(top, bottom), dataBarSeparator},
+			{bottom, dataBarBottomRow},
+		}
+	default:
+		return Barcode{}, fmt.Errorf("barcode: %s is not GS1 DataBar: %w", symbology, gtin.ErrCarrier)
+	}
+	return b, nil
+}
+
+// stackSeparator returns the separator between the rows of a stacked
+// symbol: the complement of the rows where they agree, alternating where
+// they differ, with 4 light modules at each end
+func stackSeparator(top, bottom []bool) []bool {
+	sep := make([]bool, len(top))
+	for n := 4; n < len(top)-4; n++ {
+		if top[n] == bottom[n] {
+			sep[n] = !top[n]
+		} else {
+			sep[n] = !sep[n-1]
+		}
+	}
+	return sep
+}
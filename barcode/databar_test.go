@@ -0,0 +1,293 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+// rowString returns the modules of a row as a string of 0 and 1
+func rowString(r Row) string {
+	return modules(Barcode{Bars: r.Bars})
+}
+
+func TestDataBarChar(t *testing.T) {
+
+	// Every value of a character set has its own widths, adding up to the
+	// modules of the character
+	sets := []struct {
+		name    string
+		values  int
+		modules int
+		widths  func(v int) [8]int
+	}{
+		{"outer", 2841, 16, func(v int) [8]int { return dataBarChar(v, true) }},
+		{"inner", 1597, 15, func(v int) [8]int { return dataBarChar(v, false) }},
+		{"expanded", 4096, 17, expandedChar},
+	}
+	for _, set := range sets {
+		seen := make(map[[8]int]int)
+		for v := 0; v < set.values; v++ {
+			w := set.widths(v)
+			sum := 0
+			for _, e := range w {
+				if e < 1 || e > 9 {
+					t.Fatalf("%s %d: element width in %v", set.name, v, w)
+				}
+				sum += e
+			}
+			if sum != set.modules {
+				t.Fatalf("%s %d: %v has %d modules", set.name, v, w, sum)
+			}
+			if prev, ok := seen[w]; ok {
+				t.Fatalf("%s %d: %v, same as %d", set.name, v, w, prev)
+			}
+			seen[w] = v
+		}
+	}
+}
+
+func TestEncodeDataBar(t *testing.T) {
+
+	gt := gtin.MustParse("09501101530003")
+	b, err := EncodeDataBar(gt, DataBar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := modules(b)
+	if len(m) != 96 || m[0] != '0' || m[len(m)-1] != '1' || b.Text != "(01)09501101530003" {
+		t.Errorf("got %s, %q", m, b.Text)
+	}
+
+	// A linked symbol carries the same GTIN in other characters
+	e := dataBarElements(gt, true)
+	linked := modulesOf(e[:], false)
+	if len(linked) != 96 || modules(Barcode{Bars: linked}) == m {
+		t.Errorf("linked symbol %v", linked)
+	}
+
+	s, err := EncodeDataBar(gt, DataBarStacked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Bars) != 0 || len(s.Rows) != 3 {
+		t.Fatalf("got %d bars and %d rows", len(s.Bars), len(s.Rows))
+	}
+	for n, height := range []int{dataBarTopRow, dataBarSeparator, dataBarBottomRow} {
+		if r := s.Rows[n]; len(r.Bars) != dataBarStackWidth || r.Height != height {
+			t.Errorf("row %d: %d modules, %d high", n, len(r.Bars), r.Height)
+		}
+	}
+
+	// The rows are the halves of the omnidirectional symbol
+	top, sep, bottom := rowString(s.Rows[0]), rowString(s.Rows[1]), rowString(s.Rows[2])
+	if top[:48] != m[:48] || bottom[2:] != m[48:] || !strings.HasPrefix(bottom, "10") || !strings.HasSuffix(top, "10") {
+		t.Errorf("got\n%s\n%s\nfrom %s", top, bottom, m)
+	}
+	if !strings.HasPrefix(sep, "0000") || !strings.HasSuffix(sep, "0000") {
+		t.Errorf("separator %s", sep)
+	}
+	for n := 4; n < len(sep)-4; n++ {
+		if top[n] == bottom[n] && sep[n] == top[n] {
+			t.Errorf("separator %s does not complement %c at %d", sep, top[n], n)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf(`viewBox="0 0 50 %d"`, dataBarTopRow+dataBarSeparator+dataBarBottomRow+textHeight); !strings.Contains(buf.String(), want) {
+		t.Errorf("wanted %s in\n%s", want, buf.String())
+	}
+
+	if _, err := EncodeDataBar(gtin.GTIN{}, DataBar); !errors.Is(err, gtin.ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+	if _, err := EncodeDataBar(gt, gtin.EAN13); !errors.Is(err, gtin.ErrCarrier) {
+		t.Errorf("wanted ErrCarrier, got %v", err)
+	}
+}
+
+// rssValue returns the value of the element widths of a character, the
+// getRSSvalue routine of ISO/IEC 24724, the inverse of dataBarWidths
+func rssValue(widths []int, maxWidth int, noNarrow bool) int {
+
+	n := 0
+	for _, w := range widths {
+		n += w
+	}
+	elements := len(widths)
+	val, narrowMask := 0, 0
+	for bar := 0; bar < elements-1; bar++ {
+		elmWidth := 1
+		for narrowMask |= 1 << bar; elmWidth < widths[bar]; elmWidth, narrowMask = elmWidth+1, narrowMask&^(1<<bar) {
+			subVal := combins(n-elmWidth-1, elements-bar-2)
+			if !noNarrow && narrowMask == 0 && n-elmWidth-(elements-bar-1) >= elements-bar-1 {
+				subVal -= combins(n-elmWidth-(elements-bar), elements-bar-2)
+			}
+			if elements-bar-1 > 1 {
+				lessVal := 0
+				for mxw := n - elmWidth - (elements - bar - 2); mxw > maxWidth; mxw-- {
+					lessVal += combins(n-elmWidth-mxw-1, elements-bar-3)
+				}
+				subVal -= lessVal * (elements - 1 - bar)
+			} else if n-elmWidth > maxWidth {
+				subVal--
+			}
+			val += subVal
+		}
+		n -= elmWidth
+	}
+	return val
+}
+
+// charValue returns the value of the 8 element widths of a character, odd
+// elements first. Odd elements vary slowest unless evenSlow is set.
+func charValue(w []int, groups []dataBarGroup, oddNoNarrow, evenSlow bool) int {
+	odd := []int{w[0], w[2], w[4], w[6]}
+	even := []int{w[1], w[3], w[5], w[7]}
+	sum := odd[0] + odd[1] + odd[2] + odd[3]
+	for _, g := range groups {
+		if g.odd != sum {
+			continue
+		}
+		vOdd := rssValue(odd, g.widestOdd, oddNoNarrow)
+		vEven := rssValue(even, g.widestEven, !oddNoNarrow)
+		if evenSlow {
+			return g.min + vEven*g.t + vOdd
+		}
+		return g.min + vOdd*g.t + vEven
+	}
+	return -1
+}
+
+// widthsOf returns the element widths of modules, starting with a space
+func widthsOf(m string) []int {
+	var widths []int
+	last := byte('1')
+	for n := 0; n < len(m); n++ {
+		if m[n] != last {
+			widths = append(widths, 0)
+			last = m[n]
+		}
+		widths[len(widths)-1]++
+	}
+	return widths
+}
+
+func reversed(w []int) []int {
+	r := make([]int, len(w))
+	for n := range w {
+		r[len(w)-1-n] = w[n]
+	}
+	return r
+}
+
+func TestDataBarReference(t *testing.T) {
+
+	// The modules are decoded back with the routines of ISO/IEC 24724, so
+	// the reference patterns don't rest on the encoder alone
+	const omni = "010100011101000001000100000000010100110110111110110000010010100101111111110111000110110110001101"
+	gt := gtin.MustParse("20012345678909")
+	b, err := EncodeDataBar(gt, DataBar)
+	if err != nil || modules(b) != omni {
+		t.Fatalf("got %s, %v", modules(b), err)
+	}
+	e := widthsOf(omni)
+	if len(e) != 46 {
+		t.Fatalf("%d elements", len(e))
+	}
+	chars := [4][]int{e[2:10], reversed(e[15:23]), reversed(e[36:44]), e[23:31]}
+	var v [4]int
+	checksum := 0
+	for c, w := range chars {
+		if c%2 == 0 {
+			v[c] = charValue(w, dataBarOuter, true, false)
+		} else {
+			v[c] = charValue(w, dataBarInner, false, true)
+		}
+		for n := 0; n < 8; n++ {
+			checksum += dataBarWeights[n+8*c] * w[n]
+		}
+	}
+	value := uint64(v[0]*1597+v[1])*4537077 + uint64(v[2]*1597+v[3])
+	if value != gt.Uint64()/10 {
+		t.Errorf("decoded %d from %v", value, v)
+	}
+	if checksum %= 79; checksum >= 8 {
+		checksum++
+	}
+	if checksum >= 72 {
+		checksum++
+	}
+	if left, right := dataBarFinders[checksum/9], dataBarFinders[checksum%9]; fmt.Sprint(e[10:15]) != fmt.Sprint(left[:]) || fmt.Sprint(reversed(e[31:36])) != fmt.Sprint(right[:]) {
+		t.Errorf("finders %v %v for checksum %d", e[10:15], e[31:36], checksum)
+	}
+
+	// The stacked rows of the same GTIN
+	stacked := []string{
+		"01010001110100000100010000000001010011011011111010",
+		"00001110101011011010101010101010101001001001010000",
+		"10110000010010100101111111110111000110110110001101",
+	}
+	s, err := EncodeDataBar(gt, DataBarStacked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, want := range stacked {
+		if got := rowString(s.Rows[n]); got != want {
+			t.Errorf("row %d: got %s, wanted %s", n, got, want)
+		}
+	}
+
+	// An expanded symbol, with the check character recomputed from the
+	// decoded characters and finder patterns
+	const expanded = "01011011100001001110111111110000101001110001001111011010000001100010101111100000011010011100000010010100111110111001100011111100001011101010000110000110100111000110001011111111001110011110001111010101111100101100001000110000000010101100100000100001110101111011000010111111111001101"
+	es, _ := ai.Parse("(01)98898765432106(3202)012345(15)991231")
+	x, err := EncodeDataBarExpanded(es)
+	if err != nil || modules(x) != expanded {
+		t.Fatalf("got %s, %v", modules(x), err)
+	}
+	e = widthsOf(expanded)
+	e = e[2 : len(e)-2] // Guards
+	var widths [][]int
+	var finders []int
+	for len(e) > 0 {
+		widths = append(widths, e[:8])
+		for f, pattern := range expandedFinders {
+			if fmt.Sprint(e[8:13]) == fmt.Sprint(pattern[:]) {
+				finders = append(finders, f)
+			}
+		}
+		if len(e) > 13 {
+			widths = append(widths, reversed(e[13:21]))
+		}
+		e = e[min(21, len(e)):]
+	}
+	if fmt.Sprint(finders) != fmt.Sprint(expandedSequences[len(finders)-2]) {
+		t.Fatalf("finders %v", finders)
+	}
+	var data bits
+	checksum = 0
+	for c := 1; c < len(widths); c++ {
+		data.add(charValue(widths[c], dataBarExpandedGroups, false, false), 12)
+		w := 2*finders[c/2] + c%2 - 1
+		for n, width := range widths[c] {
+			checksum += expandedWeight(8*w+n) * width
+		}
+	}
+	if check := charValue(widths[0], dataBarExpandedGroups, false, false); check != 211*(len(widths)-4)+checksum%211 {
+		t.Errorf("check character %d, checksum %d", check, checksum%211)
+	}
+	want, vls, _ := expandedBits(es, false)
+	want[vls], want[vls+1] = len(widths)%2 == 1, len(widths) > 14
+	if bitString(data) != bitString(want) {
+		t.Errorf("decoded %s, wanted %s", bitString(data), bitString(want))
+	}
+}
@@ -0,0 +1,342 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+// Groups of the data characters of GS1 DataBar Expanded, 17 modules each
+var dataBarExpandedGroups = []dataBarGroup{
+	{0, 4, 12, 5, 7, 2},
+	{348, 20, 10, 7, 5, 4},
+	{1388, 52, 8, 9, 4, 5},
+	{2948, 104, 6, 11, 3, 6},
+	{3988, 204, 4, 13, 1, 8},
+}
+
+// expandedChar returns the 8 element widths of a GS1 DataBar Expanded
+// character, odd elements first
+func expandedChar(v int) [8]int {
+	g := group(dataBarExpandedGroups, v)
+	vOdd, vEven := (v-g.min)/g.t, (v-g.min)%g.t
+	odd := dataBarWidths(vOdd, g.odd, 4, g.widestOdd, false)
+	even := dataBarWidths(vEven, g.even, 4, g.widestEven, true)
+	var w [8]int
+	for n := 0; n < 4; n++ {
+		w[2*n], w[2*n+1] = odd[n], even[n]
+	}
+	return w
+}
+
+// Finder patterns of GS1 DataBar Expanded, A1, A2, B1, ... F2. The second
+// of each pair is the first reversed.
+var expandedFinders = [12][5]int{
+	{1, 8, 4, 1, 1}, {1, 1, 4, 8, 1},
+	{3, 6, 4, 1, 1}, {1, 1, 4, 6, 3},
+	{3, 4, 6, 1, 1}, {1, 1, 6, 4, 3},
+	{3, 2, 8, 1, 1}, {1, 1, 8, 2, 3},
+	{2, 6, 5, 1, 1}, {1, 1, 5, 6, 2},
+	{2, 2, 9, 1, 1}, {1, 1, 9, 2, 2},
+}
+
+// Finder pattern sequences by the number of finder patterns, from 2, as
+// indexes into expandedFinders
+var expandedSequences = [][]int{
+	{0, 1},
+	{0, 3, 2},
+	{0, 5, 2, 7},
+	{0, 9, 2, 7, 4},
+	{0, 9, 2, 7, 6, 11},
+	{0, 9, 2, 7, 8, 11, 10},
+	{0, 1, 2, 3, 4, 5, 6, 7},
+	{0, 1, 2, 3, 4, 5, 6, 9, 8},
+	{0, 1, 2, 3, 4, 5, 6, 9, 10, 11},
+	{0, 1, 2, 3, 4, 7, 6, 9, 8, 11, 10},
+}
+
+// Limits of the data characters of a GS1 DataBar Expanded symbol, without
+// the check character
+const (
+	expandedMinChars = 3
+	expandedMaxChars = 21
+)
+
+// bits is a bit string
+type bits []bool
+
+// add appends the n lowest bits of v
+func (b *bits) add(v, n int) {
+	for n--; n >= 0; n-- {
+		*b = append(*b, v>>n&1 == 1)
+	}
+}
+
+// ISO/IEC 646 characters encoded in 8 bits, from 232
+const iso646Special = "!\"%&'()*+,-./:;<=>?_ "
+
+// Encodation modes of general purpose data
+const (
+	modeNumeric = iota
+	modeAlpha
+	modeISO
+)
+
+// expandedBits returns the bit string of the element string, padded to
+// whole symbol characters, using encodation method 1 for element strings
+// starting with a GTIN and 2 for the rest. The two bits of the variable
+// length symbol field are left zero; the index of the first is returned.
+func expandedBits(es ai.Elements, linked bool) (bits, int, error) {
+
+	var b bits
+	if linked {
+		b.add(1, 1)
+	} else {
+		b.add(0, 1)
+	}
+
+	raw := es.Raw()
+	var vls int
+	if len(es) > 0 && es[0].AI == gtin.AIGTIN {
+		b.add(1, 1)
+		vls = len(b)
+		b.add(0, 2)
+		digits := es[0].Value
+		b.add(int(digits[0]-'0'), 4)
+		for n := 1; n < 13; n += 3 {
+			b.add(atoi(digits[n:n+3]), 10)
+		}
+		raw = raw[len(es[0].AI)+len(es[0].Value):]
+	} else {
+		b.add(0, 2)
+		vls = len(b)
+		b.add(0, 2)
+	}
+
+	mode, err := generalPurpose(&b, raw)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	chars := max(expandedMinChars, (len(b)+11)/12)
+	if chars > expandedMaxChars {
+		return nil, 0, fmt.Errorf("%d bits of %s for %s: %w", len(b), es, DataBarExpanded, gtin.ErrLength)
+	}
+	// Pad with latches between the alphanumeric and ISO/IEC 646 modes,
+	// starting with one to alphanumeric mode from numeric
+	if mode == modeNumeric && len(b) < chars*12 {
+		b.add(0, 4)
+	}
+	for len(b) < chars*12 {
+		b.add(0b00100, 5)
+	}
+	return b[:chars*12], vls, nil
+}
+
+func atoi(s string) int {
+	n := 0
+	for _, c := range s {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
+// isNumericChar reports if c is a digit or FNC1, which numeric mode
+// encodes in pairs
+func isNumericChar(c byte) bool {
+	return c >= '0' && c <= '9' || c == ai.GS
+}
+
+// isAlphaChar reports if alphanumeric mode encodes c
+func isAlphaChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || strings.IndexByte("*,-./", c) >= 0 || c == ai.GS
+}
+
+// generalPurpose appends the general purpose encodation of s, with FNC1
+// as GS. It starts in numeric mode and latches to alphanumeric or ISO/IEC
+// 646 mode for characters that numeric mode can't encode in pairs. The
+// mode at the end is returned.
+func generalPurpose(b *bits, s string) (int, error) {
+
+	mode := modeNumeric
+	for n := 0; n < len(s); {
+		c := s[n]
+		switch mode {
+		case modeNumeric:
+			if n+1 < len(s) && isNumericChar(c) && isNumericChar(s[n+1]) && !(c == ai.GS && s[n+1] == ai.GS) {
+				b.add(11*numericValue(c)+numericValue(s[n+1])+8, 7)
+				n += 2
+				continue
+			}
+			if n+1 == len(s) && c >= '0' && c <= '9' {
+				// A last digit is paired with FNC1
+				b.add(11*numericValue(c)+10+8, 7)
+				n++
+				continue
+			}
+			b.add(0, 4)
+			mode = modeAlpha
+		case modeAlpha:
+			if numericRun(s[n:]) {
+				b.add(0, 3)
+				mode = modeNumeric
+				continue
+			}
+			if !isAlphaChar(c) {
+				b.add(0b00100, 5)
+				mode = modeISO
+				continue
+			}
+			switch {
+			case c == ai.GS:
+				b.add(0b01111, 5)
+				mode = modeNumeric
+			case c >= '0' && c <= '9':
+				b.add(int(c-'0')+5, 5)
+			case c >= 'A' && c <= 'Z':
+				b.add(int(c-'A')+32, 6)
+			default:
+				b.add(strings.IndexByte("*,-./", c)+58, 6)
+			}
+			n++
+		case modeISO:
+			if numericRun(s[n:]) {
+				b.add(0, 3)
+				mode = modeNumeric
+				continue
+			}
+			if isAlphaChar(c) && c != ai.GS && alphaRun(s[n:]) {
+				b.add(0b00100, 5)
+				mode = modeAlpha
+				continue
+			}
+			switch {
+			case c == ai.GS:
+				b.add(0b01111, 5)
+				mode = modeNumeric
+			case c >= '0' && c <= '9':
+				b.add(int(c-'0')+5, 5)
+			case c >= 'A' && c <= 'Z':
+				b.add(int(c-'A')+64, 7)
+			case c >= 'a' && c <= 'z':
+				b.add(int(c-'a')+90, 7)
+			case strings.IndexByte(iso646Special, c) >= 0:
+				b.add(strings.IndexByte(iso646Special, c)+232, 8)
+			default:
+				return mode, &gtin.PositionError{Err: gtin.ErrCharacter, Char: c, Pos: n}
+			}
+			n++
+		}
+	}
+	return mode, nil
+}
+
+// numericValue returns the value of a digit or FNC1 in numeric mode
+func numericValue(c byte) int {
+	if c == ai.GS {
+		return 10
+	}
+	return int(c - '0')
+}
+
+// numericRun reports if s starts with enough digits to make latching to
+// numeric mode worthwhile
+func numericRun(s string) bool {
+	n := 0
+	for n < len(s) && n < 6 && isNumericChar(s[n]) && s[n] != ai.GS {
+		n++
+	}
+	return n == 6 || n >= 4 && n == len(s)
+}
+
+// alphaRun reports if s starts with enough alphanumeric characters to make
+// latching to alphanumeric mode worthwhile
+func alphaRun(s string) bool {
+	n := 0
+	for n < len(s) && n < 5 && isAlphaChar(s[n]) && s[n] != ai.GS {
+		n++
+	}
+	return n == 5 || n == len(s)
+}
+
+// EncodeDataBarExpanded returns the GS1 DataBar Expanded symbol of the
+// element string, with up to 74 numeric or 41 alphabetic characters
+func EncodeDataBarExpanded(es ai.Elements) (Barcode, error) {
+	return encodeExpanded(es, false)
+}
+
+func encodeExpanded(es ai.Elements, linked bool) (Barcode, error) {
+
+	for _, e := range es {
+		if err := e.Validate(); err != nil {
+			return Barcode{}, fmt.Errorf("barcode: %w", err)
+		}
+	}
+	if len(es) == 0 {
+		return Barcode{}, fmt.Errorf("barcode: %w", ai.ErrSyntax)
+	}
+	b, vls, err := expandedBits(es, linked)
+	if err != nil {
+		return Barcode{}, fmt.Errorf("barcode: %w", err)
+	}
+
+	// The variable length symbol field holds the parity and size of the
+	// symbol, in symbol characters with the check character
+	chars := len(b) / 12
+	total := chars + 1
+	b[vls] = total%2 == 1
+	b[vls+1] = total > 14
+
+	widths := make([][8]int, total)
+	for c := 0; c < chars; c++ {
+		v := 0
+		for _, bit := range b[c*12 : c*12+12] {
+			v <<= 1
+			if bit {
+				v |= 1
+			}
+		}
+		widths[c+1] = expandedChar(v)
+	}
+
+	// The weights of a character follow from the finder pattern it's next
+	// to, and its side, so that reordered symbols can be checked
+	finders := expandedSequences[(total+1)/2-2]
+	checksum := 0
+	for c := 1; c < total; c++ {
+		w := 2*finders[c/2] + c%2 - 1
+		for n, width := range widths[c] {
+			checksum += expandedWeight(8*w+n) * width
+		}
+	}
+	widths[0] = expandedChar(211*(total-4) + checksum%211)
+
+	// Pairs of a character, a finder pattern and a reversed character,
+	// between guards
+	elements := []int{1, 1}
+	for c := 0; c < total; c += 2 {
+		elements = append(elements, widths[c][:]...)
+		elements = append(elements, expandedFinders[finders[c/2]][:]...)
+		if c+1 < total {
+			for n := 7; n >= 0; n-- {
+				elements = append(elements, widths[c+1][n])
+			}
+		}
+	}
+	elements = append(elements, 1, 1)
+
+	bars := modulesOf(elements, false)
+	return Barcode{Symbology: DataBarExpanded, Text: es.String(), Bars: bars, Guards: make([]bool, len(bars))}, nil
+}
+
+// expandedWeight returns the checksum weight of element n, counted over
+// the characters in weight order: 3^n mod 211
+func expandedWeight(n int) int {
+	w := 1
+	for ; n > 0; n-- {
+		w = w * 3 % 211
+	}
+	return w
+}
@@ -0,0 +1,98 @@
+package barcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+// bitString returns the bits as a string of 0 and 1
+func bitString(b bits) string {
+	var s strings.Builder
+	for _, bit := range b {
+		if bit {
+			s.WriteByte('1')
+		} else {
+			s.WriteByte('0')
+		}
+	}
+	return s.String()
+}
+
+func TestExpandedBits(t *testing.T) {
+
+	tests := []struct {
+		elements string
+		want     string
+		vls      int
+	}{
+		// Method 1: the GTIN in groups of three digits, then 10 as a pair
+		// and the lot latched to alphanumeric mode, padded to 7 characters
+		{"(01)09501101530003(10)AB1", "0" + "1" + "00" + "0000" + "1110110110" + "0001101110" + "0010011001" + "0000000000" +
+			"0010011" + "0000" + "100000" + "100001" + "00110" + "00100" + "001", 2},
+		// Method 2: a serial number as pairs, with the last digit paired
+		// with FNC1, then a latch to alphanumeric mode and padding
+		{"(21)123", "0" + "00" + "00" + "0011111" + "0010101" + "0110011" + "0000" + "00100" + "0", 3},
+		// Lower case letters in ISO/IEC 646 mode
+		{"(21)ab", "0" + "00" + "00" + "0011111" + "0000" + "00100" + "1011010" + "1011011" + "0", 3},
+	}
+	for _, tt := range tests {
+		es, err := ai.Parse(tt.elements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, vls, err := expandedBits(es, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bitString(b); got != tt.want || vls != tt.vls {
+			t.Errorf("%s: got %s, %d, wanted\n%s", tt.elements, got, vls, tt.want)
+		}
+	}
+}
+
+func TestEncodeDataBarExpanded(t *testing.T) {
+
+	tests := []struct {
+		elements string
+		chars    int // With the check character
+	}{
+		{"(01)09501101530003", 5},
+		{"(01)09501101530003(10)AB1", 8},
+		{"(21)123", 4},
+		{"(01)09501101530003(10)ABC123(17)251231(21)SERIAL-12345", 18},
+	}
+	for _, tt := range tests {
+		es, err := ai.Parse(tt.elements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := EncodeDataBarExpanded(es)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.elements, err)
+		}
+		finders := (tt.chars + 1) / 2
+		if want := 2 + 17*tt.chars + 15*finders + 2; len(b.Bars) != want {
+			t.Errorf("%s: got %d modules, wanted %d", tt.elements, len(b.Bars), want)
+		}
+		m := modules(b)
+		if !strings.HasPrefix(m, "01") || b.Text != tt.elements {
+			t.Errorf("%s: got %s, %q", tt.elements, m, b.Text)
+		}
+	}
+
+	long, _ := ai.Parse("(21)" + strings.Repeat("abcdefghij", 2))
+	es := append(long, ai.Element{AI: "240", Value: strings.Repeat("x", 30)})
+	if _, err := EncodeDataBarExpanded(es); !errors.Is(err, gtin.ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+	if _, err := EncodeDataBarExpanded(ai.Elements{{AI: "21", Value: "\x7f"}}); err == nil {
+		t.Error("wanted an error for DEL")
+	}
+	if _, err := EncodeDataBarExpanded(nil); !errors.Is(err, ai.ErrSyntax) {
+		t.Errorf("wanted ErrSyntax, got %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// HRI is the position of the human readable interpretation
+type HRI int
+
+// Positions of the human readable interpretation
+const (
+	HRIBelow HRI = iota // Below the bars, between the guard bars
+	HRIAbove            // Above the bars
+	HRINone             // Not drawn
+)
+
+// Option configures the rendering of a barcode
+type Option func(*options)
+
+type options struct {
+	hri HRI
+}
+
+// WithHRI sets the position of the human readable interpretation. It's
+// drawn below the bars by default.
+func WithHRI(position HRI) Option {
+	return func(o *options) {
+		o.hri = position
+	}
+}
+
+// ErrHRI is returned for a human readable interpretation with a character
+// the embedded font has no glyph for
+var ErrHRI = errors.New("barcode: no glyph for the human readable interpretation")
+
+// Glyphs of an embedded stroke font modeled on OCR-B, the font the GS1
+// General Specifications require for the human readable interpretation.
+// Each glyph is a set of polylines in a box 4 units wide and 8 high, from
+// the top left corner. Capitals and digits fill the box, lower case letters
+// are 5 high; the descenders of g, j, p, q and y are raised to stay on the
+// line. There is a glyph for every printable ASCII character, which covers
+// the element strings of GS1 DataBar Expanded.
+var glyphs = map[byte][][]float64{
+	'0': {{1, 0, 3, 0, 4, 1, 4, 7, 3, 8, 1, 8, 0, 7, 0, 1, 1, 0}},
+	'1': {{0.5, 2, 2.5, 0, 2.5, 8}},
+	'2': {{0, 1, 1, 0, 3, 0, 4, 1, 4, 3, 0, 8, 4, 8}},
+	'3': {{0, 0, 4, 0, 1.5, 3.3, 3, 3.3, 4, 4.3, 4, 7, 3, 8, 1, 8, 0, 7}},
+	'4': {{2.2, 0, 0, 5.5, 4, 5.5}, {3, 3.2, 3, 8}},
+	'5': {{4, 0, 0.4, 0, 0.2, 3.6, 1, 3.2, 3, 3.2, 4, 4.2, 4, 7, 3, 8, 1, 8, 0, 7}},
+	'6': {{3.2, 0, 0.4, 4.3, 0, 5.5, 0, 7, 1, 8, 3, 8, 4, 7, 4, 5.3, 3, 4.3, 1, 4.3, 0.4, 4.8}},
+	'7': {{0, 0, 4, 0, 4, 1, 1.5, 8}},
+	'8': {
+		{1, 3.6, 0.3, 2.8, 0.3, 0.9, 1.1, 0, 2.9, 0, 3.7, 0.9, 3.7, 2.8, 3, 3.6, 1, 3.6},
+		{1, 3.6, 0, 4.6, 0, 7, 1, 8, 3, 8, 4, 7, 4, 4.6, 3, 3.6},
+	},
+	'9': {{0.8, 8, 3.6, 3.7, 4, 2.5, 4, 1, 3, 0, 1, 0, 0, 1, 0, 2.7, 1, 3.7, 3, 3.7, 3.6, 3.2}},
+
+	'A': {{0, 8, 2, 0, 4, 8}, {0.7, 5.2, 3.3, 5.2}},
+	'B': {{0, 4, 3, 4, 4, 5, 4, 7, 3, 8, 0, 8, 0, 0, 3, 0, 3.8, 0.8, 3.8, 3.2, 3, 4}},
+	'C': {{4, 1, 3, 0, 1, 0, 0, 1, 0, 7, 1, 8, 3, 8, 4, 7}},
+	'D': {{0, 0, 0, 8, 2.5, 8, 4, 6.5, 4, 1.5, 2.5, 0, 0, 0}},
+	'E': {{4, 0, 0, 0, 0, 8, 4, 8}, {0, 4, 3, 4}},
+	'F': {{4, 0, 0, 0, 0, 8}, {0, 4, 3, 4}},
+	'G': {{4, 1, 3, 0, 1, 0, 0, 1, 0, 7, 1, 8, 3, 8, 4, 7, 4, 4.5, 2.2, 4.5}},
+	'H': {{0, 0, 0, 8}, {4, 0, 4, 8}, {0, 4, 4, 4}},
+	'I': {{0.8, 0, 3.2, 0}, {2, 0, 2, 8}, {0.8, 8, 3.2, 8}},
+	'J': {{1.5, 0, 4, 0, 4, 7, 3, 8, 1, 8, 0, 7}},
+	'K': {{0, 0, 0, 8}, {4, 0, 0, 5}, {1.3, 3.7, 4, 8}},
+	'L': {{0, 0, 0, 8, 4, 8}},
+	'M': {{0, 8, 0, 0, 2, 5, 4, 0, 4, 8}},
+	'N': {{0, 8, 0, 0, 4, 8, 4, 0}},
+	'O': {{1.5, 0, 2.5, 0, 4, 1.5, 4, 6.5, 2.5, 8, 1.5, 8, 0, 6.5, 0, 1.5, 1.5, 0}},
+	'P': {{0, 8, 0, 0, 3, 0, 4, 1, 4, 3.5, 3, 4.5, 0, 4.5}},
+	'Q': {{1.5, 0, 2.5, 0, 4, 1.5, 4, 6.5, 2.5, 8, 1.5, 8, 0, 6.5, 0, 1.5, 1.5, 0}, {2.5, 5.5, 4, 8}},
+	'R': {{0, 8, 0, 0, 3, 0, 4, 1, 4, 3.5, 3, 4.5, 0, 4.5}, {2, 4.5, 4, 8}},
+	'S': {{4, 1, 3, 0, 1, 0, 0, 1, 0, 3, 1, 4, 3, 4, 4, 5, 4, 7, 3, 8, 1, 8, 0, 7}},
+	'T': {{0, 0, 4, 0}, {2, 0, 2, 8}},
+	'U': {{0, 0, 0, 7, 1, 8, 3, 8, 4, 7, 4, 0}},
+	'V': {{0, 0, 2, 8, 4, 0}},
+	'W': {{0, 0, 1, 8, 2, 3, 3, 8, 4, 0}},
+	'X': {{0, 0, 4, 8}, {4, 0, 0, 8}},
+	'Y': {{0, 0, 2, 4, 4, 0}, {2, 4, 2, 8}},
+	'Z': {{0, 0, 4, 0, 0, 8, 4, 8}},
+
+	'a': {{0.5, 3, 3, 3, 4, 4, 4, 8}, {4, 5.5, 1, 5.5, 0, 6.5, 0, 7, 1, 8, 3, 8, 4, 7}},
+	'b': {{0, 0, 0, 8}, {0, 4, 1, 3, 3, 3, 4, 4, 4, 7, 3, 8, 1, 8, 0, 7}},
+	'c': {{4, 4, 3, 3, 1, 3, 0, 4, 0, 7, 1, 8, 3, 8, 4, 7}},
+	'd': {{4, 0, 4, 8}, {4, 4, 3, 3, 1, 3, 0, 4, 0, 7, 1, 8, 3, 8, 4, 7}},
+	'e': {{0, 5.5, 4, 5.5, 4, 4, 3, 3, 1, 3, 0, 4, 0, 7, 1, 8, 3.5, 8}},
+	'f': {{3.5, 0, 2.5, 0, 1.5, 1, 1.5, 8}, {0, 3, 3.5, 3}},
+	'g': {{1, 3, 3, 3, 4, 4, 4, 5, 3, 6, 1, 6, 0, 5, 0, 4, 1, 3}, {4, 3, 4, 7, 3, 8, 0.5, 8}},
+	'h': {{0, 0, 0, 8}, {0, 4, 1, 3, 3, 3, 4, 4, 4, 8}},
+	'i': {{2, 3, 2, 8}, {2, 0.8, 2, 1.2}},
+	'j': {{2.5, 3, 2.5, 7, 1.5, 8, 0.5, 8}, {2.5, 0.8, 2.5, 1.2}},
+	'k': {{0, 0, 0, 8}, {3.5, 3, 0, 6}, {1.3, 4.9, 4, 8}},
+	'l': {{1.5, 0, 1.5, 7, 2.5, 8}},
+	'm': {{0, 8, 0, 3}, {0, 4, 0.8, 3, 1.2, 3, 2, 4, 2, 8}, {2, 4, 2.8, 3, 3.2, 3, 4, 4, 4, 8}},
+	'n': {{0, 3, 0, 8}, {0, 4, 1, 3, 3, 3, 4, 4, 4, 8}},
+	'o': {{1, 3, 3, 3, 4, 4, 4, 7, 3, 8, 1, 8, 0, 7, 0, 4, 1, 3}},
+	'p': {{0, 3, 0, 8}, {0, 4, 1, 3, 3, 3, 4, 4, 4, 5, 3, 6, 1, 6, 0, 5}},
+	'q': {{4, 3, 4, 8}, {4, 4, 3, 3, 1, 3, 0, 4, 0, 5, 1, 6, 3, 6, 4, 5}},
+	'r': {{0, 3, 0, 8}, {0, 5, 2, 3, 4, 3}},
+	's': {{4, 3.5, 3.5, 3, 0.8, 3, 0, 3.8, 0, 4.7, 0.8, 5.5, 3.2, 5.5, 4, 6.3, 4, 7.2, 3.2, 8, 0.5, 8, 0, 7.5}},
+	't': {{1.5, 0.5, 1.5, 7, 2.5, 8, 3.5, 8}, {0, 3, 3.5, 3}},
+	'u': {{0, 3, 0, 7, 1, 8, 3, 8, 4, 7}, {4, 3, 4, 8}},
+	'v': {{0, 3, 2, 8, 4, 3}},
+	'w': {{0, 3, 1, 8, 2, 4.5, 3, 8, 4, 3}},
+	'x': {{0, 3, 4, 8}, {4, 3, 0, 8}},
+	'y': {{0, 3, 0, 5, 1, 6, 3, 6, 4, 5}, {4, 3, 4, 7, 3, 8, 0.5, 8}},
+	'z': {{0, 3, 4, 3, 0, 8, 4, 8}},
+
+	' ':  {},
+	'!':  {{2, 0, 2, 5.5}, {2, 7.6, 2, 8}},
+	'"':  {{1.2, 0, 1.2, 2}, {2.8, 0, 2.8, 2}},
+	'#':  {{1.3, 1, 0.7, 7}, {3.3, 1, 2.7, 7}, {0, 3, 4, 3}, {0, 5, 4, 5}},
+	'$':  {{4, 1.5, 3, 1, 1, 1, 0, 2, 0, 3, 1, 4, 3, 4, 4, 5, 4, 6, 3, 7, 1, 7, 0, 6.5}, {2, 0, 2, 8}},
+	'%':  {{4, 0, 0, 8}, {0.5, 0.5, 1.5, 0.5, 1.5, 2, 0.5, 2, 0.5, 0.5}, {2.5, 6, 3.5, 6, 3.5, 7.5, 2.5, 7.5, 2.5, 6}},
+	'&':  {{4, 8, 0.8, 2.8, 0.8, 1, 1.6, 0, 2.4, 0, 3.2, 1, 3.2, 2, 0, 5, 0, 7, 1, 8, 2.5, 8, 4, 5.5}},
+	'\'': {{2, 0, 2, 2}},
+	'(':  {{3, 0, 1.5, 1.5, 1, 4, 1.5, 6.5, 3, 8}},
+	')':  {{1, 0, 2.5, 1.5, 3, 4, 2.5, 6.5, 1, 8}},
+	'*':  {{2, 1, 2, 5}, {0.3, 2, 3.7, 4}, {3.7, 2, 0.3, 4}},
+	'+':  {{2, 2, 2, 6}, {0, 4, 4, 4}},
+	',':  {{2.3, 6.5, 2.3, 7.2, 1.5, 8}},
+	'-':  {{0.5, 4, 3.5, 4}},
+	'.':  {{2, 7.6, 2, 8}},
+	'/':  {{4, 0, 0, 8}},
+	':':  {{2, 2.8, 2, 3.2}, {2, 7.6, 2, 8}},
+	';':  {{2.3, 2.8, 2.3, 3.2}, {2.3, 6.5, 2.3, 7.2, 1.5, 8}},
+	'<':  {{4, 1, 0, 4, 4, 7}},
+	'=':  {{0, 3, 4, 3}, {0, 5, 4, 5}},
+	'>':  {{0, 1, 4, 4, 0, 7}},
+	'?':  {{0, 1, 1, 0, 3, 0, 4, 1, 4, 2.5, 2, 4, 2, 5.5}, {2, 7.6, 2, 8}},
+	'@':  {{3, 5, 3, 3, 1.8, 3, 1.2, 4, 1.5, 5, 3, 5, 4, 4, 4, 1, 3, 0, 1, 0, 0, 1, 0, 7, 1, 8, 3.5, 8}},
+	'[':  {{3, 0, 1.5, 0, 1.5, 8, 3, 8}},
+	'\\': {{0, 0, 4, 8}},
+	']':  {{1, 0, 2.5, 0, 2.5, 8, 1, 8}},
+	'^':  {{0.5, 2.5, 2, 0, 3.5, 2.5}},
+	'_':  {{0, 8, 4, 8}},
+	'`':  {{1.5, 0, 2.5, 1.5}},
+	'{':  {{3, 0, 2, 0, 1.5, 0.5, 1.5, 3.5, 0.8, 4, 1.5, 4.5, 1.5, 7.5, 2, 8, 3, 8}},
+	'|':  {{2, 0, 2, 8}},
+	'}':  {{1, 0, 2, 0, 2.5, 0.5, 2.5, 3.5, 3.2, 4, 2.5, 4.5, 2.5, 7.5, 2, 8, 1, 8}},
+	'~':  {{0, 4.5, 1, 3.5, 3, 4.5, 4, 3.5}},
+}
+
+// Size of the human readable interpretation, in modules
+const (
+	charWidth  = 7   // Width of a character cell, one EAN/UPC symbol character
+	charHeight = 8   // Height of the digits, about 2.75 mm at nominal size
+	strokeSize = 0.9 // Width of the strokes
+	textGap    = 1   // Gap between the bars and the digits
+)
+
+// char is a character of the human readable interpretation, at x modules
+// from the start of the symbol, scaled by size
+type char struct {
+	c    byte
+	x    float64
+	size float64
+}
+
+// hriChars places the digits of the human readable interpretation under
+// their symbol characters, as in the GS1 General Specifications: the first
+// EAN-13 digit in the left quiet zone, the first and last UPC-A digits
+// smaller outside the symbol. Other texts are centered, and scaled down to
+// the width of the symbol if wider.
+func hriChars(b Barcode) ([]char, error) {
+
+	for n := 0; n < len(b.Text); n++ {
+		if _, ok := glyphs[b.Text[n]]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrHRI, b.Text[n])
+		}
+	}
+
+	digits := strings.ReplaceAll(b.Text, " ", "")
+	var chars []char
+	place := func(from, to int, x float64, size float64) {
+		for n := from; n < to; n++ {
+			chars = append(chars, char{digits[n], x, size})
+			x += charWidth * size
+		}
+	}
+
+	switch {
+	case b.Symbology == gtin.EAN13 && len(digits) == 13:
+		place(0, 1, -charWidth, 1)
+		place(1, 7, 3, 1)
+		place(7, 13, 50, 1)
+	case b.Symbology == gtin.UPCA && len(digits) == 12:
+		place(0, 1, -charWidth*0.75, 0.75)
+		place(1, 6, 10, 1)
+		place(6, 11, 50, 1)
+		place(11, 12, 96, 0.75)
+	case b.Symbology == gtin.EAN8 && len(digits) == 8:
+		place(0, 4, 3, 1)
+		place(4, 8, 36, 1)
+	default:
+		width, size := float64(len(b.Text))*charWidth, 1.0
+		if w := float64(b.width()); width > w && width > 0 {
+			width, size = w, w/width
+		}
+		x := (float64(b.width()) - width) / 2
+		for n := 0; n < len(b.Text); n++ {
+			chars = append(chars, char{b.Text[n], x, size})
+			x += charWidth * size
+		}
+	}
+	return chars, nil
+}
+
+// strokes returns the polylines of the characters, as x, y pairs in
+// modules from the top left corner of the symbol, with the top of the
+// characters at y
+func strokes(chars []char, y float64) [][]float64 {
+
+	var lines [][]float64
+	for _, ch := range chars {
+		// Center the glyph in its cell and align it with the bottom of
+		// full size characters
+		left := ch.x + (charWidth-4)*ch.size/2
+		top := y + charHeight*(1-ch.size)
+		for _, g := range glyphs[ch.c] {
+			line := make([]float64, len(g))
+			for n := 0; n < len(g); n += 2 {
+				line[n] = left + g[n]*ch.size
+				line[n+1] = top + g[n+1]*ch.size
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// bar is a bar in modules from the top left corner of the symbol, without
+// quiet zones
+type bar struct {
+	x, y, w, h float64
+}
+
+// layout is a barcode laid out in modules
+type layout struct {
+	bars          []bar
+	text          [][]float64 // Strokes of the human readable interpretation
+	width, height float64     // With quiet zones
+	left          float64     // Left quiet zone
+}
+
+// lay lays out the barcode with bars of the given height, in modules
+func lay(b Barcode, height float64, opts []Option) (layout, error) {
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	left, right := b.QuietZone()
+	l := layout{width: float64(left + b.width() + right), left: float64(left)}
+
+	// Stacked symbols are as high as their rows and have no guard bars
+	rows := b.Rows
+	guard := float64(guardHeight)
+	if len(rows) > 0 {
+		height, guard = 0, 0
+		for _, r := range rows {
+			height += float64(r.Height)
+		}
+	}
+
+	top := 0.0
+	if o.hri != HRINone {
+		chars, err := hriChars(b)
+		if err != nil {
+			return layout{}, err
+		}
+		if o.hri == HRIAbove {
+			top = textHeight
+			l.text = strokes(chars, textGap/2)
+		} else {
+			l.text = strokes(chars, height+textGap)
+		}
+	}
+	l.height = top + height + guard
+	if o.hri != HRINone {
+		l.height += textHeight
+	}
+
+	if len(rows) == 0 {
+		l.bars = runs(b.Bars, b.Guards, top, height)
+		return l, nil
+	}
+	for _, r := range rows {
+		l.bars = append(l.bars, runs(r.Bars, nil, top, float64(r.Height))...)
+		top += float64(r.Height)
+	}
+	return l, nil
+}
+
+// runs returns the bars of a row of modules, with guard bars extended
+func runs(modules, guards []bool, top, height float64) []bar {
+	guard := func(n int) bool { return guards != nil && guards[n] }
+	var bars []bar
+	for x := 0; x < len(modules); {
+		if !modules[x] {
+			x++
+			continue
+		}
+		start := x
+		for x < len(modules) && modules[x] && guard(x) == guard(start) {
+			x++
+		}
+		h := height
+		if guard(start) {
+			h += guardHeight
+		}
+		bars = append(bars, bar{float64(start), top, float64(x - start), h})
+	}
+	return bars
+}
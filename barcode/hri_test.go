@@ -0,0 +1,133 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+func TestHRIChars(t *testing.T) {
+
+	tests := []struct {
+		code       string
+		x          []float64 // of the first, second and last characters
+		first      float64   // size of the first character
+		characters int
+	}{
+		{"4006381333931", []float64{-7, 3, 85}, 1, 13},
+		{"614141000012", []float64{-5.25, 10, 96}, 0.75, 12},
+		{"96385074", []float64{3, 10, 57}, 1, 8},
+		{"50614141000994", []float64{18.5, 25.5, 109.5}, 1, 14},
+	}
+	for _, tt := range tests {
+		b, _ := Encode(gtin.MustParse(tt.code), Auto)
+		chars, _ := hriChars(b)
+		if len(chars) != tt.characters {
+			t.Errorf("%s: got %d characters", tt.code, len(chars))
+			continue
+		}
+		got := []float64{chars[0].x, chars[1].x, chars[len(chars)-1].x}
+		for n := range got {
+			if got[n] != tt.x[n] {
+				t.Errorf("%s: wanted characters at %v, got %v", tt.code, tt.x, got)
+				break
+			}
+		}
+		if chars[0].size != tt.first || chars[0].c != tt.code[0] {
+			t.Errorf("%s: got first character %+v", tt.code, chars[0])
+		}
+	}
+
+	// Every printable character but space has a glyph inside its box
+	for c := byte('!'); c <= '~'; c++ {
+		if len(glyphs[c]) == 0 {
+			t.Errorf("no glyph for %c", c)
+		}
+		for _, line := range glyphs[c] {
+			for n := 0; n < len(line); n += 2 {
+				if line[n] < 0 || line[n] > 4 || line[n+1] < 0 || line[n+1] > charHeight {
+					t.Errorf("%c: %v outside the box", c, line)
+				}
+			}
+		}
+	}
+
+	// Element strings keep their parentheses and letters, within the width
+	// of the symbol
+	es, _ := ai.Parse("(01)09501101530003(10)AB-1(21)xyz")
+	for _, encode := range []func() (Barcode, error){
+		func() (Barcode, error) { return EncodeDataBar(gtin.MustParse("09501101530003"), DataBar) },
+		func() (Barcode, error) { return EncodeDataBar(gtin.MustParse("09501101530003"), DataBarStacked) },
+		func() (Barcode, error) { return EncodeDataBarExpanded(es) },
+	} {
+		b, err := encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		chars, err := hriChars(b)
+		if err != nil || len(chars) != len(b.Text) {
+			t.Fatalf("%s: got %d characters, %v", b.Text, len(chars), err)
+		}
+		last := chars[len(chars)-1]
+		if chars[0].c != '(' || chars[0].x < 0 || last.x+charWidth*last.size > float64(b.width())+1e-9 {
+			t.Errorf("%s: characters from %v to %v in %d modules", b.Text, chars[0], last, b.width())
+		}
+	}
+
+	b, _ := EncodeAztec("caf\xc3\xa9")
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, b); !errors.Is(err, ErrHRI) {
+		t.Errorf("wanted ErrHRI, got %v", err)
+	}
+}
+
+func TestWithHRI(t *testing.T) {
+
+	b, _ := Encode(gtin.MustParse("4006381333931"), Auto)
+	tests := []struct {
+		hri        HRI
+		want, skip string
+	}{
+		{HRIBelow, `viewBox="0 0 113 84"`, "never"},
+		{HRIAbove, "M11 9h1v75h-1z", "never"},
+		{HRINone, `viewBox="0 0 113 75"`, "stroke"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteSVG(&buf, b, WithHRI(tt.hri)); err != nil {
+			t.Fatal(err)
+		}
+		if svg := buf.String(); !strings.Contains(svg, tt.want) || strings.Contains(svg, tt.skip) {
+			t.Errorf("%d: wanted %q and no %q in\n%s", tt.hri, tt.want, tt.skip, svg)
+		}
+	}
+
+	// Text above the bars starts at the top
+	l, _ := lay(b, barHeight, []Option{WithHRI(HRIAbove)})
+	for _, line := range l.text {
+		for n := 1; n < len(line); n += 2 {
+			if line[n] < 0 || line[n] > textHeight {
+				t.Fatalf("text outside the top band: %v", line)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPS(&buf, b, Vector{}); err != nil {
+		t.Fatal(err)
+	}
+	if eps := buf.String(); !strings.Contains(eps, "setlinewidth") || !strings.Contains(eps, "stroke\n") {
+		t.Errorf("wanted stroked text in\n%s", eps)
+	}
+	buf.Reset()
+	if err := WritePDF(&buf, b, Vector{}); err != nil {
+		t.Fatal(err)
+	}
+	if pdf := buf.String(); !strings.Contains(pdf, " w 1 J 1 j\n") || !strings.Contains(pdf, "\nS\n") {
+		t.Errorf("wanted stroked text in\n%s", pdf)
+	}
+}
@@ -0,0 +1,137 @@
+package barcode
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Printer places a barcode on a label for thermal printers, which render
+// the symbol and its human readable interpretation themselves. The digits
+// are printed below the bars unless WithHRI(HRINone) is given. Lengths are
+// in printer dots; Calculate gives them for a printer resolution.
+type Printer struct {
+	X, Y   int // Position of the upper left corner of the symbol
+	Module int // Width of a module, 2 if zero
+	Height int // Height of the bars, that of barHeight modules if zero
+}
+
+// withDefaults returns p with zero sizes replaced by the defaults
+func (p Printer) withDefaults() Printer {
+	if p.Module <= 0 {
+		p.Module = 2
+	}
+	if p.Height <= 0 {
+		p.Height = barHeight * p.Module
+	}
+	return p
+}
+
+// Number of digits of each symbology
+var symbolDigits = map[string]int{
+	gtin.EAN13: 13,
+	gtin.EAN8:  8,
+	gtin.UPCA:  12,
+	gtin.ITF14: 14,
+}
+
+// printerData returns the digits of the barcode, zero padded to those of
+// its symbology
+func printerData(b Barcode) string {
+	s := strings.ReplaceAll(b.Text, " ", "")
+	if n := symbolDigits[b.Symbology]; len(s) < n {
+		s = strings.Repeat("0", n-len(s)) + s
+	}
+	return s
+}
+
+// EPL2 barcode types. The printer adds the check digit of EAN and UPC, so
+// it's not sent.
+var eplTypes = map[string]string{
+	gtin.EAN13: "E30",
+	gtin.EAN8:  "E80",
+	gtin.UPCA:  "UA0",
+	gtin.ITF14: "2",
+}
+
+// WriteEPL writes an EPL2 label with the barcode, for Eltron and Zebra
+// desktop printers
+func WriteEPL(w io.Writer, b Barcode, p Printer, opts ...Option) error {
+
+	name, ok := eplTypes[b.Symbology]
+	if !ok {
+		return fmt.Errorf("barcode: %s in EPL: %w", b.Symbology, gtin.ErrCarrier)
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p = p.withDefaults()
+	hri := "B"
+	if o.hri == HRINone {
+		hri = "N"
+	}
+	data := printerData(b)
+	if b.Symbology != gtin.ITF14 {
+		data = data[:len(data)-1]
+	}
+
+	var s strings.Builder
+	s.WriteString("\nN\n")
+	fmt.Fprintf(&s, "B%d,%d,0,%s,%d,%d,%d,%s,%q\n", p.X, p.Y, name, p.Module, p.Module*itfWide, p.Height, hri, data)
+	s.WriteString("P1\n")
+
+	_, err := io.WriteString(w, s.String())
+	return err
+}
+
+// IPL control characters
+const (
+	stx = "\x02"
+	etx = "\x03"
+	esc = "\x1b"
+	can = "\x18"
+	etb = "\x17"
+)
+
+// IPL barcode types. The check digit is sent with the data.
+var iplTypes = map[string]string{
+	gtin.ITF14: "2",
+	gtin.UPCA:  "7",
+	gtin.EAN8:  "9",
+	gtin.EAN13: "10",
+}
+
+// WriteIPL writes an Intermec IPL command stream that defines a format
+// with the barcode as format 1 and prints one label
+func WriteIPL(w io.Writer, b Barcode, p Printer, opts ...Option) error {
+
+	code, ok := iplTypes[b.Symbology]
+	if !ok {
+		return fmt.Errorf("barcode: %s in IPL: %w", b.Symbology, gtin.ErrCarrier)
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p = p.withDefaults()
+	hri := "1"
+	if o.hri == HRINone {
+		hri = "0"
+	}
+	data := printerData(b)
+
+	var s strings.Builder
+	s.WriteString(stx + esc + "C" + etx + "\n")
+	s.WriteString(stx + esc + "P" + etx + "\n")
+	s.WriteString(stx + "E1;F1;" + etx + "\n")
+	fmt.Fprintf(&s, "%sB0;f0;o%d,%d;c%s;h%d;w%d;i%s;d3,%s;%s\n", stx, p.X, p.Y, code, p.Height, p.Module, hri, data, etx)
+	s.WriteString(stx + "R;" + etx + "\n")
+	s.WriteString(stx + esc + "E1" + can + etx + "\n")
+	s.WriteString(stx + etb + etx + "\n")
+
+	_, err := io.WriteString(w, s.String())
+	return err
+}
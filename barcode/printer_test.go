@@ -0,0 +1,72 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestWriteEPL(t *testing.T) {
+
+	tests := []struct {
+		code string
+		p    Printer
+		opts []Option
+		want string
+	}{
+		{"4006381333931", Printer{}, nil, `B0,0,0,E30,2,6,140,B,"400638133393"`},
+		{"614141000012", Printer{X: 50, Y: 20, Module: 3, Height: 100}, nil, `B50,20,0,UA0,3,9,100,B,"61414100001"`},
+		{"96385074", Printer{}, []Option{WithHRI(HRINone)}, `B0,0,0,E80,2,6,140,N,"9638507"`},
+		{"50614141000994", Printer{Module: 4}, nil, `B0,0,0,2,4,12,280,B,"50614141000994"`},
+	}
+	for _, tt := range tests {
+		b, _ := Encode(gtin.MustParse(tt.code), Auto)
+		var buf bytes.Buffer
+		if err := WriteEPL(&buf, b, tt.p, tt.opts...); err != nil {
+			t.Fatal(err)
+		}
+		if want := "\nN\n" + tt.want + "\nP1\n"; buf.String() != want {
+			t.Errorf("%s: wanted %q, got %q", tt.code, want, buf.String())
+		}
+	}
+
+	if err := WriteEPL(new(bytes.Buffer), Barcode{Symbology: "QR"}, Printer{}); !errors.Is(err, gtin.ErrCarrier) {
+		t.Errorf("wanted ErrCarrier, got %v", err)
+	}
+}
+
+func TestWriteIPL(t *testing.T) {
+
+	tests := []struct {
+		code string
+		p    Printer
+		opts []Option
+		want string
+	}{
+		{"4006381333931", Printer{X: 10, Y: 30}, nil, "\x02B0;f0;o10,30;c10;h140;w2;i1;d3,4006381333931;\x03\n"},
+		{"614141000012", Printer{Module: 3}, []Option{WithHRI(HRINone)}, "\x02B0;f0;o0,0;c7;h210;w3;i0;d3,614141000012;\x03\n"},
+		{"96385074", Printer{Height: 80}, nil, "\x02B0;f0;o0,0;c9;h80;w2;i1;d3,96385074;\x03\n"},
+		{"50614141000994", Printer{}, nil, "\x02B0;f0;o0,0;c2;h140;w2;i1;d3,50614141000994;\x03\n"},
+	}
+	for _, tt := range tests {
+		b, _ := Encode(gtin.MustParse(tt.code), Auto)
+		var buf bytes.Buffer
+		if err := WriteIPL(&buf, b, tt.p, tt.opts...); err != nil {
+			t.Fatal(err)
+		}
+		ipl := buf.String()
+		if !strings.Contains(ipl, tt.want) {
+			t.Errorf("%s: wanted %q in %q", tt.code, tt.want, ipl)
+		}
+		if !strings.HasPrefix(ipl, "\x02\x1bC\x03\n") || !strings.HasSuffix(ipl, "\x02\x1bE1\x18\x03\n\x02\x17\x03\n") {
+			t.Errorf("%s: wanted a complete command stream, got %q", tt.code, ipl)
+		}
+	}
+
+	if err := WriteIPL(new(bytes.Buffer), Barcode{Symbology: "QR"}, Printer{}); !errors.Is(err, gtin.ErrCarrier) {
+		t.Errorf("wanted ErrCarrier, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package barcode
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+)
+
+// Height of the bars and the extension of guard bars, in modules
+const (
+	barHeight   = 70
+	guardHeight = 5
+	textHeight  = 9
+)
+
+// WriteSVG writes the barcode, with quiet zones and the human readable
+// interpretation, as an SVG image of one unit per module
+func WriteSVG(w io.Writer, b Barcode, opts ...Option) error {
+
+	l, err := lay(b, barHeight, opts)
+	if err != nil {
+		return err
+	}
+
+	var s strings.Builder
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %s %s" width="%s" height="%s">`+"\n",
+		num(l.width), num(l.height), num(l.width*2), num(l.height*2))
+	fmt.Fprintf(&s, "<title>%s</title>\n", html.EscapeString(b.Text))
+	fmt.Fprintf(&s, `<rect width="%s" height="%s" fill="#fff"/>`+"\n", num(l.width), num(l.height))
+
+	s.WriteString(`<path fill="#000" d="`)
+	for _, r := range l.bars {
+		fmt.Fprintf(&s, "M%s %sh%sv%sh-%sz", num(l.left+r.x), num(r.y), num(r.w), num(r.h), num(r.w))
+	}
+	s.WriteString("\"/>\n")
+
+	if len(l.text) > 0 {
+		fmt.Fprintf(&s, `<path fill="none" stroke="#000" stroke-width="%s" stroke-linecap="round" stroke-linejoin="round" d="`, num(strokeSize))
+		for _, line := range l.text {
+			for n := 0; n < len(line); n += 2 {
+				cmd := "L"
+				if n == 0 {
+					cmd = "M"
+				}
+				fmt.Fprintf(&s, "%s%s %s", cmd, num(l.left+line[n]), num(line[n+1]))
+			}
+		}
+		s.WriteString("\"/>\n")
+	}
+	s.WriteString("</svg>\n")
+
+	_, err = io.WriteString(w, s.String())
+	return err
+}
+
+// WritePNG writes the barcode, with quiet zones and the human readable
+// interpretation, as a PNG image with the given number of pixels per
+// module
+func WritePNG(w io.Writer, b Barcode, scale int, opts ...Option) error {
+
+	if scale < 1 {
+		scale = 1
+	}
+	l, err := lay(b, barHeight, opts)
+	if err != nil {
+		return err
+	}
+	img := image.NewGray(image.Rect(0, 0, int(l.width)*scale, int(l.height)*scale))
+	for n := range img.Pix {
+		img.Pix[n] = 0xff
+	}
+	for _, r := range l.bars {
+		for px := int(l.left+r.x) * scale; px < int(l.left+r.x+r.w)*scale; px++ {
+			for py := int(r.y) * scale; py < int(r.y+r.h)*scale; py++ {
+				img.SetGray(px, py, color.Gray{})
+			}
+		}
+	}
+
+	// Pixels with their centre within half a stroke of a line are inked
+	k := float64(scale)
+	for _, line := range l.text {
+		for n := 2; n < len(line); n += 2 {
+			x0, y0 := (l.left+line[n-2])*k, line[n-1]*k
+			x1, y1 := (l.left+line[n])*k, line[n+1]*k
+			half := strokeSize / 2 * k
+			for py := int(math.Min(y0, y1) - half); py <= int(math.Max(y0, y1)+half); py++ {
+				for px := int(math.Min(x0, x1) - half); px <= int(math.Max(x0, x1)+half); px++ {
+					if distance(float64(px)+0.5, float64(py)+0.5, x0, y0, x1, y1) <= half {
+						img.SetGray(px, py, color.Gray{})
+					}
+				}
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// distance returns the distance from x, y to the segment x0, y0 - x1, y1
+func distance(x, y, x0, y0, x1, y1 float64) float64 {
+	dx, dy := x1-x0, y1-y0
+	t := 0.0
+	if d := dx*dx + dy*dy; d > 0 {
+		t = math.Max(0, math.Min(1, ((x-x0)*dx+(y-y0)*dy)/d))
+	}
+	return math.Hypot(x-(x0+t*dx), y-(y0+t*dy))
+}
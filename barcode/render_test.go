@@ -0,0 +1,72 @@
+package barcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestWriteSVG(t *testing.T) {
+
+	b, err := Encode(gtin.MustParse("4006381333931"), Auto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, b); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	for _, want := range []string{`viewBox="0 0 113 84"`, "M11 0h1v75h-1z", "<title>4 006381 333931</title>", `stroke-width="0.9"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("wanted %q in\n%s", want, svg)
+		}
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+
+	b, err := Encode(gtin.MustParse("96385074"), Auto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, b, 2); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Dx(); got != (7+67+7)*2 {
+		t.Errorf("wanted width %d, got %d", (7+67+7)*2, got)
+	}
+	if r, _, _, _ := img.At(7*2, 0).RGBA(); r != 0 {
+		t.Error("wanted start guard bar at the quiet zone")
+	}
+	if got := img.Bounds().Dy(); got != (barHeight+guardHeight+textHeight)*2 {
+		t.Errorf("wanted height %d, got %d", (barHeight+guardHeight+textHeight)*2, got)
+	}
+
+	// The digits are drawn below the bars
+	dark := 0
+	for y := (barHeight + guardHeight) * 2; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r == 0 {
+				dark++
+			}
+		}
+	}
+	if dark == 0 {
+		t.Error("wanted the human readable interpretation")
+	}
+
+	buf.Reset()
+	WritePNG(&buf, b, 1, WithHRI(HRINone))
+	if img, _ := png.Decode(&buf); img.Bounds().Dy() != barHeight+guardHeight {
+		t.Errorf("wanted no text, got height %d", img.Bounds().Dy())
+	}
+}
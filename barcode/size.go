@@ -0,0 +1,64 @@
+package barcode
+
+import (
+	"fmt"
+	"math"
+)
+
+// mmPerInch converts printer resolutions to millimetres
+const mmPerInch = 25.4
+
+// Size is the printed size of a symbol. Printers can only draw whole dots,
+// so the X-dimension is rounded to a number of dots per module and differs
+// slightly from the requested magnification. Lengths are in millimetres.
+type Size struct {
+	Spec Spec
+	DPI  int // Printer resolution, in dots per inch
+	Dots int // Dots per module
+
+	X             float64 // Printed X-dimension
+	Magnification float64 // Printed X-dimension relative to the nominal
+
+	// Width includes the quiet zones; Height is the minimum bar height
+	Width, Height         float64
+	WidthDots, HeightDots int
+
+	// Bearer bar thickness, ITF-14 only
+	Bearer     float64
+	BearerDots int
+}
+
+// Calculate returns the size of the symbology printed at the given
+// magnification, e.g. 1.0 for the nominal X-dimension, and printer
+// resolution. Use Check to see if the result meets the specification.
+func Calculate(symbology string, magnification float64, dpi int) (Size, error) {
+
+	spec, err := Specification(symbology)
+	if err != nil {
+		return Size{}, err
+	}
+	if dpi <= 0 || magnification <= 0 {
+		return Size{}, fmt.Errorf("%w: magnification %v at %d dpi", ErrDimension, magnification, dpi)
+	}
+
+	dot := mmPerInch / float64(dpi)
+	s := Size{Spec: spec, DPI: dpi}
+	s.Dots = max(1, int(math.Round(spec.NominalX*magnification/dot)))
+	s.X = float64(s.Dots) * dot
+	s.Magnification = s.X / spec.NominalX
+
+	modules := spec.QuietZone[0] + spec.Modules + spec.QuietZone[1]
+	s.WidthDots = modules * s.Dots
+	s.Width = float64(s.WidthDots) * dot
+	s.HeightDots = int(math.Ceil(spec.MinHeight(s.X) / dot))
+	s.Height = float64(s.HeightDots) * dot
+	s.BearerDots = spec.Bearer * s.Dots
+	s.Bearer = float64(s.BearerDots) * dot
+	return s, nil
+}
+
+// Check returns ErrDimension if the printed symbol does not meet the
+// specification
+func (s Size) Check() error {
+	return s.Spec.Check(s.X, s.Height)
+}
@@ -0,0 +1,57 @@
+package barcode
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestCalculate(t *testing.T) {
+
+	tests := []struct {
+		symbology     string
+		magnification float64
+		dpi           int
+		dots, width   int
+		bearer        int
+		want          error
+	}{
+		{gtin.EAN13, 1, 300, 4, 113 * 4, 0, nil},
+		{gtin.EAN13, 1, 203, 3, 113 * 3, 0, nil},
+		{gtin.EAN8, 1.5, 600, 12, 81 * 12, 0, nil},
+		// Rounded up to 16 dots, above the 200% maximum
+		{gtin.EAN8, 2, 600, 16, 81 * 16, 0, ErrDimension},
+		// Rounded down to 2 dots, below the 80% minimum
+		{gtin.UPCA, 0.8, 203, 2, 113 * 2, 0, ErrDimension},
+		{gtin.ITF14, 1, 203, 8, 155 * 8, 16, nil},
+		{gtin.ITF14, 0.4, 300, 5, 155 * 5, 10, ErrDimension},
+	}
+	for _, tt := range tests {
+		s, err := Calculate(tt.symbology, tt.magnification, tt.dpi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Dots != tt.dots || s.WidthDots != tt.width || s.BearerDots != tt.bearer {
+			t.Errorf("%s at %v, %d dpi: got %d dots per module, %d wide, %d bearer", tt.symbology, tt.magnification, tt.dpi, s.Dots, s.WidthDots, s.BearerDots)
+		}
+		if err := s.Check(); !errors.Is(err, tt.want) {
+			t.Errorf("%s at %v, %d dpi: wanted %v, got %v", tt.symbology, tt.magnification, tt.dpi, tt.want, err)
+		}
+	}
+
+	s, _ := Calculate(gtin.EAN13, 1, 300)
+	if math.Abs(s.X-4*25.4/300) > 1e-9 || math.Abs(s.Width-113*s.X) > 1e-9 || s.Height < s.Spec.MinHeight(s.X) {
+		t.Errorf("got %+v", s)
+	}
+
+	for _, dpi := range []int{0, -300} {
+		if _, err := Calculate(gtin.EAN13, 1, dpi); !errors.Is(err, ErrDimension) {
+			t.Errorf("%d dpi: wanted ErrDimension, got %v", dpi, err)
+		}
+	}
+	if _, err := Calculate("QR", 1, 300); !errors.Is(err, gtin.ErrCarrier) {
+		t.Errorf("wanted ErrCarrier, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+)
+
+// ErrDimension is returned for symbols printed outside their specification
+var ErrDimension = errors.New("barcode: symbol dimensions out of range")
+
+// Spec is the physical specification of a symbology, from the symbol
+// specification tables of the GS1 General Specifications. Lengths are in
+// millimetres.
+type Spec struct {
+	Symbology string
+	Modules   int    // Width of the symbol in modules, without quiet zones
+	QuietZone [2]int // Left and right quiet zones, in modules
+
+	// Range of the X-dimension, the width of a module
+	MinX, NominalX, MaxX float64
+
+	// Height is the minimum bar height at the nominal X-dimension. It
+	// scales with the X-dimension, unless FixedHeight is set.
+	Height      float64
+	FixedHeight bool
+
+	// Bearer is the minimum thickness of bearer bars in modules, or 0 for
+	// symbologies without
+	Bearer int
+}
+
+// specs of the symbologies, for scanning at point-of-sale except ITF-14,
+// which is only scanned in general distribution
+var specs = map[string]Spec{
+	gtin.EAN13: {Modules: 95, MinX: 0.264, NominalX: 0.330, MaxX: 0.660, Height: 22.85},
+	gtin.UPCA:  {Modules: 95, MinX: 0.264, NominalX: 0.330, MaxX: 0.660, Height: 22.85},
+	gtin.EAN8:  {Modules: 67, MinX: 0.264, NominalX: 0.330, MaxX: 0.660, Height: 18.23},
+	gtin.ITF14: {Modules: 4 + 7*2*(3+2*itfWide) + itfWide + 2, MinX: 0.495, NominalX: 1.016, MaxX: 1.016, Height: 32, FixedHeight: true, Bearer: 2},
+}
+
+// Specification returns the physical specification of the symbology
+func Specification(symbology string) (Spec, error) {
+	s, ok := specs[symbology]
+	if !ok {
+		return Spec{}, fmt.Errorf("barcode: no specification of %q: %w", symbology, gtin.ErrCarrier)
+	}
+	s.Symbology = symbology
+	s.QuietZone = quietZones[symbology]
+	return s, nil
+}
+
+// Magnification returns the allowed range of the X-dimension relative to
+// the nominal, e.g. 0.8 to 2.0 for EAN-13
+func (s Spec) Magnification() (min, max float64) {
+	return s.MinX / s.NominalX, s.MaxX / s.NominalX
+}
+
+// MinHeight returns the minimum bar height at the X-dimension x
+func (s Spec) MinHeight(x float64) float64 {
+	if s.FixedHeight {
+		return s.Height
+	}
+	return s.Height * x / s.NominalX
+}
+
+// Width returns the width of the symbol with its quiet zones at the
+// X-dimension x
+func (s Spec) Width(x float64) float64 {
+	return float64(s.QuietZone[0]+s.Modules+s.QuietZone[1]) * x
+}
+
+// Check returns ErrDimension if a symbol printed with the X-dimension x
+// and bars of the given height is outside the specification
+func (s Spec) Check(x, height float64) error {
+	// Allow for rounding of the values in the tables
+	const tolerance = 1e-9
+	if x < s.MinX-tolerance || x > s.MaxX+tolerance {
+		return fmt.Errorf("%w: X-dimension %.3f mm of %s, not %.3f to %.3f mm", ErrDimension, x, s.Symbology, s.MinX, s.MaxX)
+	}
+	if min := s.MinHeight(x); height < min-tolerance {
+		return fmt.Errorf("%w: bar height %.2f mm of %s, below %.2f mm", ErrDimension, height, s.Symbology, min)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package barcode
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestSpecification(t *testing.T) {
+
+	// The module counts match the encoded symbols
+	for _, code := range []string{"4006381333931", "614141000012", "96385074", "50614141000994"} {
+		b, _ := Encode(gtin.MustParse(code), Auto)
+		s, err := Specification(b.Symbology)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Modules != len(b.Bars) {
+			t.Errorf("%s: %d modules, encoded %d", s.Symbology, s.Modules, len(b.Bars))
+		}
+		if left, right := b.QuietZone(); s.QuietZone != [2]int{left, right} {
+			t.Errorf("%s: quiet zone %v", s.Symbology, s.QuietZone)
+		}
+	}
+
+	s, _ := Specification(gtin.EAN13)
+	if min, max := s.Magnification(); math.Abs(min-0.8) > 1e-9 || math.Abs(max-2) > 1e-9 {
+		t.Errorf("got magnification %v to %v", min, max)
+	}
+	if w := s.Width(s.NominalX); math.Abs(w-37.29) > 1e-9 {
+		t.Errorf("got width %v", w)
+	}
+	if h := s.MinHeight(s.MaxX); math.Abs(h-45.7) > 1e-9 {
+		t.Errorf("got height %v", h)
+	}
+
+	tests := []struct {
+		symbology string
+		x, height float64
+		want      error
+	}{
+		{gtin.EAN13, 0.330, 22.85, nil},
+		{gtin.EAN13, 0.264, 18.28, nil},
+		{gtin.EAN13, 0.660, 30, ErrDimension},
+		{gtin.EAN13, 0.2, 30, ErrDimension},
+		{gtin.EAN8, 0.7, 30, ErrDimension},
+		{gtin.ITF14, 0.495, 32, nil},
+		{gtin.ITF14, 1.016, 31, ErrDimension},
+	}
+	for _, tt := range tests {
+		s, _ := Specification(tt.symbology)
+		if err := s.Check(tt.x, tt.height); !errors.Is(err, tt.want) {
+			t.Errorf("%s %v x %v: wanted %v, got %v", tt.symbology, tt.x, tt.height, tt.want, err)
+		}
+	}
+
+	if _, err := Specification("QR"); !errors.Is(err, gtin.ErrCarrier) {
+		t.Errorf("wanted ErrCarrier, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package barcode
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ptPerMM converts millimetres to PostScript points
+const ptPerMM = 72 / mmPerInch
+
+// Vector configures EPS and PDF output. Lengths are in millimetres.
+type Vector struct {
+	// X is the X-dimension, the nominal of the symbology if zero
+	X float64
+
+	// BWR is the bar width reduction, subtracted from the width of every
+	// bar to compensate for ink spread on press. Half is taken from each
+	// edge, so the bar centres don't move.
+	BWR float64
+}
+
+// rect is a bar in points, from the lower left corner of the page
+type rect struct {
+	x, y, w, h float64
+}
+
+// page is a barcode laid out in points
+type page struct {
+	bars          []rect
+	text          [][]float64 // Strokes, as x, y pairs from the lower left
+	stroke        float64     // Width of the strokes
+	width, height float64
+}
+
+// layout lays out the barcode in points
+func (o Vector) layout(b Barcode, opts []Option) (page, error) {
+
+	x, height := o.X, 0.0
+	if spec, err := Specification(b.Symbology); err == nil {
+		if x == 0 {
+			x = spec.NominalX
+		}
+		height = spec.MinHeight(x) / x
+	} else if x == 0 {
+		return page{}, err
+	}
+	if height == 0 {
+		height = barHeight
+	}
+	if o.BWR < 0 || o.BWR >= x {
+		return page{}, fmt.Errorf("%w: bar width reduction %.3f mm at X-dimension %.3f mm", ErrDimension, o.BWR, x)
+	}
+
+	// From modules down the page to points up the page
+	l, err := lay(b, height, opts)
+	if err != nil {
+		return page{}, err
+	}
+	k := x * ptPerMM
+	bwr := o.BWR * ptPerMM
+	p := page{stroke: strokeSize * k, width: l.width * k, height: l.height * k}
+	for _, r := range l.bars {
+		p.bars = append(p.bars, rect{(l.left+r.x)*k + bwr/2, (l.height - r.y - r.h) * k, r.w*k - bwr, r.h * k})
+	}
+	for _, line := range l.text {
+		points := make([]float64, len(line))
+		for n := 0; n < len(line); n += 2 {
+			points[n] = (l.left + line[n]) * k
+			points[n+1] = (l.height - line[n+1]) * k
+		}
+		p.text = append(p.text, points)
+	}
+	return p, nil
+}
+
+// num formats a length rounded to a thousandth, without trailing zeros
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
+
+// WriteEPS writes the barcode, with quiet zones, as Encapsulated
+// PostScript, with the human readable interpretation drawn as strokes
+func WriteEPS(w io.Writer, b Barcode, o Vector, opts ...Option) error {
+
+	p, err := o.layout(b, opts)
+	if err != nil {
+		return err
+	}
+
+	var s strings.Builder
+	s.WriteString("%!PS-Adobe-3.0 EPSF-3.0\n")
+	fmt.Fprintf(&s, "%%%%BoundingBox: 0 0 %d %d\n", int(math.Ceil(p.width)), int(math.Ceil(p.height)))
+	fmt.Fprintf(&s, "%%%%HiResBoundingBox: 0 0 %s %s\n", num(p.width), num(p.height))
+	fmt.Fprintf(&s, "%%%%Title: %s %s\n", b.Symbology, b.Text)
+	s.WriteString("%%EndComments\n")
+	s.WriteString("0 setgray\n")
+	for _, r := range p.bars {
+		fmt.Fprintf(&s, "%s %s %s %s rectfill\n", num(r.x), num(r.y), num(r.w), num(r.h))
+	}
+	if len(p.text) > 0 {
+		fmt.Fprintf(&s, "%s setlinewidth 1 setlinecap 1 setlinejoin\n", num(p.stroke))
+		for _, line := range p.text {
+			for n := 0; n < len(line); n += 2 {
+				op := "lineto"
+				if n == 0 {
+					op = "moveto"
+				}
+				fmt.Fprintf(&s, "%s %s %s\n", num(line[n]), num(line[n+1]), op)
+			}
+		}
+		s.WriteString("stroke\n")
+	}
+	s.WriteString("showpage\n%%EOF\n")
+
+	_, err = io.WriteString(w, s.String())
+	return err
+}
+
+// WritePDF writes the barcode, with quiet zones, as a PDF of a single page
+// the size of the symbol, with the human readable interpretation drawn as
+// strokes
+func WritePDF(w io.Writer, b Barcode, o Vector, opts ...Option) error {
+
+	p, err := o.layout(b, opts)
+	if err != nil {
+		return err
+	}
+
+	var content strings.Builder
+	content.WriteString("0 g\n")
+	for _, r := range p.bars {
+		fmt.Fprintf(&content, "%s %s %s %s re\n", num(r.x), num(r.y), num(r.w), num(r.h))
+	}
+	content.WriteString("f\n")
+	if len(p.text) > 0 {
+		fmt.Fprintf(&content, "0 G %s w 1 J 1 j\n", num(p.stroke))
+		for _, line := range p.text {
+			for n := 0; n < len(line); n += 2 {
+				op := "l"
+				if n == 0 {
+					op = "m"
+				}
+				fmt.Fprintf(&content, "%s %s %s\n", num(line[n]), num(line[n+1]), op)
+			}
+		}
+		content.WriteString("S\n")
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents 4 0 R /Resources << >> >>", num(p.width), num(p.height)),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for n, obj := range objects {
+		offsets[n] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", n+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,96 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestWriteEPS(t *testing.T) {
+
+	b, err := Encode(gtin.MustParse("4006381333931"), Auto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteEPS(&buf, b, Vector{BWR: 0.02}, WithHRI(HRINone)); err != nil {
+		t.Fatal(err)
+	}
+	eps := buf.String()
+	for _, want := range []string{"%!PS-Adobe-3.0 EPSF-3.0\n", "%%BoundingBox: 0 0 106 70\n", "%%Title: EAN-13 4 006381 333931\n", "%%EOF\n"} {
+		if !strings.Contains(eps, want) {
+			t.Errorf("wanted %q in\n%s", want, eps)
+		}
+	}
+
+	// One rectangle per bar, narrowed by the bar width reduction
+	var rects [][4]float64
+	for _, line := range strings.Split(eps, "\n") {
+		if f := strings.Fields(line); len(f) == 5 && f[4] == "rectfill" {
+			var r [4]float64
+			for n := range r {
+				r[n], _ = strconv.ParseFloat(f[n], 64)
+			}
+			rects = append(rects, r)
+		}
+	}
+	if want := strings.Count("0"+modules(b), "01"); len(rects) != want {
+		t.Fatalf("wanted %d bars, got %d", want, len(rects))
+	}
+	mm := func(v float64) float64 { return v * 72 / 25.4 }
+	if first := rects[0]; math.Abs(first[0]-mm(11*0.33+0.01)) > 1e-3 || math.Abs(first[2]-mm(0.31)) > 1e-3 || first[1] != 0 {
+		t.Errorf("got first bar %v", first)
+	}
+	if second := rects[2]; second[1] == 0 {
+		t.Errorf("wanted a shorter data bar, got %v", second)
+	}
+
+	if err := WriteEPS(&buf, b, Vector{BWR: 0.4}); !errors.Is(err, ErrDimension) {
+		t.Errorf("wanted ErrDimension, got %v", err)
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+
+	b, err := Encode(gtin.MustParse("50614141000994"), Auto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, b, Vector{X: 0.5}, WithHRI(HRINone)); err != nil {
+		t.Fatal(err)
+	}
+	pdf := buf.String()
+	if !strings.HasPrefix(pdf, "%PDF-1.4\n") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatalf("not a PDF:\n%s", pdf)
+	}
+	if want := fmt.Sprintf("/MediaBox [0 0 %s %s]", num(155*0.5*72/25.4), num((32+5*0.5)*72/25.4)); !strings.Contains(pdf, want) {
+		t.Errorf("wanted %q in\n%s", want, pdf)
+	}
+
+	// The cross-reference table points at the objects
+	var xref int
+	fmt.Sscanf(pdf[strings.LastIndex(pdf, "startxref\n"):], "startxref\n%d", &xref)
+	if !strings.HasPrefix(pdf[xref:], "xref\n0 5\n") {
+		t.Fatalf("wrong startxref %d", xref)
+	}
+	for n, line := range strings.Split(pdf[xref:], "\n")[3:7] {
+		offset, _ := strconv.Atoi(line[:10])
+		if want := fmt.Sprintf("%d 0 obj\n", n+1); !strings.HasPrefix(pdf[offset:], want) {
+			t.Errorf("offset %d: wanted %q", offset, want)
+		}
+	}
+
+	start := strings.Index(pdf, "stream\n") + len("stream\n")
+	var length int
+	fmt.Sscanf(pdf[strings.Index(pdf, "/Length "):], "/Length %d", &length)
+	if !strings.HasPrefix(pdf[start+length:], "endstream") {
+		t.Errorf("wrong stream length %d", length)
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Package bulk validates GTINs in large delimited files, such as nightly
+full-catalog exports.
+
+Files are memory-mapped where the platform supports it and scanned in
+place, so multi-gigabyte files don't need to fit in the heap and lines are
+never copied. Only the field holding the code is converted to a string.
+
+Directory trees, or any fs.FS, are validated file by file in parallel and
+the results merged into one Report.
+
+A Job validates a file with checkpoints, so that runs over billions of
+lines resume after a crash instead of starting over.
+*/
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// progressLines is the number of lines between progress reports
+const progressLines = 10000
+
+// Validator validates one column of delimited files. Fields may be quoted,
+// but not span lines.
+type Validator struct {
+	Column int  // Index of the field holding the codes, from 0
+	Comma  byte // Field delimiter, ',' if zero
+	Header bool // Skip the first line
+
+	// Policy validates the parsed GTINs, gtin.DefaultPolicy() if nil.
+	// Only errors make a code invalid.
+	Policy *gtin.Policy
+
+	// OnError, if not nil, is called for every invalid code, with its line
+	// number from 1. The field is only valid during the call.
+	OnError func(line int, field []byte, err error)
+
+	// OnFileError, if not nil, is called like OnError by ValidateDir and
+	// ValidateFS, with the slash-separated path of the file
+	OnFileError func(path string, line int, field []byte, err error)
+
+	// OnGTIN, if not nil, is called for every valid code
+	OnGTIN func(line int, gt gtin.GTIN)
+
+	// Progress, if not nil, is called every progressLines lines and at the
+	// end. For files, the total is estimated from the bytes scanned so far.
+	Progress gtin.ProgressFunc
+}
+
+// ValidateFile validates the file at path
+func (v *Validator) ValidateFile(path string) (*gtin.Stats, error) {
+
+	data, release, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return v.Validate(data), nil
+}
+
+// Validate validates the lines of data. Empty lines are skipped.
+func (v *Validator) Validate(data []byte) *gtin.Stats {
+	c := Checkpoint{Stats: new(gtin.Stats)}
+	v.run(context.Background(), data, &c, 0, nil)
+	return c.Stats
+}
+
+// run validates the lines of data from the checkpoint on, advancing it.
+// If save is not nil, it's called with the checkpoint every `every` lines,
+// and when ctx is cancelled, before returning its error.
+func (v *Validator) run(ctx context.Context, data []byte, c *Checkpoint, every int, save func(Checkpoint) error) error {
+
+	comma := v.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	policy := v.Policy
+	if policy == nil {
+		p := gtin.DefaultPolicy()
+		policy = &p
+	}
+
+	start := time.Now()
+	var done, invalid int
+	if c.Line > 0 {
+		r := c.Stats.Report(0)
+		done, invalid = r.Total, r.Invalid
+	}
+	report := func(total int) {
+		if v.Progress != nil {
+			v.Progress(gtin.Progress{Done: done, Errors: invalid, Total: total, Elapsed: time.Since(start)})
+		}
+	}
+
+	for offset := int(c.Offset); offset < len(data); {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			end = len(data)
+		} else {
+			end += offset
+		}
+		text := bytes.TrimSuffix(data[offset:end], []byte{'\r'})
+		offset = min(end+1, len(data))
+		c.Line++
+		c.Offset = int64(offset)
+		if len(text) != 0 && !(c.Line == 1 && v.Header) {
+			f := field(text, v.Column, comma)
+			gt, err := gtin.Parse(string(f))
+			if err == nil {
+				if report := policy.Validate(gt); !report.OK() {
+					err = errors.Join(report.Errors...)
+				}
+			}
+			c.Stats.Add(gt, err)
+			done++
+			switch {
+			case err != nil:
+				invalid++
+				if v.OnError != nil {
+					v.OnError(c.Line, f, err)
+				}
+			case v.OnGTIN != nil:
+				v.OnGTIN(c.Line, gt)
+			}
+			if done%progressLines == 0 {
+				report(max(estimateLines(done, offset, len(data)), done+1))
+			}
+		}
+
+		if save == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			if err := save(*c); err != nil {
+				return err
+			}
+			return ctx.Err()
+		}
+		if c.Line%every == 0 {
+			if err := save(*c); err != nil {
+				return err
+			}
+		}
+	}
+	report(done)
+	return nil
+}
+
+// field returns field n of the line, without quotes and surrounding
+// space, or nil if the line has fewer fields
+func field(line []byte, n int, comma byte) []byte {
+
+	for ; n > 0; n-- {
+		i := indexUnquoted(line, comma)
+		if i < 0 {
+			return nil
+		}
+		line = line[i+1:]
+	}
+	if i := indexUnquoted(line, comma); i >= 0 {
+		line = line[:i]
+	}
+	line = bytes.TrimSpace(line)
+	if len(line) >= 2 && line[0] == '"' && line[len(line)-1] == '"' {
+		line = bytes.TrimSpace(line[1 : len(line)-1])
+	}
+	return line
+}
+
+// indexUnquoted returns the index of the first comma outside quotes
+func indexUnquoted(line []byte, comma byte) int {
+	quoted := false
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == comma && !quoted:
+			return i
+		}
+	}
+	return -1
+}
+
+// estimateLines extrapolates the number of lines of a file of size bytes
+// from the lines done in the first offset bytes. It's computed in floating
+// point, as done*size overflows int for files of many gigabytes.
+func estimateLines(done, offset, size int) int {
+	return int(float64(done) * float64(size) / float64(offset))
+}
@@ -0,0 +1,116 @@
+package bulk
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+const feed = "sku;name;ean\r\n" +
+	"A;\"Milk; 1 l\";4006381333931\r\n" +
+	"B;Bread;\"4006381333932\"\r\n" +
+	"\r\n" +
+	"C;Butter; 614141000012 \r\n" +
+	"D;Cheese\r\n" +
+	"E;Eggs;4006381333931"
+
+func TestValidateFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "feed.csv")
+	if err := os.WriteFile(path, []byte(feed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []int
+	var last gtin.Progress
+	v := Validator{
+		Column: 2,
+		Comma:  ';',
+		Header: true,
+		OnError: func(line int, field []byte, err error) {
+			lines = append(lines, line)
+		},
+		Progress: func(p gtin.Progress) { last = p },
+	}
+	stats, err := v.ValidateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := stats.Report(-1)
+	if r.Total != 5 || r.Valid != 3 || r.Invalid != 2 {
+		t.Errorf("got %+v", r)
+	}
+	if want := []int{3, 6}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("wanted errors on lines %v, got %v", want, lines)
+	}
+	if len(r.Duplicates) != 1 || r.Duplicates[0].Count != 2 {
+		t.Errorf("got duplicates %v", r.Duplicates)
+	}
+	if last.Done != 5 || last.Total != 5 || last.Errors != 2 {
+		t.Errorf("got progress %+v", last)
+	}
+}
+
+func TestValidateEmptyFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "empty.csv")
+	os.WriteFile(path, nil, 0o644)
+	stats, err := new(Validator).ValidateFile(path)
+	if err != nil || stats.Report(0).Total != 0 {
+		t.Errorf("got %+v, %v", stats, err)
+	}
+
+	if _, err := new(Validator).ValidateFile(filepath.Join(t.TempDir(), "missing.csv")); !os.IsNotExist(err) {
+		t.Errorf("wanted not exist, got %v", err)
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+
+	policy := gtin.DefaultPolicy()
+	policy.Errors = append(policy.Errors, gtin.AllowTypes(gtin.GTIN13))
+	v := Validator{Policy: &policy}
+	r := v.Validate([]byte(strings.Join([]string{"4006381333931", "614141000012"}, "\n"))).Report(0)
+	if r.Valid != 1 || r.Errors["GTIN_E015_NOT_ALLOWED"] != 1 {
+		t.Errorf("got %+v", r)
+	}
+}
+
+func TestField(t *testing.T) {
+
+	for _, test := range []struct {
+		line string
+		n    int
+		want string
+	}{
+		{`a,b,c`, 0, "a"},
+		{`a,b,c`, 2, "c"},
+		{`a,"b,c",d`, 2, "d"},
+		{`a," b ",d`, 1, "b"},
+		{`a,b`, 2, ""},
+	} {
+		if got := string(field([]byte(test.line), test.n, ',')); got != test.want {
+			t.Errorf("%q field %d: wanted %q, got %q", test.line, test.n, test.want, got)
+		}
+	}
+}
+
+func TestEstimateLines(t *testing.T) {
+
+	if got := estimateLines(100, 1000, 10000); got != 1000 {
+		t.Errorf("wanted 1000, got %d", got)
+	}
+
+	// done*size overflows int, the estimate must not
+	size := math.MaxInt / 2
+	got := estimateLines(1000000, 4000000, size)
+	if want := float64(size) / 4; got <= 0 || math.Abs(float64(got)-want) > want*1e-9 {
+		t.Errorf("wanted about %.0f, got %d", want, got)
+	}
+}
@@ -0,0 +1,69 @@
+package bulk
+
+import (
+	"sort"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Location is a line of a file
+type Location struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+}
+
+// Collision is a GTIN found in more than one file
+type Collision struct {
+	GTIN      gtin.GTIN
+	Locations []Location // In file and line order
+}
+
+// Collisions returns the valid GTINs that occur in more than one of the
+// files, e.g. in feeds of different suppliers, sorted by GTIN. GTINs are
+// compared as GTIN-14, so 614141000012 and 00614141000012 collide.
+// Repeats within one file are only listed if the GTIN is in another file
+// too. The callbacks of v are called for each file in turn.
+func (v *Validator) Collisions(paths ...string) ([]Collision, error) {
+
+	// Locations by GTIN, with the index of the first file
+	type seen struct {
+		file      int
+		locations []Location
+		multiple  bool
+	}
+	all := make(map[uint64]*seen)
+
+	for file, path := range paths {
+		scan := *v
+		scan.OnGTIN = func(line int, gt gtin.GTIN) {
+			if v.OnGTIN != nil {
+				v.OnGTIN(line, gt)
+			}
+			key := gt.Uint64()
+			s, ok := all[key]
+			if !ok {
+				s = &seen{file: file}
+				all[key] = s
+			}
+			s.locations = append(s.locations, Location{path, line})
+			s.multiple = s.multiple || s.file != file
+		}
+		if _, err := scan.ValidateFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var keys []uint64
+	for key, s := range all {
+		if s.multiple {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	collisions := make([]Collision, len(keys))
+	for n, key := range keys {
+		gt, _ := gtin.FromUint64(key, gtin.GTIN14)
+		collisions[n] = Collision{gt, all[key].locations}
+	}
+	return collisions, nil
+}
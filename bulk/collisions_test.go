@@ -0,0 +1,53 @@
+package bulk
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCollisions(t *testing.T) {
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.csv": "sku,gtin\nA1,4006381333931\nA2,96385074\nA3,4006381333931\n",
+		"b.csv": "sku,gtin\nB1,00614141000012\nB2,04006381333931\nB3,4006381333932\n",
+		"c.csv": "sku,gtin\nC1,614141000012\nC2,96385074 \nC3,12345670\nC4,12345670\n",
+	}
+	var paths []string
+	for _, name := range []string{"a.csv", "b.csv", "c.csv"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	a, b, c := paths[0], paths[1], paths[2]
+
+	v := Validator{Column: 1, Header: true}
+	collisions, err := v.Collisions(paths...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		gtin      string
+		locations []Location
+	}{
+		{"00000096385074", []Location{{a, 3}, {c, 3}}},
+		{"00614141000012", []Location{{b, 2}, {c, 2}}},
+		{"04006381333931", []Location{{a, 2}, {a, 4}, {b, 3}}},
+	}
+	if len(collisions) != len(want) {
+		t.Fatalf("got %+v", collisions)
+	}
+	for n, w := range want {
+		if collisions[n].GTIN.String() != w.gtin || !reflect.DeepEqual(collisions[n].Locations, w.locations) {
+			t.Errorf("wanted %s at %v, got %+v", w.gtin, w.locations, collisions[n])
+		}
+	}
+
+	if _, err := v.Collisions(a, filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("wanted an error for a missing file")
+	}
+}
@@ -0,0 +1,144 @@
+package bulk
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// FileResult is the outcome of validating one file
+type FileResult struct {
+	Path  string // Slash-separated, relative to the root
+	Stats *gtin.Stats
+	Err   error // If the file, or directory, could not be read
+}
+
+// Report is the outcome of validating many files
+type Report struct {
+	Files []FileResult // In walk order
+	Stats *gtin.Stats  // Merged over all files that could be read
+}
+
+// ValidateDir validates the files in the directory tree at dir whose names
+// match pattern, see ValidateFS. Files are memory-mapped.
+func (v *Validator) ValidateDir(dir, pattern string, workers int) (*Report, error) {
+	return v.validateTree(os.DirFS(dir), pattern, workers, func(w *Validator, name string) (*gtin.Stats, error) {
+		return w.ValidateFile(filepath.Join(dir, filepath.FromSlash(name)))
+	})
+}
+
+// ValidateFS validates the files in fsys whose names match pattern, as in
+// path.Match, with up to workers files at a time. An empty pattern matches
+// all files. A file or subdirectory that can't be read gets an error in its
+// FileResult and doesn't stop the others; only errors reading the root or
+// a bad pattern are returned.
+//
+// OnError, OnFileError and Progress are never called concurrently. OnError
+// gets the line numbers within each file, OnFileError the path too.
+// Progress counts files, with the ones that could not be read as errors.
+func (v *Validator) ValidateFS(fsys fs.FS, pattern string, workers int) (*Report, error) {
+	return v.validateTree(fsys, pattern, workers, func(w *Validator, name string) (*gtin.Stats, error) {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		return w.Validate(data), nil
+	})
+}
+
+// validateTree walks fsys and validates the matching files with validate,
+// which is called with copies of v with serialized callbacks
+func (v *Validator) validateTree(fsys fs.FS, pattern string, workers int, validate func(w *Validator, name string) (*gtin.Stats, error)) (*Report, error) {
+
+	var names []string
+	unreadable := make(map[string]error)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == "." {
+				return err
+			}
+			names = append(names, name)
+			unreadable[name] = err
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if ok, err := path.Match(pattern, d.Name()); pattern == "" || ok {
+				names = append(names, name)
+			} else if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	progress := gtin.Progress{Total: len(names)}
+	start := time.Now()
+
+	// file returns a copy of v with serialized callbacks for the file
+	file := func(name string) *Validator {
+		w := *v
+		w.Progress = nil
+		if v.OnError != nil || v.OnFileError != nil {
+			w.OnError = func(line int, field []byte, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if v.OnError != nil {
+					v.OnError(line, field, err)
+				}
+				if v.OnFileError != nil {
+					v.OnFileError(name, line, field, err)
+				}
+			}
+		}
+		return &w
+	}
+
+	report := &Report{Files: make([]FileResult, len(names)), Stats: new(gtin.Stats)}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range next {
+				var stats *gtin.Stats
+				err, ok := unreadable[names[n]]
+				if !ok {
+					stats, err = validate(file(names[n]), names[n])
+				}
+				report.Files[n] = FileResult{Path: names[n], Stats: stats, Err: err}
+
+				mu.Lock()
+				if err == nil {
+					report.Stats.Merge(stats)
+				} else {
+					progress.Errors++
+				}
+				progress.Done++
+				progress.Elapsed = time.Since(start)
+				if v.Progress != nil {
+					v.Progress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for n := range names {
+		next <- n
+	}
+	close(next)
+	wg.Wait()
+	return report, nil
+}
@@ -0,0 +1,103 @@
+package bulk
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestValidateFS(t *testing.T) {
+
+	fsys := fstest.MapFS{
+		"a.csv":         {Data: []byte("4006381333931\n614141000012\n")},
+		"sub/b.csv":     {Data: []byte("4006381333931\n12345\n")},
+		"sub/notes.txt": {Data: []byte("not codes\n")},
+		"sub/c.csv":     {Data: []byte("4006381333931\n")},
+	}
+	var errs, calls int
+	var last gtin.Progress
+	var paths []string
+	v := Validator{
+		OnError:     func(line int, field []byte, err error) { errs++ },
+		OnFileError: func(path string, line int, field []byte, err error) { paths = append(paths, path) },
+		Progress:    func(p gtin.Progress) { calls++; last = p },
+	}
+	report, err := v.ValidateFS(fsys, "*.csv", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Files) != 3 || report.Files[0].Path != "a.csv" || report.Files[2].Path != "sub/c.csv" {
+		t.Fatalf("got %+v", report.Files)
+	}
+	r := report.Stats.Report(-1)
+	if r.Total != 5 || r.Invalid != 1 || errs != 1 {
+		t.Errorf("got %+v and %d errors", r, errs)
+	}
+	if len(paths) != 1 || paths[0] != "sub/b.csv" {
+		t.Errorf("wanted the error in sub/b.csv, got %v", paths)
+	}
+	if len(r.Duplicates) != 1 || r.Duplicates[0].Count != 3 {
+		t.Errorf("wanted a duplicate across files, got %v", r.Duplicates)
+	}
+	if calls != 3 || last.Done != 3 || last.Total != 3 {
+		t.Errorf("got %d progress calls, last %+v", calls, last)
+	}
+}
+
+// failingFS fails to open one file and one directory
+type failingFS struct{ files fstest.MapFS }
+
+func (f failingFS) Open(name string) (fs.File, error) {
+	if name == "bad.csv" || name == "locked" {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return f.files.Open(name)
+}
+
+func TestValidateFSIsolation(t *testing.T) {
+
+	fsys := failingFS{fstest.MapFS{
+		"bad.csv":      {Data: []byte("4006381333931\n")},
+		"good.csv":     {Data: []byte("4006381333931\n")},
+		"locked/c.csv": {Data: []byte("4006381333931\n")},
+	}}
+	report, err := new(Validator).ValidateFS(fsys, "*.csv", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 3 || !errors.Is(report.Files[0].Err, fs.ErrPermission) || report.Files[1].Err != nil {
+		t.Fatalf("got %+v", report.Files)
+	}
+	if f := report.Files[2]; f.Path != "locked" || !errors.Is(f.Err, fs.ErrPermission) {
+		t.Errorf("wanted the unreadable directory in the report, got %+v", f)
+	}
+	if r := report.Stats.Report(0); r.Total != 1 {
+		t.Errorf("wanted the good file counted, got %+v", r)
+	}
+}
+
+func TestValidateDir(t *testing.T) {
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "2026"), 0o755)
+	os.WriteFile(filepath.Join(dir, "2026", "feed.csv"), []byte("ean\n4006381333931\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "feed.tsv"), []byte("ean\n4006381333931\n"), 0o644)
+
+	report, err := (&Validator{Header: true}).ValidateDir(dir, "*.csv", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 1 || report.Files[0].Path != "2026/feed.csv" || report.Stats.Report(0).Valid != 1 {
+		t.Errorf("got %+v", report)
+	}
+
+	if _, err := new(Validator).ValidateDir(filepath.Join(dir, "missing"), "", 1); err == nil {
+		t.Error("wanted an error for a missing directory")
+	}
+}
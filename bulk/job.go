@@ -0,0 +1,193 @@
+package bulk
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/peterstark72/gtin"
+)
+
+// defaultEvery is the default number of lines between checkpoints
+const defaultEvery = 1000000
+
+var (
+	// ErrFileChanged is returned when resuming a job whose file has
+	// changed size since the checkpoint
+	ErrFileChanged = errors.New("bulk: file changed since checkpoint")
+
+	// ErrCheckpoint is returned for malformed checkpoints
+	ErrCheckpoint = errors.New("bulk: invalid checkpoint")
+)
+
+// Checkpoint is the state of a Job after a number of lines
+type Checkpoint struct {
+	Size   int64 // Size of the file
+	Offset int64 // Bytes done
+	Line   int   // Lines done
+	Done   bool  // The file is done
+	Stats  *gtin.Stats
+}
+
+// MarshalBinary encodes the checkpoint, for stores of bytes
+func (c Checkpoint) MarshalBinary() ([]byte, error) {
+	stats, err := c.Stats.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	b := binary.AppendUvarint(nil, uint64(c.Size))
+	b = binary.AppendUvarint(b, uint64(c.Offset))
+	b = binary.AppendUvarint(b, uint64(c.Line))
+	if c.Done {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	return append(b, stats...), nil
+}
+
+// UnmarshalBinary decodes a checkpoint encoded by MarshalBinary
+func (c *Checkpoint) UnmarshalBinary(data []byte) error {
+	var fields [3]uint64
+	for n := range fields {
+		v, size := binary.Uvarint(data)
+		if size <= 0 {
+			return ErrCheckpoint
+		}
+		fields[n], data = v, data[size:]
+	}
+	if len(data) == 0 || data[0] > 1 {
+		return ErrCheckpoint
+	}
+	stats := new(gtin.Stats)
+	if err := stats.UnmarshalBinary(data[1:]); err != nil {
+		return fmt.Errorf("%w: %v", ErrCheckpoint, err)
+	}
+	*c = Checkpoint{int64(fields[0]), int64(fields[1]), int(fields[2]), data[0] == 1, stats}
+	return nil
+}
+
+// CheckpointStore persists the checkpoints of jobs by key. Save must
+// encode the checkpoint before it returns; its Stats keep changing.
+type CheckpointStore interface {
+	// Load returns the checkpoint of key, or false if there is none
+	Load(key string) (Checkpoint, bool, error)
+	Save(key string, c Checkpoint) error
+}
+
+// DirStore is a CheckpointStore keeping checkpoints as files in a
+// directory. Files are replaced atomically, so a crash while saving leaves
+// the previous checkpoint.
+type DirStore string
+
+// path returns the file of key
+func (d DirStore) path(key string) string {
+	return filepath.Join(string(d), url.PathEscape(key)+".checkpoint")
+}
+
+// Load reads the checkpoint of key
+func (d DirStore) Load(key string) (Checkpoint, bool, error) {
+	data, err := os.ReadFile(d.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return Checkpoint{}, false, nil
+	}
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+	var c Checkpoint
+	if err := c.UnmarshalBinary(data); err != nil {
+		return Checkpoint{}, false, err
+	}
+	return c, true, nil
+}
+
+// Save writes the checkpoint of key
+func (d DirStore) Save(key string, c Checkpoint) error {
+	data, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(string(d), ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), d.path(key))
+}
+
+// Job validates a file with a Validator, saving checkpoints to a store as
+// it goes, so that runs over huge files resume where they stopped after a
+// crash or cancellation instead of starting over
+type Job struct {
+	Validator
+	Store CheckpointStore
+	Key   string // Key of the checkpoints, the path of the file if empty
+	Every int    // Lines between checkpoints, 1000000 if zero
+
+	// Duplicates makes the stats track duplicates, see
+	// gtin.Stats.TrackDuplicates. Every distinct GTIN is then kept in the
+	// checkpoints, which grow with the file; without, they stay a few
+	// hundred bytes.
+	Duplicates bool
+}
+
+// Run validates the file at path, resuming from its checkpoint, if any.
+// A checkpoint is also saved when ctx is cancelled. Once done, Run saves a
+// final checkpoint and later runs return its stats without reading the
+// file. OnError is only called for lines after the checkpoint.
+func (j *Job) Run(ctx context.Context, path string) (*gtin.Stats, error) {
+
+	key := j.Key
+	if key == "" {
+		key = path
+	}
+	every := j.Every
+	if every <= 0 {
+		every = defaultEvery
+	}
+
+	c, ok, err := j.Store.Load(key)
+	if err != nil {
+		return nil, err
+	}
+	if ok && c.Done {
+		return c.Stats, nil
+	}
+
+	data, release, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	switch {
+	case !ok:
+		c = Checkpoint{Size: int64(len(data)), Stats: new(gtin.Stats)}
+		c.Stats.TrackDuplicates(j.Duplicates)
+	case c.Size != int64(len(data)) || c.Offset > c.Size:
+		return nil, fmt.Errorf("%w: %s", ErrFileChanged, path)
+	}
+
+	save := func(c Checkpoint) error {
+		return j.Store.Save(key, c)
+	}
+	if err := j.run(ctx, data, &c, every, save); err != nil {
+		return c.Stats, err
+	}
+	c.Done = true
+	return c.Stats, save(c)
+}
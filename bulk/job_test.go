@@ -0,0 +1,115 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+// crashingStore fails after a number of saves, like a process that dies
+type crashingStore struct {
+	CheckpointStore
+	saves int
+}
+
+var errCrash = errors.New("crash")
+
+func (s *crashingStore) Save(key string, c Checkpoint) error {
+	if s.saves == 0 {
+		return errCrash
+	}
+	s.saves--
+	return s.CheckpointStore.Save(key, c)
+}
+
+func TestJob(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.csv")
+	if err := os.WriteFile(path, []byte(feed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v := Validator{Column: 2, Comma: ';', Header: true}
+	want := v.Validate([]byte(feed)).Report(-1)
+
+	// The first run dies after two checkpoints, at line 4
+	var lines []int
+	v.OnError = func(line int, field []byte, err error) { lines = append(lines, line) }
+	job := Job{Validator: v, Store: &crashingStore{DirStore(dir), 2}, Every: 2, Duplicates: true}
+	if _, err := job.Run(context.Background(), path); !errors.Is(err, errCrash) {
+		t.Fatalf("wanted crash, got %v", err)
+	}
+	c, ok, err := DirStore(dir).Load(path)
+	if !ok || err != nil || c.Line != 4 || c.Done {
+		t.Fatalf("got %+v, %v, %v", c, ok, err)
+	}
+
+	// The second run resumes at line 5
+	lines = nil
+	job.Store = DirStore(dir)
+	stats, err := job.Run(context.Background(), path)
+	if err != nil || !reflect.DeepEqual(stats.Report(-1), want) {
+		t.Errorf("wanted %+v, got %+v, %v", want, stats.Report(-1), err)
+	}
+	if !reflect.DeepEqual(lines, []int{6}) {
+		t.Errorf("wanted errors after the checkpoint, got %v", lines)
+	}
+
+	// Done jobs are not run again
+	lines = nil
+	if stats, err := job.Run(context.Background(), path); err != nil || !reflect.DeepEqual(stats.Report(-1), want) || lines != nil {
+		t.Errorf("got %+v, %v, %v", stats.Report(-1), err, lines)
+	}
+
+	// Without duplicates the checkpoints only hold counters
+	bounded := Job{Validator: Validator{Column: 2, Comma: ';', Header: true}, Store: DirStore(dir), Key: "bounded"}
+	stats, err = bounded.Run(context.Background(), path)
+	if r := stats.Report(-1); err != nil || r.Total != want.Total || len(r.Duplicates) != 0 {
+		t.Errorf("got %+v, %v", r, err)
+	}
+
+	// Cancelled jobs save a checkpoint
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	job.Key = "cancelled"
+	if _, err := job.Run(ctx, path); !errors.Is(err, context.Canceled) {
+		t.Errorf("wanted Canceled, got %v", err)
+	}
+	if c, ok, _ := DirStore(dir).Load("cancelled"); !ok || c.Line != 1 {
+		t.Errorf("got %+v, %v", c, ok)
+	}
+
+	// Changed files are not resumed
+	if err := os.WriteFile(path, []byte(feed+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := job.Run(context.Background(), path); !errors.Is(err, ErrFileChanged) {
+		t.Errorf("wanted ErrFileChanged, got %v", err)
+	}
+}
+
+func TestCheckpointBinary(t *testing.T) {
+
+	stats := new(gtin.Stats)
+	stats.Add(gtin.Parse("4006381333931"))
+	c := Checkpoint{Size: 1 << 40, Offset: 1 << 33, Line: 123456789, Done: true, Stats: stats}
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d Checkpoint
+	if err := d.UnmarshalBinary(data); err != nil || d.Size != c.Size || d.Offset != c.Offset || d.Line != c.Line || !d.Done ||
+		!reflect.DeepEqual(d.Stats.Report(-1), stats.Report(-1)) {
+		t.Errorf("got %+v, %v", d, err)
+	}
+	for _, bad := range [][]byte{nil, data[:3], data[:len(data)-1]} {
+		if err := d.UnmarshalBinary(bad); !errors.Is(err, ErrCheckpoint) {
+			t.Errorf("%v: wanted ErrCheckpoint, got %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package bulk
+
+import "os"
+
+// mapFile reads the file into memory, on platforms without mmap
+func mapFile(path string) (data []byte, release func(), err error) {
+	data, err = os.ReadFile(path)
+	return data, func() {}, err
+}
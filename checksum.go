@@ -0,0 +1,121 @@
+package gtin
+
+// ChecksumScheme computes the check digit for a sequence of payload digits,
+// i.e. all significant digits except the check digit itself.
+type ChecksumScheme interface {
+	CheckDigit(payload []uint8) uint8
+}
+
+// GS1Mod10 is the GS1 modulo-10 algorithm used by all GTINs.
+// https://www.gs1.org/services/how-calculate-check-digit-manually
+type GS1Mod10 struct{}
+
+// CheckDigit weights the payload 3, 1, 3, ... from the right and returns the
+// distance to the next multiple of ten.
+func (GS1Mod10) CheckDigit(payload []uint8) uint8 {
+	var checksum int
+	for n := range payload {
+		m := 1
+		if n%2 == 0 {
+			m = 3
+		}
+		checksum += int(payload[len(payload)-1-n]) * m
+	}
+	return uint8((10 - checksum%10) % 10)
+}
+
+// ISBNMod11 is the ISBN-10 modulo-11 algorithm. A check digit of 10 is
+// written as 'X'.
+type ISBNMod11 struct{}
+
+// CheckDigit weights the payload 2, 3, 4, ... from the right.
+func (ISBNMod11) CheckDigit(payload []uint8) uint8 {
+	var checksum int
+	for n := range payload {
+		checksum += int(payload[len(payload)-1-n]) * (n + 2)
+	}
+	return uint8((11 - checksum%11) % 11)
+}
+
+// Damm is the Damm algorithm, which detects all single-digit errors and all
+// adjacent transpositions.
+type Damm struct{}
+
+var dammTable = [10][10]uint8{
+	{0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+	{7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+	{4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+	{1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+	{6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+	{3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+	{5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+	{8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+	{9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+	{2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+// CheckDigit returns the interim digit after processing the payload.
+func (Damm) CheckDigit(payload []uint8) uint8 {
+	var interim uint8
+	for _, d := range payload {
+		interim = dammTable[interim][d%10]
+	}
+	return interim
+}
+
+// Verhoeff is the Verhoeff dihedral group algorithm.
+type Verhoeff struct{}
+
+var verhoeffD = [10][10]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+	{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+	{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+	{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+	{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+	{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+	{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+	{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+var verhoeffP = [8][10]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+	{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+	{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+	{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+	{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+	{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+	{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+}
+
+var verhoeffInv = [10]uint8{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
+
+// CheckDigit processes the payload from the right, leaving room for the
+// check digit at position 0.
+func (Verhoeff) CheckDigit(payload []uint8) uint8 {
+	var c uint8
+	for n := range payload {
+		d := payload[len(payload)-1-n] % 10
+		c = verhoeffD[c][verhoeffP[(n+1)%8][d]]
+	}
+	return verhoeffInv[c]
+}
+
+// ComputeCheckDigit returns the GS1 check digit for a payload of 7, 11, 12
+// or 13 digits, i.e. a GTIN-8, 12, 13 or 14 without its check digit
+func ComputeCheckDigit(payload string) (uint8, error) {
+
+	if _, err := getGTINType(payload + "0"); err != nil {
+		return 0, err
+	}
+	digits := make([]uint8, len(payload))
+	for n := 0; n < len(payload); n++ {
+		if payload[n] < '0' || payload[n] > '9' {
+			return 0, &PositionError{ErrDigit, payload[n], n}
+		}
+		digits[n] = payload[n] - '0'
+	}
+	return GS1Mod10{}.CheckDigit(digits), nil
+}
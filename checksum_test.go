@@ -0,0 +1,89 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func digitsOf(s string) []uint8 {
+	d := make([]uint8, len(s))
+	for n := range s {
+		d[n] = s[n] - '0'
+	}
+	return d
+}
+
+func TestChecksumSchemes(t *testing.T) {
+
+	tests := []struct {
+		scheme  ChecksumScheme
+		payload string
+		want    uint8
+	}{
+		{GS1Mod10{}, "629104150021", 3},
+		{GS1Mod10{}, "0061414100001", 2},
+		{GS1Mod10{}, "9638507", 4},
+		{ISBNMod11{}, "030640615", 2},
+		{ISBNMod11{}, "080442957", 10},
+		{Damm{}, "572", 4},
+		{Verhoeff{}, "236", 3},
+		{Verhoeff{}, "12345", 1},
+	}
+
+	for _, tt := range tests {
+		got := tt.scheme.CheckDigit(digitsOf(tt.payload))
+		if got != tt.want {
+			t.Errorf("%T(%s): wanted %v, got %v", tt.scheme, tt.payload, tt.want, got)
+		}
+	}
+}
+
+func TestParseWithChecksum(t *testing.T) {
+
+	if _, err := Parse("6291041500213"); err != nil {
+		t.Error(err)
+	}
+	if _, err := Parse("6291041500214"); err == nil {
+		t.Errorf("expected check digit error")
+	}
+	// 1234567 with a Damm check digit
+	if _, err := Parse("12345671", WithChecksum(Damm{})); err != nil {
+		t.Error(err)
+	}
+
+	// 1000002 with a modulo-11 check digit of 10
+	gt, err := Parse("1000002X", WithChecksum(ISBNMod11{}))
+	if err != nil || gt.Short() != "1000002X" || gt.Valid() {
+		t.Errorf("got %s, %v, valid %v", gt, err, gt.Valid())
+	}
+	if _, err := Parse("1000002x", WithChecksum(ISBNMod11{})); err != nil {
+		t.Error(err)
+	}
+	var pe *PositionError
+	if _, err := Parse("1000002X"); !errors.As(err, &pe) || pe.Pos != 7 {
+		t.Errorf("wanted X rejected by GS1Mod10, got %v", err)
+	}
+}
+
+func TestComputeCheckDigit(t *testing.T) {
+
+	tests := []struct {
+		payload string
+		want    uint8
+		err     bool
+	}{
+		{"761303463405", 4, false},
+		{"61414100001", 2, false},
+		{"9638507", 4, false},
+		{"5061414100099", 4, false},
+		{"12345", 0, true},
+		{"76130346340a", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ComputeCheckDigit(tt.payload)
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("%v: wanted %v, got %v %v", tt.payload, tt.want, got, err)
+		}
+	}
+}
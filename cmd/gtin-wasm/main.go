@@ -0,0 +1,111 @@
+//go:build js && wasm
+
+/*
+Gtin-wasm exposes GTIN parsing and validation to JavaScript, so browser
+forms run the same checks as Go backends.
+
+Build it with
+
+	GOOS=js GOARCH=wasm go build -o gtin.wasm ./cmd/gtin-wasm
+
+and load it with wasm_exec.js from the Go distribution. It defines a global
+gtin object:
+
+	gtin.parse("4006381333931")
+	// {gtin: "04006381333931", type: "GTIN-13", carrier: "EAN-13", valid: true}
+
+	gtin.validate("4006381333932", "sv")
+	// {valid: false, errors: [{code: "GTIN_E003_CHECK_DIGIT", message: "..."}], warnings: []}
+
+	gtin.computeCheckDigit("400638133393")
+	// {checkDigit: 1, gtin: "4006381333931"}
+
+Failures are returned as {error: {code, message}}, never thrown. Messages
+are in the optional language, English by default.
+*/
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/peterstark72/gtin"
+)
+
+func main() {
+	js.Global().Set("gtin", js.ValueOf(map[string]any{
+		"parse":             js.FuncOf(binding(parse)),
+		"validate":          js.FuncOf(binding(validate)),
+		"computeCheckDigit": js.FuncOf(binding(computeCheckDigit)),
+	}))
+	// Keep the functions available until the page is closed
+	select {}
+}
+
+// binding adapts f to a JavaScript function taking a string and an
+// optional language
+func binding(f func(s, lang string) map[string]any) func(js.Value, []js.Value) any {
+	return func(_ js.Value, args []js.Value) any {
+		var s, lang string
+		if len(args) > 0 {
+			s = args[0].String()
+		}
+		if len(args) > 1 && args[1].Type() == js.TypeString {
+			lang = args[1].String()
+		}
+		return js.ValueOf(f(s, lang))
+	}
+}
+
+// jsError returns err as a JavaScript object with code and message
+func jsError(err error, lang string) map[string]any {
+	return map[string]any{"code": gtin.ErrorCode(err), "message": gtin.ErrorMessage(err, lang)}
+}
+
+// jsErrors returns the errors as an array of JavaScript error objects,
+// unwrapping joined errors
+func jsErrors(errs []error, lang string) []any {
+	out := []any{}
+	for _, err := range errs {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			out = append(out, jsErrors(joined.Unwrap(), lang)...)
+			continue
+		}
+		out = append(out, jsError(err, lang))
+	}
+	return out
+}
+
+func parse(s, lang string) map[string]any {
+	gt, err := gtin.Parse(s)
+	if err != nil {
+		return map[string]any{"error": jsError(err, lang)}
+	}
+	return map[string]any{
+		"gtin":    gt.String(),
+		"type":    string(gt.Type()),
+		"carrier": gt.Carrier(),
+		"valid":   gt.Valid(),
+	}
+}
+
+func validate(s, lang string) map[string]any {
+	gt, err := gtin.Atog(s)
+	if err != nil {
+		return map[string]any{"valid": false, "errors": jsErrors([]error{err}, lang), "warnings": []any{}}
+	}
+	report := gt.Validate()
+	return map[string]any{
+		"valid":    report.OK(),
+		"errors":   jsErrors(report.Errors, lang),
+		"warnings": jsErrors(report.Warnings, lang),
+	}
+}
+
+func computeCheckDigit(s, lang string) map[string]any {
+	check, err := gtin.ComputeCheckDigit(s)
+	if err != nil {
+		return map[string]any{"error": jsError(err, lang)}
+	}
+	return map[string]any{"checkDigit": int(check), "gtin": fmt.Sprintf("%s%d", s, check)}
+}
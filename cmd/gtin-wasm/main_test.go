@@ -0,0 +1,49 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+
+	got := parse("4006381333931", "")
+	if got["gtin"] != "04006381333931" || got["type"] != "GTIN-13" || got["carrier"] != "EAN-13" || got["valid"] != true {
+		t.Errorf("got %v", got)
+	}
+
+	got = parse("4006381333932", "")
+	if e, ok := got["error"].(map[string]any); !ok || e["code"] != "GTIN_E003_CHECK_DIGIT" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	got := validate("12a", "")
+	errs := got["errors"].([]any)
+	if got["valid"] != false || len(errs) != 2 || errs[1].(map[string]any)["code"] != "GTIN_E002_DIGIT" {
+		t.Errorf("got %v", got)
+	}
+
+	got = validate("2001234567893", "sv")
+	warnings := got["warnings"].([]any)
+	if got["valid"] != true || len(warnings) != 1 {
+		t.Fatalf("got %v", got)
+	}
+	if w := warnings[0].(map[string]any); w["code"] != "GTIN_E010_RESTRICTED_PREFIX" || w["message"] == "" {
+		t.Errorf("got %v", w)
+	}
+}
+
+func TestComputeCheckDigit(t *testing.T) {
+
+	got := computeCheckDigit("400638133393", "")
+	if got["checkDigit"] != 1 || got["gtin"] != "4006381333931" {
+		t.Errorf("got %v", got)
+	}
+	if got := computeCheckDigit("12345", ""); got["error"] == nil {
+		t.Errorf("wanted error, got %v", got)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/barcode"
+)
+
+// symbologyFlags maps --symbology values to symbologies
+var symbologyFlags = map[string]string{
+	"auto":  barcode.Auto,
+	"ean13": gtin.EAN13,
+	"ean8":  gtin.EAN8,
+	"upca":  gtin.UPCA,
+	"itf14": gtin.ITF14,
+}
+
+// hriFlags maps --hri values to positions of the human readable
+// interpretation
+var hriFlags = map[string]barcode.HRI{
+	"below": barcode.HRIBelow,
+	"above": barcode.HRIAbove,
+	"none":  barcode.HRINone,
+}
+
+// formats are the --format values
+var formats = map[string]bool{"svg": true, "png": true, "epl": true, "ipl": true}
+
+// runBarcode renders a code as an SVG or PNG barcode, or as commands for a
+// label printer
+func runBarcode(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("barcode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "svg", "output format: svg or png, or epl or ipl for label printers")
+	out := fs.String("out", "", "output file, default is stdout")
+	symbology := fs.String("symbology", "auto", "auto, ean13, ean8, upca or itf14; auto selects from the code's carrier")
+	scale := fs.Int("scale", 4, "pixels per module for png, printer dots for epl and ipl")
+	hriFlag := fs.String("hri", "below", "human readable digits: below, above or none")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin barcode <code> [flags]\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	// Flags may also follow the code
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	code := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return exitUsage
+	}
+	sym, ok := symbologyFlags[strings.ToLower(*symbology)]
+	hri, hriOK := hriFlags[strings.ToLower(*hriFlag)]
+	if fs.NArg() != 0 || !ok || !hriOK || !formats[*format] {
+		fs.Usage()
+		return exitUsage
+	}
+
+	gt, err := gtin.Parse(code)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", code, err)
+		return exitInvalid
+	}
+	b, err := barcode.Encode(gt, sym)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", code, err)
+		return exitInvalid
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(stderr, "gtin barcode: %v\n", err)
+			return exitIO
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "png":
+		err = barcode.WritePNG(w, b, *scale, barcode.WithHRI(hri))
+	case "epl":
+		err = barcode.WriteEPL(w, b, barcode.Printer{Module: *scale}, barcode.WithHRI(hri))
+	case "ipl":
+		err = barcode.WriteIPL(w, b, barcode.Printer{Module: *scale}, barcode.WithHRI(hri))
+	default:
+		err = barcode.WriteSVG(w, b, barcode.WithHRI(hri))
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gtin barcode: %v\n", err)
+		return exitIO
+	}
+	return exitOK
+}
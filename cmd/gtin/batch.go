@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// verdict is the outcome of checking one code
+type verdict struct {
+	result string   // valid, warning or invalid
+	codes  []string // Error codes of all problems found
+}
+
+// check validates a code and classifies the problems found
+func check(code string, policy gtin.Policy) verdict {
+
+	gt, err := gtin.Atog(strings.TrimSpace(code))
+	if err != nil {
+		return verdict{"invalid", errorCodes(err)}
+	}
+	report := policy.Validate(gt)
+	switch {
+	case !report.OK():
+		return verdict{"invalid", errorCodes(append(report.Errors, report.Warnings...)...)}
+	case len(report.Warnings) > 0:
+		return verdict{"warning", errorCodes(report.Warnings...)}
+	}
+	return verdict{result: "valid"}
+}
+
+// errorCodes returns the codes of the errors, unwrapping joined errors
+func errorCodes(errs ...error) []string {
+	var codes []string
+	for _, err := range errs {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			codes = append(codes, errorCodes(joined.Unwrap()...)...)
+			continue
+		}
+		codes = append(codes, gtin.ErrorCode(err))
+	}
+	return codes
+}
+
+// runBatch validates a column of a CSV file, writes the file annotated with
+// a verdict per row and prints a summary. It returns 1 if any row is invalid.
+func runBatch(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	column := fs.String("column", "gtin", "name of the column holding the codes")
+	out := fs.String("out", "", "annotated output file, default is <file>.checked.csv")
+	comma := fs.String("comma", ",", "field delimiter")
+	profile := fs.String("profile", "", "validate for a sales channel: "+strings.Join(gtin.Profiles(), ", "))
+	showProgress := fs.Bool("progress", false, "report rows checked and time left on stderr")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin batch [flags] <file.csv>\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	policy, ok := policyOf(*profile)
+	if fs.NArg() != 1 || len([]rune(*comma)) != 1 || !ok {
+		fs.Usage()
+		return exitUsage
+	}
+
+	path := fs.Arg(0)
+	if *out == "" {
+		*out = strings.TrimSuffix(path, filepath.Ext(path)) + ".checked.csv"
+	}
+
+	var progress gtin.ProgressFunc
+	if *showProgress {
+		progress = func(p gtin.Progress) {
+			left := "unknown"
+			if eta, ok := p.ETA(); ok {
+				left = eta.Round(time.Second).String()
+			}
+			fmt.Fprintf(stderr, "\rgtin batch: %d rows, %d invalid, %s left ", p.Done, p.Errors, left)
+			if p.Done == p.Total {
+				fmt.Fprintln(stderr)
+			}
+		}
+	}
+
+	summary, err := batch(path, *out, *column, []rune(*comma)[0], policy, progress)
+	if err != nil {
+		fmt.Fprintf(stderr, "gtin batch: %v\n", err)
+		if errors.Is(err, errNoColumn) {
+			return exitUsage
+		}
+		return exitIO
+	}
+
+	if jsonOutput {
+		writeJSON(stdout, struct {
+			Rows    int            `json:"rows"`
+			Valid   int            `json:"valid"`
+			Warning int            `json:"warning"`
+			Invalid int            `json:"invalid"`
+			Errors  map[string]int `json:"errors"`
+		}{summary.rows, summary.results["valid"], summary.results["warning"], summary.results["invalid"], summary.codes})
+	} else {
+		printSummary(stdout, summary)
+	}
+
+	if summary.results["invalid"] > 0 {
+		return exitInvalid
+	}
+	return exitOK
+}
+
+// printSummary prints the counts of results and error codes
+func printSummary(stdout io.Writer, summary batchSummary) {
+	fmt.Fprintf(stdout, "rows\t%d\nvalid\t%d\nwarning\t%d\ninvalid\t%d\n",
+		summary.rows, summary.results["valid"], summary.results["warning"], summary.results["invalid"])
+	var codes []string
+	for code := range summary.codes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Fprintf(stdout, "%s\t%d\n", code, summary.codes[code])
+	}
+}
+
+// errNoColumn is returned when the file has no column of the given name
+var errNoColumn = errors.New("no such column")
+
+type batchSummary struct {
+	rows    int
+	results map[string]int
+	codes   map[string]int
+}
+
+// progressRows is the number of rows between progress reports
+const progressRows = 1000
+
+// batch checks the column of every row in path with policy and writes the
+// rows, with verdict and error codes appended, to out. If progress is not
+// nil, it's called every progressRows rows and at the end. The total number
+// of rows is estimated from the size of the file and the bytes read so far.
+func batch(path, out, column string, comma rune, policy gtin.Policy, progress gtin.ProgressFunc) (batchSummary, error) {
+
+	summary := batchSummary{results: make(map[string]int), codes: make(map[string]int)}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return summary, err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return summary, err
+	}
+	start := time.Now()
+	report := func(total int) {
+		if progress != nil {
+			progress(gtin.Progress{
+				Done:    summary.rows,
+				Errors:  summary.results["invalid"],
+				Total:   total,
+				Elapsed: time.Since(start),
+			})
+		}
+	}
+
+	r := csv.NewReader(in)
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return summary, fmt.Errorf("%s: %w", path, err)
+	}
+	col := -1
+	for n, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			col = n
+		}
+	}
+	if col < 0 {
+		return summary, fmt.Errorf("%s: %w %q", path, errNoColumn, column)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return summary, err
+	}
+	w := csv.NewWriter(f)
+	w.Comma = comma
+	w.Write(append(header, column+"_verdict", column+"_errors"))
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return summary, fmt.Errorf("%s: %w", path, err)
+		}
+
+		var v verdict
+		if col < len(row) {
+			v = check(row[col], policy)
+		} else {
+			v = check("", policy)
+		}
+		summary.rows++
+		summary.results[v.result]++
+		for _, code := range v.codes {
+			summary.codes[code]++
+		}
+		w.Write(append(row, v.result, strings.Join(v.codes, " ")))
+
+		if summary.rows%progressRows == 0 {
+			total := 0
+			if offset := r.InputOffset(); offset > 0 {
+				total = int(int64(summary.rows) * info.Size() / offset)
+			}
+			report(max(total, summary.rows+1))
+		}
+	}
+	report(summary.rows)
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return summary, err
+	}
+	return summary, f.Close()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/peterstark72/gtin"
+)
+
+// runCheckDigit prints the check digit and completed code of each payload
+func runCheckDigit(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("checkdigit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin checkdigit <code without check digit>...\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	exit := exitOK
+	for _, payload := range fs.Args() {
+		check, err := gtin.ComputeCheckDigit(payload)
+		if err != nil {
+			exit = exitInvalid
+		}
+		switch {
+		case jsonOutput:
+			r := struct {
+				Payload    string      `json:"payload"`
+				CheckDigit *uint8      `json:"checkDigit,omitempty"`
+				GTIN       string      `json:"gtin,omitempty"`
+				Errors     []jsonError `json:"errors,omitempty"`
+			}{Payload: payload, Errors: jsonErrors(err)}
+			if err == nil {
+				r.CheckDigit, r.GTIN = &check, fmt.Sprintf("%s%d", payload, check)
+			}
+			writeJSON(stdout, r)
+		case err != nil:
+			fmt.Fprintf(stderr, "%s: %v\n", payload, err)
+		default:
+			fmt.Fprintf(stdout, "%d\t%s%d\n", check, payload, check)
+		}
+	}
+	return exit
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// runConvert converts codes between GTIN types, ISBN-10 and UPC-E
+func runConvert(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	to := fs.String("to", "gtin14", "target form: gtin8, gtin12, gtin13, gtin14, isbn10 or upce")
+	from := fs.String("from", "auto", "input form: auto, gtin, isbn10 or upce")
+	indicator := fs.Int("indicator", 0, "packaging indicator digit 0-9 when converting to gtin14")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin convert [flags] <code>...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 || *indicator < 0 || *indicator > 9 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *indicator != 0 && *to != "gtin14" {
+		fmt.Fprintf(stderr, "gtin convert: --indicator needs --to gtin14\n")
+		return exitUsage
+	}
+
+	exit := exitOK
+	for _, code := range fs.Args() {
+		out, err := convert(code, *from, *to, uint8(*indicator))
+		if err != nil {
+			exit = exitInvalid
+		}
+		switch {
+		case jsonOutput:
+			writeJSON(stdout, struct {
+				Code   string      `json:"code"`
+				Result string      `json:"result,omitempty"`
+				Errors []jsonError `json:"errors,omitempty"`
+			}{code, out, jsonErrors(err)})
+		case err != nil:
+			fmt.Fprintf(stderr, "%s: %v\n", code, err)
+		default:
+			fmt.Fprintln(stdout, out)
+		}
+	}
+	return exit
+}
+
+// convert parses code in the given input form and formats it in the target form
+func convert(code, from, to string, indicator uint8) (string, error) {
+
+	if from == "auto" {
+		from = "gtin"
+		if len(strings.NewReplacer("-", "", " ", "").Replace(code)) == 10 {
+			from = "isbn10"
+		}
+	}
+
+	var (
+		gt  gtin.GTIN
+		err error
+	)
+	switch from {
+	case "gtin":
+		gt, err = gtin.Parse(code)
+	case "isbn10":
+		gt, err = gtin.ParseISBN10(code)
+	case "upce":
+		gt, err = gtin.ParseUPCE(code)
+	default:
+		return "", fmt.Errorf("unknown input form %q", from)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch to {
+	case "isbn10":
+		return gt.ISBN10()
+	case "upce":
+		return gt.UPCE()
+	case "gtin14":
+		if indicator != 0 {
+			gt, err := gtin.NewGTIN14(gt, indicator)
+			if err != nil {
+				return "", err
+			}
+			return gt.String(), nil
+		}
+	}
+	typ, ok := typeFlags[to]
+	if !ok {
+		return "", fmt.Errorf("unknown target form %q", to)
+	}
+	return gt.Pad(typ)
+}
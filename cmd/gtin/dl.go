@@ -0,0 +1,170 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/digitallink"
+)
+
+// runDL builds and parses GS1 Digital Link URIs
+func runDL(args []string, stdout, stderr io.Writer) int {
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "encode":
+			return runDLEncode(args[1:], stdout, stderr)
+		case "decode":
+			return runDLDecode(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "Usage: gtin dl encode|decode [arguments]\n")
+	return exitUsage
+}
+
+// runDLEncode prints the Digital Link URI of a code and its qualifiers
+func runDLEncode(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("dl encode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	base := fs.String("base", digitallink.DefaultResolver, "resolver base URI")
+	cpv := fs.String("cpv", "", "consumer product variant, AI 22")
+	lot := fs.String("lot", "", "batch or lot number, AI 10")
+	serial := fs.String("serial", "", "serial number, AI 21")
+	compressed := fs.Bool("compressed", false, "write the compressed form")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin dl encode <code> [flags] [AI=value]...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	// Flags may also follow the code
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	code := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return exitUsage
+	}
+
+	gt, err := gtin.Parse(code)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", code, err)
+		return exitInvalid
+	}
+	link := digitallink.Link{GTIN: gt, CPV: *cpv, Lot: *lot, Serial: *serial}
+	for _, attr := range fs.Args() {
+		ai, value, ok := cutAttribute(attr)
+		if !ok {
+			fs.Usage()
+			return exitUsage
+		}
+		if link.Attributes == nil {
+			link.Attributes = make(map[string]string)
+		}
+		link.Attributes[ai] = value
+	}
+
+	uri := link.URI(*base)
+	if *compressed {
+		if uri, err = link.CompressedURI(*base); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", code, err)
+			return exitInvalid
+		}
+	}
+	if jsonOutput {
+		writeJSON(stdout, struct {
+			URI string `json:"uri"`
+		}{uri})
+	} else {
+		fmt.Fprintln(stdout, uri)
+	}
+	return exitOK
+}
+
+// cutAttribute splits a data attribute AI=value
+func cutAttribute(attr string) (ai, value string, ok bool) {
+	for n := 0; n < len(attr); n++ {
+		if attr[n] == '=' {
+			return attr[:n], attr[n+1:], n >= 2
+		}
+		if attr[n] < '0' || attr[n] > '9' {
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
+
+// runDLDecode prints the AIs and values of Digital Link URIs, one per line
+func runDLDecode(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("dl decode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin dl decode <uri>...\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	exit := exitOK
+	for _, uri := range fs.Args() {
+		link, err := digitallink.Parse(uri)
+		if err != nil {
+			exit = exitInvalid
+			if jsonOutput {
+				writeJSON(stdout, struct {
+					URI    string      `json:"uri"`
+					Errors []jsonError `json:"errors"`
+				}{uri, jsonErrors(err)})
+			} else {
+				fmt.Fprintf(stderr, "%s: %v\n", uri, err)
+			}
+			continue
+		}
+
+		ais := map[string]string{digitallink.AIGTIN: link.GTIN.String()}
+		for _, q := range [][2]string{
+			{digitallink.AICPV, link.CPV},
+			{digitallink.AILot, link.Lot},
+			{digitallink.AISerial, link.Serial},
+		} {
+			if q[1] != "" {
+				ais[q[0]] = q[1]
+			}
+		}
+		for ai, value := range link.Attributes {
+			ais[ai] = value
+		}
+
+		if jsonOutput {
+			writeJSON(stdout, struct {
+				URI string            `json:"uri"`
+				AIs map[string]string `json:"ais"`
+			}{uri, ais})
+			continue
+		}
+		// Qualifiers sort by rank, attributes by AI
+		keys := []string{digitallink.AIGTIN, digitallink.AICPV, digitallink.AILot, digitallink.AISerial}
+		var attrs []string
+		for ai := range link.Attributes {
+			attrs = append(attrs, ai)
+		}
+		sort.Strings(attrs)
+		for _, ai := range append(keys, attrs...) {
+			if value, ok := ais[ai]; ok {
+				fmt.Fprintf(stdout, "%s\t%s\n", ai, value)
+			}
+		}
+	}
+	return exit
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/peterstark72/gtin/bulk"
+)
+
+// runDupes lists the GTINs found in more than one of the files. It returns
+// 1 if there are any.
+func runDupes(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("dupes", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	column := fs.Int("column", 0, "index of the column holding the codes, from 0")
+	comma := fs.String("comma", ",", "field delimiter")
+	header := fs.Bool("header", false, "skip the first line of each file")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin dupes [flags] <file>...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() < 2 || len(*comma) != 1 || *column < 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	v := bulk.Validator{Column: *column, Comma: (*comma)[0], Header: *header}
+	collisions, err := v.Collisions(fs.Args()...)
+	if err != nil {
+		fmt.Fprintf(stderr, "gtin dupes: %v\n", err)
+		return exitIO
+	}
+
+	for _, c := range collisions {
+		if jsonOutput {
+			writeJSON(stdout, struct {
+				GTIN      string          `json:"gtin"`
+				Locations []bulk.Location `json:"locations"`
+			}{c.GTIN.String(), c.Locations})
+			continue
+		}
+		locations := make([]string, len(c.Locations))
+		for n, l := range c.Locations {
+			locations[n] = fmt.Sprintf("%s:%d", l.Path, l.Line)
+		}
+		fmt.Fprintf(stdout, "%s\t%s\n", c.GTIN, strings.Join(locations, " "))
+	}
+	if len(collisions) > 0 {
+		return exitInvalid
+	}
+	return exitOK
+}
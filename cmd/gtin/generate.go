@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// typeFlags maps --type values to GTIN types
+var typeFlags = map[string]gtin.Type{
+	"gtin8":  gtin.GTIN8,
+	"gtin12": gtin.GTIN12,
+	"gtin13": gtin.GTIN13,
+	"gtin14": gtin.GTIN14,
+}
+
+// runGenerate prints valid codes with the given prefix, for test data
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typeFlag := fs.String("type", "gtin13", "GTIN type: gtin8, gtin12, gtin13 or gtin14")
+	prefix := fs.String("prefix", "", "leading digits of every code, e.g. a company prefix")
+	count := fs.Int("count", 1, "number of codes")
+	sequential := fs.Bool("sequential", false, "number item references from --start instead of picking them at random")
+	start := fs.Uint64("start", 0, "first item reference in sequential mode")
+	seed := fs.Int64("seed", 0, "random seed, default is the current time")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin generate [flags]\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	typ, ok := typeFlags[strings.ToLower(*typeFlag)]
+	if !ok {
+		fmt.Fprintf(stderr, "gtin generate: unknown type %q\n", *typeFlag)
+		return exitUsage
+	}
+	if strings.Trim(*prefix, "0123456789") != "" {
+		fmt.Fprintf(stderr, "gtin generate: prefix must be digits\n")
+		return exitUsage
+	}
+
+	// The item reference fills the digits between prefix and check digit
+	width := typ.Len() - 1 - len(*prefix)
+	if width < 1 {
+		fmt.Fprintf(stderr, "gtin generate: prefix too long for %s\n", typ)
+		return exitUsage
+	}
+	capacity := uint64(1)
+	for i := 0; i < width; i++ {
+		capacity *= 10
+	}
+	if *count < 0 || uint64(*count) > capacity || (*sequential && *start+uint64(*count) > capacity) {
+		fmt.Fprintf(stderr, "gtin generate: prefix %s has room for %d codes\n", *prefix, capacity)
+		return exitUsage
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(*seed))
+	used := make(map[uint64]bool)
+
+	for i := 0; i < *count; i++ {
+		ref := *start + uint64(i)
+		if !*sequential {
+			for {
+				ref = uint64(rnd.Int63n(int64(capacity)))
+				if !used[ref] {
+					used[ref] = true
+					break
+				}
+			}
+		}
+		payload := fmt.Sprintf("%s%0*d", *prefix, width, ref)
+		check, err := gtin.ComputeCheckDigit(payload)
+		if err != nil {
+			fmt.Fprintf(stderr, "gtin generate: %v\n", err)
+			return exitInvalid
+		}
+		if jsonOutput {
+			writeJSON(stdout, struct {
+				GTIN string `json:"gtin"`
+			}{fmt.Sprintf("%s%d", payload, check)})
+		} else {
+			fmt.Fprintf(stdout, "%s%d\n", payload, check)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/peterstark72/gtin"
+)
+
+// jsonOutput is set by the global --json flag
+var jsonOutput bool
+
+// jsonError is an error with its stable code, as written in JSON output
+type jsonError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// jsonErrors returns the errors as jsonErrors, unwrapping joined errors
+// and skipping nil
+func jsonErrors(errs ...error) []jsonError {
+	var out []jsonError
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			out = append(out, jsonErrors(joined.Unwrap()...)...)
+			continue
+		}
+		out = append(out, jsonError{gtin.ErrorCode(err), err.Error()})
+	}
+	return out
+}
+
+// writeJSON writes v as one line of JSON
+func writeJSON(w io.Writer, v any) {
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,110 @@
+/*
+Gtin is a command line tool for GTINs.
+
+Usage:
+
+	gtin <command> [arguments]
+
+The commands are:
+
+	validate    validate GTINs
+	generate    generate valid GTINs for test data
+	convert     convert between GTIN types, ISBN-10 and UPC-E
+	checkdigit  compute check digits
+	scale       generate variable measure EAN-13s for in-store scales
+	batch       validate a column of a CSV file
+	dupes       find GTINs in more than one file
+	barcode     render a barcode as SVG, PNG, EPL or IPL
+	dl          encode and decode GS1 Digital Link URIs
+	tui         validate interactively as you type or scan
+
+The global flag --json, given before the command, writes results as JSON,
+one object per line, instead of text. Errors are reported with their stable
+codes, e.g. GTIN_E003_CHECK_DIGIT.
+
+The validate and batch commands take --profile to validate for a sales
+channel instead of with the default policy, e.g. --profile amazon.
+
+The exit codes are:
+
+	0  success, all input is valid
+	1  some input is invalid, e.g. a wrong check digit or unknown carrier
+	2  the command line is wrong
+	3  reading or writing a file failed
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes, as documented above
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+	exitIO      = 3
+)
+
+// stdin is read by commands given the argument -
+var stdin io.Reader = os.Stdin
+
+// command is a gtin subcommand
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"validate", "validate GTINs", runValidate},
+	{"generate", "generate valid GTINs for test data", runGenerate},
+	{"convert", "convert between GTIN types, ISBN-10 and UPC-E", runConvert},
+	{"checkdigit", "compute check digits", runCheckDigit},
+	{"scale", "generate variable measure EAN-13s for in-store scales", runScale},
+	{"batch", "validate a column of a CSV file", runBatch},
+	{"dupes", "find GTINs in more than one file", runDupes},
+	{"barcode", "render a barcode as SVG, PNG, EPL or IPL", runBarcode},
+	{"dl", "encode and decode GS1 Digital Link URIs", runDL},
+	{"tui", "validate interactively as you type or scan", runTUI},
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage:\n\n\tgtin [--json] <command> [arguments]\n\nThe commands are:\n\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "\t%-12s%s\n", c.name, c.usage)
+	}
+}
+
+// run runs the command line and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("gtin", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&jsonOutput, "json", false, "write results as JSON")
+	fs.Usage = func() { usage(stderr) }
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	args = fs.Args()
+
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "gtin: unknown command %q\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
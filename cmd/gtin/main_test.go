@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		args []string
+		exit int
+		want []string
+	}{
+		{[]string{"validate", "4006381333931"}, 0, []string{"GTIN-13", "EAN-13", "GS1 Germany", "valid"}},
+		{[]string{"validate", "4006381333932"}, 1, []string{"wrong, want 1", "invalid check digit"}},
+		{[]string{"validate", "614141000012", "12a"}, 1, []string{"UPC-A", "invalid length 3; invalid digit 'a' at position 2"}},
+		{[]string{"validate", "2001234567893"}, 0, []string{"valid, GS1 restricted prefix"}},
+		{[]string{"validate"}, 2, nil},
+		{[]string{"validate", "--profile", "amazon", "96385074"}, 1, []string{"not allowed by policy: type GTIN-8"}},
+		{[]string{"validate", "--profile", "google", "96385074"}, 0, []string{"valid"}},
+		{[]string{"validate", "--profile", "ebay", "96385074"}, 2, nil},
+		{[]string{"nosuchcommand"}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if exit := run(tt.args, &stdout, &stderr); exit != tt.exit {
+			t.Errorf("%v: wanted exit %d, got %d", tt.args, tt.exit, exit)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("%v: wanted %q in\n%s", tt.args, want, stdout.String())
+			}
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"generate", "--type", "gtin13", "--prefix", "7350", "--count", "100", "--seed", "1"}, &stdout, &stderr); exit != 0 {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	codes := strings.Fields(stdout.String())
+	if len(codes) != 100 {
+		t.Errorf("wanted 100 codes, got %d", len(codes))
+	}
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if _, err := gtin.Parse(code); err != nil || !strings.HasPrefix(code, "7350") || seen[code] {
+			t.Errorf("bad code %v: %v", code, err)
+		}
+		seen[code] = true
+	}
+
+	stdout.Reset()
+	run([]string{"generate", "--type", "gtin8", "--prefix", "963850", "--count", "3", "--sequential", "--start", "6"}, &stdout, &stderr)
+	if got := stdout.String(); got != "96385067\n96385074\n96385081\n" {
+		t.Errorf("wrong sequence %q", got)
+	}
+
+	if exit := run([]string{"generate", "--type", "gtin8", "--prefix", "963850", "--count", "11"}, &stdout, &stderr); exit != 2 {
+		t.Errorf("wanted exit 2, got %d", exit)
+	}
+}
+
+func TestConvert(t *testing.T) {
+
+	tests := []struct {
+		args []string
+		exit int
+		want string
+	}{
+		{[]string{"convert", "614141000012"}, 0, "00614141000012\n"},
+		{[]string{"convert", "--to", "gtin14", "--indicator", "3", "4006381333931"}, 0, "34006381333932\n"},
+		{[]string{"convert", "--to", "gtin13", "0-306-40615-2"}, 0, "9780306406157\n"},
+		{[]string{"convert", "--to", "isbn10", "9780804429573"}, 0, "080442957X\n"},
+		{[]string{"convert", "--from", "upce", "--to", "gtin12", "04252614"}, 0, "042100005264\n"},
+		{[]string{"convert", "--to", "upce", "042100005264"}, 0, "04252614\n"},
+		{[]string{"convert", "--to", "gtin8", "614141000012"}, 1, ""},
+		{[]string{"convert", "--to", "gtin13", "--indicator", "3", "614141000012"}, 2, ""},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if exit := run(tt.args, &stdout, &stderr); exit != tt.exit {
+			t.Errorf("%v: wanted exit %d, got %d: %s", tt.args, tt.exit, exit, stderr.String())
+		}
+		if stdout.String() != tt.want {
+			t.Errorf("%v: wanted %q, got %q", tt.args, tt.want, stdout.String())
+		}
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"checkdigit", "761303463405", "9638507"}, &stdout, &stderr); exit != 0 {
+		t.Errorf("wanted exit 0, got %d", exit)
+	}
+	if want := "4\t7613034634054\n4\t96385074\n"; stdout.String() != want {
+		t.Errorf("wanted %q, got %q", want, stdout.String())
+	}
+
+	if exit := run([]string{"checkdigit", "12345"}, &stdout, &stderr); exit != 1 {
+		t.Errorf("wanted exit 1, got %d", exit)
+	}
+}
+
+func TestScale(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"scale", "--prefix", "2", "--item", "6", "--value", "4", "--price-check", "4711:2875", "12:99"}, &stdout, &stderr); exit != 0 {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	if want := "2004711928750\n2000012400995\n"; stdout.String() != want {
+		t.Errorf("wanted %q, got %q", want, stdout.String())
+	}
+
+	// The defaults are a 6-digit item and 5-digit value after prefix 2
+	stdout.Reset()
+	if exit := run([]string{"scale", "42:1250"}, &stdout, &stderr); exit != 0 || stdout.String() != "2000042012502\n" {
+		t.Errorf("got exit %d, %q: %s", exit, stdout.String(), stderr.String())
+	}
+
+	if exit := run([]string{"scale", "--prefix", "23", "123456:1"}, &stdout, &stderr); exit != 1 {
+		t.Errorf("wanted exit 1, got %d", exit)
+	}
+	if exit := run([]string{"scale", "4711"}, &stdout, &stderr); exit != 2 {
+		t.Errorf("wanted exit 2, got %d", exit)
+	}
+}
+
+func TestBatch(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.csv")
+	os.WriteFile(path, []byte("sku,ean\nA,4006381333931\nB,4006381333932\nC,2001234567893\nD,12a\n"), 0o644)
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"batch", "--column", "ean", path}, &stdout, &stderr); exit != 1 {
+		t.Errorf("wanted exit 1, got %d: %s", exit, stderr.String())
+	}
+	for _, want := range []string{"rows\t4\n", "valid\t1\n", "warning\t1\n", "invalid\t2\n", "GTIN_E003_CHECK_DIGIT\t1\n", "GTIN_E002_DIGIT\t1\n"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("wanted %q in\n%s", want, stdout.String())
+		}
+	}
+
+	annotated, err := os.ReadFile(filepath.Join(dir, "feed.checked.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "sku,ean,ean_verdict,ean_errors\n" +
+		"A,4006381333931,valid,\n" +
+		"B,4006381333932,invalid,GTIN_E003_CHECK_DIGIT\n" +
+		"C,2001234567893,warning,GTIN_E010_RESTRICTED_PREFIX\n" +
+		"D,12a,invalid,GTIN_E001_LENGTH GTIN_E002_DIGIT\n"
+	if string(annotated) != want {
+		t.Errorf("wanted\n%s\ngot\n%s", want, annotated)
+	}
+
+	stderr.Reset()
+	run([]string{"batch", "--column", "ean", "--progress", path}, &stdout, &stderr)
+	if want := "\rgtin batch: 4 rows, 2 invalid, 0s left \n"; stderr.String() != want {
+		t.Errorf("wanted progress %q, got %q", want, stderr.String())
+	}
+}
+
+func TestDupes(t *testing.T) {
+
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")
+	os.WriteFile(a, []byte("sku;ean\nA;4006381333931\nB;96385074\n"), 0o644)
+	os.WriteFile(b, []byte("sku;ean\nC;04006381333931\n"), 0o644)
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"dupes", "--column", "1", "--comma", ";", "--header", a, b}, &stdout, &stderr); exit != 1 {
+		t.Errorf("wanted exit 1, got %d: %s", exit, stderr.String())
+	}
+	if want := "04006381333931\t" + a + ":2 " + b + ":2\n"; stdout.String() != want {
+		t.Errorf("wanted %q, got %q", want, stdout.String())
+	}
+
+	stdout.Reset()
+	if exit := run([]string{"--json", "dupes", "--column", "1", "--comma", ";", a, b}, &stdout, &stderr); exit != 1 ||
+		!strings.Contains(stdout.String(), `"locations":[{"path":`) {
+		t.Errorf("got %d, %s", exit, stdout.String())
+	}
+	if exit := run([]string{"dupes", a}, &stdout, &stderr); exit != 2 {
+		t.Errorf("wanted exit 2 for one file, got %d", exit)
+	}
+	if exit := run([]string{"dupes", a, filepath.Join(dir, "missing.csv")}, &stdout, &stderr); exit != 3 {
+		t.Errorf("wanted exit 3 for a missing file, got %d", exit)
+	}
+}
+
+func TestBarcode(t *testing.T) {
+
+	out := filepath.Join(t.TempDir(), "label.svg")
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"barcode", "04006381333931", "--format", "svg", "--out", out}, &stdout, &stderr); exit != 0 {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	svg, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(svg), "<svg") || !strings.Contains(string(svg), ">4 006381 333931<") {
+		t.Errorf("wanted EAN-13 svg, got\n%s", svg)
+	}
+
+	stdout.Reset()
+	if exit := run([]string{"barcode", "--format", "png", "50614141000994"}, &stdout, &stderr); exit != 0 {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "\x89PNG") {
+		t.Error("wanted png on stdout")
+	}
+
+	stdout.Reset()
+	if exit := run([]string{"barcode", "--hri", "none", "4006381333931"}, &stdout, &stderr); exit != 0 || strings.Contains(stdout.String(), "stroke") {
+		t.Errorf("wanted svg without digits, got %d:\n%s", exit, stdout.String())
+	}
+	stdout.Reset()
+	if exit := run([]string{"barcode", "--format", "epl", "--scale", "3", "4006381333931"}, &stdout, &stderr); exit != 0 || !strings.Contains(stdout.String(), `B0,0,0,E30,3,9,210,B,"400638133393"`) {
+		t.Errorf("wanted EPL, got %d:\n%s", exit, stdout.String())
+	}
+
+	for _, args := range [][]string{
+		{"barcode", "4006381333932"},
+		{"barcode", "4006381333931", "--symbology", "upca"},
+	} {
+		if exit := run(args, &stdout, &stderr); exit != 1 {
+			t.Errorf("%v: wanted exit 1, got %d", args, exit)
+		}
+	}
+	if exit := run([]string{"barcode", "4006381333931", "--format", "gif"}, &stdout, &stderr); exit != 2 {
+		t.Errorf("wanted exit 2 for unknown format, got %d", exit)
+	}
+	if exit := run([]string{"barcode", "4006381333931", "--hri", "left"}, &stdout, &stderr); exit != 2 {
+		t.Errorf("wanted exit 2 for unknown position, got %d", exit)
+	}
+}
+
+func TestDL(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"dl", "encode", "9506000134352", "--lot", "ABC", "17=251231"}, &stdout, &stderr); exit != 0 {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	uri := "https://id.gs1.org/01/09506000134352/10/ABC?17=251231"
+	if got := strings.TrimSpace(stdout.String()); got != uri {
+		t.Errorf("wanted %s, got %s", uri, got)
+	}
+
+	stdout.Reset()
+	if exit := run([]string{"dl", "decode", uri}, &stdout, &stderr); exit != 0 {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	if want := "01\t09506000134352\n10\tABC\n17\t251231\n"; stdout.String() != want {
+		t.Errorf("wanted %q, got %q", want, stdout.String())
+	}
+
+	stdout.Reset()
+	if exit := run([]string{"dl", "encode", "--compressed", "9780345418913"}, &stdout, &stderr); exit != 0 || stdout.String() != "https://id.gs1.org/ARHKVAdpQg\n" {
+		t.Errorf("got exit %d, %q: %s", exit, stdout.String(), stderr.String())
+	}
+	stdout.Reset()
+	if exit := run([]string{"dl", "decode", "https://id.gs1.org/ARHKVAdpQg"}, &stdout, &stderr); exit != 0 || stdout.String() != "01\t09780345418913\n" {
+		t.Errorf("got exit %d, %q: %s", exit, stdout.String(), stderr.String())
+	}
+
+	stderr.Reset()
+	if exit := run([]string{"dl", "decode", "https://id.gs1.org/ARHKVAdp"}, &stdout, &stderr); exit != 1 {
+		t.Errorf("wanted exit 1, got %d", exit)
+	}
+	if !strings.Contains(stderr.String(), "compressed") {
+		t.Errorf("wanted compressed error, got %s", stderr.String())
+	}
+	if exit := run([]string{"dl", "encode", "9506000134352", "expiry=251231"}, &stdout, &stderr); exit != 2 {
+		t.Errorf("wanted exit 2 for bad attribute, got %d", exit)
+	}
+}
+
+func TestJSON(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"--json", "validate", "4006381333931", "4006381333932", "12a"}, &stdout, &stderr); exit != exitInvalid {
+		t.Errorf("wanted exit %d, got %d", exitInvalid, exit)
+	}
+
+	var results []validateResult
+	dec := json.NewDecoder(&stdout)
+	for dec.More() {
+		var r validateResult
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, r)
+	}
+	if len(results) != 3 {
+		t.Fatalf("wanted 3 results, got %d", len(results))
+	}
+	if r := results[0]; !r.Valid || r.Type != gtin.GTIN13 || r.Carrier != gtin.EAN13 || r.Prefix != "GS1 Germany" || *r.CheckDigit != 1 {
+		t.Errorf("got %+v", r)
+	}
+	if r := results[1]; r.Valid || len(r.Errors) != 1 || r.Errors[0].Code != "GTIN_E003_CHECK_DIGIT" || *r.CheckDigit != 1 {
+		t.Errorf("got %+v", r)
+	}
+	if r := results[2]; r.Valid || r.GTIN != "" || len(r.Errors) != 2 || r.Errors[1].Code != "GTIN_E002_DIGIT" {
+		t.Errorf("got %+v", r)
+	}
+
+	stdout.Reset()
+	run([]string{"--json", "checkdigit", "9638507"}, &stdout, &stderr)
+	if want := `{"payload":"9638507","checkDigit":4,"gtin":"96385074"}` + "\n"; stdout.String() != want {
+		t.Errorf("wanted %s, got %s", want, stdout.String())
+	}
+
+	stdout.Reset()
+	run([]string{"--json", "convert", "--to", "gtin8", "614141000012"}, &stdout, &stderr)
+	if !strings.Contains(stdout.String(), `"code":"GTIN_E009_NOT_CONVERTIBLE"`) {
+		t.Errorf("wanted error code in %s", stdout.String())
+	}
+
+	// Without --json, output is text again
+	stdout.Reset()
+	run([]string{"generate", "--prefix", "40063813339", "--sequential", "--start", "3"}, &stdout, &stderr)
+	if stdout.String() != "4006381333931\n" {
+		t.Errorf("wanted text, got %s", stdout.String())
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.csv")
+	os.WriteFile(path, []byte("ean\n4006381333931\n"), 0o644)
+
+	tests := []struct {
+		args []string
+		exit int
+	}{
+		{[]string{"batch", "--column", "ean", path}, exitOK},
+		{[]string{"batch", "--column", "sku", path}, exitUsage},
+		{[]string{"batch", filepath.Join(dir, "missing.csv")}, exitIO},
+		{[]string{"barcode", "--out", filepath.Join(dir, "no", "such", "dir.svg"), "4006381333931"}, exitIO},
+		{[]string{"--nosuchflag", "validate"}, exitUsage},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if exit := run(tt.args, &stdout, &stderr); exit != tt.exit {
+			t.Errorf("%v: wanted exit %d, got %d: %s", tt.args, tt.exit, exit, stderr.String())
+		}
+	}
+}
+
+func TestValidateStdin(t *testing.T) {
+
+	stdin = strings.NewReader("4006381333931\n\n  614141000012 \n4006381333932\n")
+	defer func() { stdin = os.Stdin }()
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"validate", "-"}, &stdout, &stderr); exit != exitInvalid {
+		t.Errorf("wanted exit %d, got %d", exitInvalid, exit)
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("wanted 3 lines, got %q", lines)
+	}
+	if want := "4006381333931\tGTIN-13\tEAN-13\tOK\tGS1 Germany\tvalid"; lines[0] != want {
+		t.Errorf("wanted %q, got %q", want, lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "614141000012\tGTIN-12\t") || !strings.HasSuffix(lines[2], "invalid check digit") {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestTUI(t *testing.T) {
+
+	// Type a payload, fix a typo, accept with Enter and type a valid code
+	stdin = strings.NewReader("40063813338\x7f93\r96385074\x1bignored")
+	defer func() { stdin = os.Stdin }()
+
+	var stdout, stderr bytes.Buffer
+	if exit := run([]string{"tui"}, &stdout, &stderr); exit != exitOK {
+		t.Fatalf("wanted exit 0, got %d: %s", exit, stderr.String())
+	}
+	frames := strings.Split(stdout.String(), clearScreen)
+	// Empty screen, 11 digits, backspace, 2 digits, Enter and 8 digits
+	if len(frames) != 1+1+11+1+2+1+8 {
+		t.Fatalf("wanted one frame per key, got %d", len(frames)-1)
+	}
+
+	payload := frames[1+14]
+	for _, want := range []string{"> " + reverse + "400" + reset + "638133393\r\n", "check digit 1, as 4006381333931", "GS1 Germany"} {
+		if !strings.Contains(payload, want) {
+			t.Errorf("wanted %q in\n%s", want, payload)
+		}
+	}
+	if !strings.Contains(payload, "result      invalid check digit") || strings.Contains(payload, "█") {
+		t.Errorf("wanted invalid GTIN-12 and no barcode\n%s", payload)
+	}
+
+	last := frames[len(frames)-1]
+	for _, want := range []string{"result      valid", "GTIN-8, EAN-8", "█", "9638 5074", "last        400638133393  invalid check digit"} {
+		if !strings.Contains(last, want) {
+			t.Errorf("wanted %q in\n%s", want, last)
+		}
+	}
+	if strings.Contains(last, "check digit 4") {
+		t.Errorf("wanted no check digit suggestion for a valid code\n%s", last)
+	}
+}
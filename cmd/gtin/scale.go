@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// runScale prints the variable measure EAN-13 of each item and price or
+// weight, for the configuration files of in-store scales
+func runScale(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("scale", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var l gtin.ScaleLayout
+	fs.StringVar(&l.Prefix, "prefix", "2", "GS1 prefix of the layout, 20 to 29")
+	fs.IntVar(&l.ItemWidth, "item", 6, "digits of the item code")
+	fs.IntVar(&l.ValueWidth, "value", 5, "digits of the price or weight")
+	fs.BoolVar(&l.PriceCheck, "price-check", false, "add a price check digit before a 4 or 5 digit value")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin scale [flags] <item>:<price or weight>...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	exit := exitOK
+	for _, arg := range fs.Args() {
+		item, value, ok := strings.Cut(arg, ":")
+		i, err1 := strconv.Atoi(item)
+		v, err2 := strconv.Atoi(value)
+		if !ok || err1 != nil || err2 != nil {
+			fmt.Fprintf(stderr, "gtin scale: %q is not <item>:<price or weight>\n", arg)
+			return exitUsage
+		}
+		gt, err := l.Encode(i, v)
+		if err != nil {
+			exit = exitInvalid
+		}
+		switch {
+		case jsonOutput:
+			r := struct {
+				Item   int         `json:"item"`
+				Value  int         `json:"value"`
+				GTIN   string      `json:"gtin,omitempty"`
+				Errors []jsonError `json:"errors,omitempty"`
+			}{Item: i, Value: v, Errors: jsonErrors(err)}
+			if err == nil {
+				r.GTIN = gt.Short()
+			}
+			writeJSON(stdout, r)
+		case err != nil:
+			fmt.Fprintf(stderr, "%s: %v\n", arg, err)
+		default:
+			fmt.Fprintf(stdout, "%s\n", gt.Short())
+		}
+	}
+	return exit
+}
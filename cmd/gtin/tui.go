@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/barcode"
+)
+
+// ANSI sequences used by the TUI
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	reverse     = "\x1b[7m"
+	reset       = "\x1b[0m"
+)
+
+// Keys handled by the TUI, in addition to digits
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// runTUI runs an interactive validator that validates as you type. Enter,
+// as sent by most barcode scanners, clears the input for the next code.
+func runTUI(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin tui\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	// Read keys one by one, without echo, and handle Ctrl-C ourselves so
+	// the terminal is always restored
+	if f, ok := stdin.(*os.File); ok && isTerminal(f) {
+		restore, err := stty(f, "-icanon", "-echo", "-isig", "min", "1")
+		if err != nil {
+			fmt.Fprintf(stderr, "gtin tui: %v\n", err)
+			return exitIO
+		}
+		defer restore()
+	}
+
+	tui(stdin, stdout)
+	return exitOK
+}
+
+// isTerminal reports whether f is a character device, such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stty changes the settings of the terminal f and returns a function that
+// restores them
+func stty(f *os.File, settings ...string) (func(), error) {
+
+	cmd := exec.Command("stty", "-g")
+	cmd.Stdin = f
+	saved, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	cmd = exec.Command("stty", settings...)
+	cmd.Stdin = f
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return func() {
+		cmd := exec.Command("stty", strings.TrimSpace(string(saved)))
+		cmd.Stdin = f
+		cmd.Run()
+	}, nil
+}
+
+// tui reads keys from in and redraws the screen on out after each key,
+// until Ctrl-C, Ctrl-D, Escape or end of input
+func tui(in io.Reader, out io.Writer) {
+
+	r := bufio.NewReader(in)
+	var input []byte
+	var last string
+	fmt.Fprint(out, clearScreen+frame("", last))
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		switch {
+		case b == keyCtrlC || b == keyCtrlD || b == keyEscape:
+			return
+		case b == keyBackspace || b == keyDelete:
+			if len(input) > 0 {
+				input = input[:len(input)-1]
+			}
+		case b == '\r' || b == '\n':
+			if len(input) > 0 {
+				last = summarize(string(input))
+			}
+			input = input[:0]
+		case b >= '0' && b <= '9' && len(input) < gtin.GTIN_LENGTH:
+			input = append(input, b)
+		default:
+			continue
+		}
+		fmt.Fprint(out, clearScreen+frame(string(input), last))
+	}
+}
+
+// summarize returns the code with its validation result on one line
+func summarize(code string) string {
+	gt, err := gtin.Atog(code)
+	if err != nil {
+		return code + "  " + oneLine(err)
+	}
+	if report := gt.Validate(); !report.OK() {
+		return code + "  " + oneLine(report.Errors...)
+	}
+	return code + "  valid"
+}
+
+// frame returns the screen for the given input: the input with its prefix
+// highlighted, the check digit, the validation result and, for valid
+// codes, a barcode preview
+func frame(input, last string) string {
+
+	var s strings.Builder
+	s.WriteString("GTIN validator - type or scan a code, Enter for next, Esc to quit\r\n\r\n")
+
+	// Complete payloads with their check digit to find the prefix early.
+	// Codes with a wrong check digit are more likely payloads being typed.
+	code := input
+	gt, err := gtin.Atog(code)
+	check, checkErr := gtin.ComputeCheckDigit(input)
+	if (err != nil || !gt.Valid()) && checkErr == nil {
+		code = fmt.Sprintf("%s%d", input, check)
+		gt, err = gtin.Atog(code)
+	}
+
+	name := ""
+	from, to := 0, 0
+	if err == nil {
+		from, to, name = prefixSegment(gt, len(code))
+		to = min(to, len(input))
+	}
+	shown := input
+	if from < to {
+		shown = input[:from] + reverse + input[from:to] + reset + input[to:]
+	}
+	fmt.Fprintf(&s, "> %s\r\n\r\n", shown)
+	if name != "" {
+		fmt.Fprintf(&s, "  prefix      %s\r\n", name)
+	}
+
+	// A 12 or 13 digit input is either a code or the payload of a longer
+	// one, so the check digit is only suggested if it isn't a valid code
+	valid, validErr := gtin.Parse(input)
+	if checkErr == nil && validErr != nil {
+		fmt.Fprintf(&s, "  check digit %d, as %s%d\r\n", check, input, check)
+	}
+	switch len(input) {
+	case 8, 12, 13, 14:
+		fmt.Fprintf(&s, "  result      %s\r\n", strings.TrimPrefix(summarize(input), input+"  "))
+	}
+	if validErr == nil {
+		fmt.Fprintf(&s, "  type        %s, %s\r\n\r\n", valid.Type(), valid.Carrier())
+		if b, err := barcode.Encode(valid, barcode.Auto); err == nil {
+			s.WriteString(blocks(b))
+		}
+	}
+
+	if last != "" {
+		fmt.Fprintf(&s, "\r\n  last        %s\r\n", last)
+	}
+	return s.String()
+}
+
+// prefixSegment returns the range of the input holding the company prefix,
+// or else the GS1 prefix, with a description
+func prefixSegment(gt gtin.GTIN, length int) (from, to int, name string) {
+
+	// Prefixes are looked up in the 13-digit form, or the 8 digits of a
+	// GTIN-8, which end where the input ends
+	start, size := length-13, 3
+	if gt.MinimalType() == gtin.GTIN8 {
+		start = length - 8
+	}
+	if mo, err := gt.MemberOrganization(); err == nil {
+		name = mo
+	}
+	if gcp, err := gt.CompanyPrefix(); err == nil {
+		size = len(gcp)
+		name = fmt.Sprintf("company prefix %s, %s", gcp, name)
+	}
+
+	from, to = max(start, 0), max(start+size, 0)
+	return from, min(to, length), name
+}
+
+// blocks draws the barcode with Unicode half blocks, two modules per
+// character, with its human readable interpretation below
+func blocks(b barcode.Barcode) string {
+
+	const rows = 4
+	glyphs := [4]string{" ", "▐", "▌", "█"}
+
+	bars := append(append([]bool{false, false}, b.Bars...), false, false)
+	if len(bars)%2 != 0 {
+		bars = append(bars, false)
+	}
+	var line strings.Builder
+	for n := 0; n < len(bars); n += 2 {
+		g := 0
+		if bars[n] {
+			g |= 2
+		}
+		if bars[n+1] {
+			g |= 1
+		}
+		line.WriteString(glyphs[g])
+	}
+
+	var s strings.Builder
+	for n := 0; n < rows; n++ {
+		s.WriteString("  " + line.String() + "\r\n")
+	}
+	fmt.Fprintf(&s, "  %*s\r\n", (len(bars)/2+len(b.Text))/2, b.Text)
+	return s.String()
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/peterstark72/gtin"
+)
+
+// runValidate prints type, carrier, check digit status and prefix info of
+// each code. It returns 1 if any code is invalid.
+//
+// The argument - reads codes from stdin, one per line, and streams the
+// results as tab-separated lines without header, so the command works in
+// pipes on files of any size.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	profile := fs.String("profile", "", "validate for a sales channel: "+strings.Join(gtin.Profiles(), ", "))
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gtin validate [flags] <code>... | -\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	policy, ok := policyOf(*profile)
+	if fs.NArg() == 0 || !ok {
+		fs.Usage()
+		return exitUsage
+	}
+
+	exit := exitOK
+	emit := func(w io.Writer, code string) {
+		r := validate(code, policy)
+		if !r.Valid {
+			exit = exitInvalid
+		}
+		if jsonOutput {
+			writeJSON(w, r)
+		} else {
+			r.print(w)
+		}
+	}
+
+	if fs.NArg() == 1 && fs.Arg(0) == "-" {
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			if code := strings.TrimSpace(scanner.Text()); code != "" {
+				emit(stdout, code)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintf(stderr, "gtin validate: %v\n", err)
+			return exitIO
+		}
+		return exit
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	if !jsonOutput {
+		fmt.Fprintln(tw, "CODE\tTYPE\tCARRIER\tCHECK DIGIT\tPREFIX\tRESULT")
+	}
+	for _, code := range fs.Args() {
+		emit(tw, code)
+	}
+	tw.Flush()
+	return exit
+}
+
+// validateResult is the outcome of validating one code
+type validateResult struct {
+	Code       string      `json:"code"`
+	GTIN       string      `json:"gtin,omitempty"`
+	Type       gtin.Type   `json:"type,omitempty"`
+	Carrier    string      `json:"carrier,omitempty"`
+	CheckDigit *uint8      `json:"checkDigit,omitempty"` // The correct check digit
+	Prefix     string      `json:"prefix,omitempty"`
+	Valid      bool        `json:"valid"`
+	Errors     []jsonError `json:"errors,omitempty"`
+	Warnings   []jsonError `json:"warnings,omitempty"`
+
+	gt          gtin.GTIN
+	errs, warns []error
+}
+
+// policyOf returns the policy of the named profile, or the default policy
+// if name is empty
+func policyOf(name string) (gtin.Policy, bool) {
+	if name == "" {
+		return gtin.DefaultPolicy(), true
+	}
+	profile, ok := gtin.LookupProfile(name)
+	return profile.Policy, ok
+}
+
+// validate parses and validates a code
+func validate(code string, policy gtin.Policy) validateResult {
+
+	r := validateResult{Code: code}
+	gt, err := gtin.Atog(code)
+	if err != nil {
+		r.errs = []error{err}
+		r.Errors = jsonErrors(err)
+		return r
+	}
+
+	check := gtin.GS1Mod10{}.CheckDigit(digitsOf(gt))
+	report := policy.Validate(gt)
+	r.gt = gt
+	r.GTIN = gt.String()
+	r.Type = gt.Type()
+	r.Carrier = gt.Carrier()
+	r.CheckDigit = &check
+	r.Prefix, _ = gt.MemberOrganization()
+	r.Valid = report.OK()
+	r.errs, r.warns = report.Errors, report.Warnings
+	r.Errors, r.Warnings = jsonErrors(report.Errors...), jsonErrors(report.Warnings...)
+	return r
+}
+
+// print writes the result as a row of the validate table
+func (r validateResult) print(tw io.Writer) {
+
+	if r.GTIN == "" {
+		fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t%v\n", r.Code, oneLine(r.errs...))
+		return
+	}
+
+	check := "OK"
+	if !r.gt.Valid() {
+		check = fmt.Sprintf("wrong, want %d", *r.CheckDigit)
+	}
+	prefix := r.Prefix
+	if prefix == "" {
+		prefix = "unknown"
+	}
+	result := "valid"
+	if !r.Valid {
+		result = oneLine(r.errs...)
+	} else if len(r.warns) > 0 {
+		result = "valid, " + oneLine(r.warns...)
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Code, r.Type, r.Carrier, check, prefix, result)
+}
+
+// digitsOf returns the payload of the GTIN-14, without the check digit
+func digitsOf(gt gtin.GTIN) []uint8 {
+	digits := gt.Digits()
+	return digits[:gtin.GTIN_LENGTH-1]
+}
+
+// oneLine joins errors, unwrapping joined errors, into one line
+func oneLine(errs ...error) string {
+	var parts []string
+	for _, err := range errs {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			parts = append(parts, oneLine(joined.Unwrap()...))
+			continue
+		}
+		parts = append(parts, err.Error())
+	}
+	return strings.Join(parts, "; ")
+}
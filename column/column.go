@@ -0,0 +1,124 @@
+/*
+Package column converts between []gtin.GTIN and columnar buffers laid out
+like Apache Arrow arrays, for analytics pipelines using Arrow or Parquet.
+
+GTINs are either a uint64 column, see gtin.GTIN.Uint64, or a
+FixedSizeBinary(6) column of the keys of gtin.GTIN.Key. Nulls are marked
+in a validity bitmap as Arrow defines it: bit n, least significant first,
+is set if row n is not null. A nil bitmap means no nulls.
+
+The package has no dependency on an Arrow implementation. The buffers can
+be wrapped without copying, e.g. with array.NewData of the Arrow Go
+module, and the buffers of Arrow arrays passed in directly.
+*/
+package column
+
+import (
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Width is the byte width of a FixedSizeBinary GTIN column
+const Width = gtin.KEY_SIZE
+
+// Uint64s returns the GTINs as a uint64 column. Zero GTINs are null.
+func Uint64s(gts []gtin.GTIN) (values []uint64, validity []byte) {
+
+	values = make([]uint64, len(gts))
+	validity = bitmap(gts)
+	for n, gt := range gts {
+		values[n] = gt.Uint64()
+	}
+	return values, validity
+}
+
+// FromUint64s returns the GTIN-14s of a uint64 column, and zero GTINs for
+// nulls. It returns an error for values of more than 14 digits.
+func FromUint64s(values []uint64, validity []byte) ([]gtin.GTIN, error) {
+
+	gts := make([]gtin.GTIN, len(values))
+	for n, v := range values {
+		if !IsValid(validity, n) {
+			continue
+		}
+		gt, err := gtin.FromUint64(v, gtin.GTIN14)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", n, err)
+		}
+		gts[n] = gt
+	}
+	return gts, nil
+}
+
+// FixedSizeBinary returns the GTINs as a FixedSizeBinary column of Width
+// bytes per row. Zero GTINs are null.
+func FixedSizeBinary(gts []gtin.GTIN) (data []byte, validity []byte) {
+
+	data = make([]byte, 0, Width*len(gts))
+	for _, gt := range gts {
+		key := gt.Key()
+		data = append(data, key[:]...)
+	}
+	return data, bitmap(gts)
+}
+
+// FromFixedSizeBinary returns the GTIN-14s of a FixedSizeBinary column, and
+// zero GTINs for nulls
+func FromFixedSizeBinary(data []byte, validity []byte) ([]gtin.GTIN, error) {
+
+	if len(data)%Width != 0 {
+		return nil, fmt.Errorf("%w %d of column, not a multiple of %d", gtin.ErrLength, len(data), Width)
+	}
+	gts := make([]gtin.GTIN, len(data)/Width)
+	for n := range gts {
+		if !IsValid(validity, n) {
+			continue
+		}
+		gt, err := gtin.FromKey(data[n*Width : (n+1)*Width])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", n, err)
+		}
+		gts[n] = gt
+	}
+	return gts, nil
+}
+
+// Valid returns a bitmap of the rows of a uint64 column that hold valid
+// GTINs, see gtin.GTIN.Valid. Nulls are not valid.
+func Valid(values []uint64, validity []byte) []byte {
+
+	valid := make([]byte, (len(values)+7)/8)
+	for n, v := range values {
+		if !IsValid(validity, n) {
+			continue
+		}
+		if gt, err := gtin.FromUint64(v, gtin.GTIN14); err == nil && gt.Valid() {
+			valid[n/8] |= 1 << (n % 8)
+		}
+	}
+	return valid
+}
+
+// IsValid reports whether row n is not null in the bitmap
+func IsValid(validity []byte, n int) bool {
+	return validity == nil || validity[n/8]&(1<<(n%8)) != 0
+}
+
+// bitmap returns the validity bitmap of the GTINs, nil if none is zero
+func bitmap(gts []gtin.GTIN) []byte {
+
+	var validity []byte
+	for n, gt := range gts {
+		if gt.IsZero() && validity == nil {
+			validity = make([]byte, (len(gts)+7)/8)
+			for i := 0; i < n; i++ {
+				validity[i/8] |= 1 << (i % 8)
+			}
+		}
+		if validity != nil && !gt.IsZero() {
+			validity[n/8] |= 1 << (n % 8)
+		}
+	}
+	return validity
+}
@@ -0,0 +1,71 @@
+package column
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+var gts = []gtin.GTIN{
+	gtin.MustParse("4006381333931"),
+	{},
+	gtin.MustParse("96385074"),
+	gtin.MustParse("10614141000019"),
+}
+
+func TestUint64s(t *testing.T) {
+
+	values, validity := Uint64s(gts)
+	if values[0] != 4006381333931 || values[2] != 96385074 || !bytes.Equal(validity, []byte{0b1101}) {
+		t.Errorf("got %v, %08b", values, validity)
+	}
+
+	back, err := FromUint64s(values, validity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := range gts {
+		if !gtin.Equal(back[n], gts[n]) {
+			t.Errorf("row %d: wanted %v, got %v", n, gts[n], back[n])
+		}
+	}
+
+	if _, validity := Uint64s(gts[:1]); validity != nil {
+		t.Errorf("wanted no bitmap without nulls, got %08b", validity)
+	}
+	if _, err := FromUint64s([]uint64{1e14}, nil); !errors.Is(err, gtin.ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+}
+
+func TestFixedSizeBinary(t *testing.T) {
+
+	data, validity := FixedSizeBinary(gts)
+	if len(data) != Width*len(gts) || !bytes.Equal(validity, []byte{0b1101}) {
+		t.Errorf("got %x, %08b", data, validity)
+	}
+	back, err := FromFixedSizeBinary(data, validity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := range gts {
+		if !gtin.Equal(back[n], gts[n]) {
+			t.Errorf("row %d: wanted %v, got %v", n, gts[n], back[n])
+		}
+	}
+
+	if _, err := FromFixedSizeBinary(data[:7], nil); !errors.Is(err, gtin.ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+}
+
+func TestValid(t *testing.T) {
+
+	// Wrong check digit, null, valid, valid
+	values := []uint64{4006381333932, 0, 96385074, 10614141000019}
+	if got := Valid(values, []byte{0b1101}); !bytes.Equal(got, []byte{0b1100}) {
+		t.Errorf("got %08b", got)
+	}
+}
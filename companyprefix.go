@@ -0,0 +1,76 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompanyPrefix is a GS1 Company Prefix of 4 to 12 digits, as licensed to
+// a company by a GS1 member organization
+type CompanyPrefix string
+
+// ParseCompanyPrefix checks that the prefix is 4 to 12 digits and that its
+// length matches the GCP length table, see LoadGCPLengths. Prefixes the
+// table has no entry for are accepted.
+func ParseCompanyPrefix(input string) (CompanyPrefix, error) {
+	return parseCompanyPrefix(currentGCPLengths(), input)
+}
+
+func parseCompanyPrefix(t *GCPLengths, input string) (CompanyPrefix, error) {
+
+	if len(input) < 4 || len(input) > 12 {
+		return "", fmt.Errorf("%w %d of company prefix", ErrLength, len(input))
+	}
+	if n := strings.IndexFunc(input, func(r rune) bool { return r < '0' || r > '9' }); n >= 0 {
+		return "", &PositionError{ErrDigit, input[n], n}
+	}
+	if length, ok := t.Lookup(input); ok && length != len(input) {
+		if length == 0 {
+			return "", fmt.Errorf("%w: no company prefixes are allocated under %s", ErrCompanyPrefix, input)
+		}
+		return "", fmt.Errorf("%w: %s should be %d digits", ErrCompanyPrefix, input, length)
+	}
+	return CompanyPrefix(input), nil
+}
+
+// Capacity returns the number of GTINs of the given type the prefix can
+// number. GTIN-12s need a prefix starting with 0, the U.P.C. Company
+// Prefix being the remaining digits. GTIN-14s have the capacity of the
+// GTIN-13 they are based on, per indicator digit. Company prefixes don't
+// number GTIN-8s.
+func (p CompanyPrefix) Capacity(typ Type) int {
+	switch typ {
+	case GTIN13, GTIN14:
+		return pow10(GTIN13.Len() - 1 - len(p))
+	case GTIN12:
+		if strings.HasPrefix(string(p), "0") {
+			return pow10(GTIN12.Len() - len(p))
+		}
+	}
+	return 0
+}
+
+// SSCCCapacity returns the number of SSCCs the prefix can number, over all
+// ten extension digits
+func (p CompanyPrefix) SSCCCapacity() int {
+	return 10 * pow10(SSCC_LENGTH-2-len(p))
+}
+
+// Contains returns true if the GTIN was numbered from the prefix
+func (p CompanyPrefix) Contains(gt GTIN) bool {
+	if gt.IsZero() || gt.MinimalType() == GTIN8 {
+		return false
+	}
+	return strings.HasPrefix(gt.String()[1:], string(p))
+}
+
+func pow10(n int) int {
+	if n < 0 {
+		return 0
+	}
+	p := 1
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
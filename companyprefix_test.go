@@ -0,0 +1,47 @@
+package gtin
+
+import "testing"
+
+func TestCompanyPrefixCapacity(t *testing.T) {
+
+	tests := []struct {
+		prefix CompanyPrefix
+		typ    Type
+		want   int
+	}{
+		{"4006381", GTIN13, 100000},
+		{"4006381", GTIN14, 100000},
+		{"4006381", GTIN12, 0},
+		{"0614141", GTIN12, 100000},
+		{"0614141", GTIN13, 100000},
+		{"400638133393", GTIN13, 1},
+		{"4006381", GTIN8, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.prefix.Capacity(tt.typ); got != tt.want {
+			t.Errorf("%s %s: wanted %d, got %d", tt.prefix, tt.typ, tt.want, got)
+		}
+	}
+	if got := CompanyPrefix("0614141").SSCCCapacity(); got != 10000000000 {
+		t.Errorf("wanted 10000000000 SSCCs, got %d", got)
+	}
+}
+
+func TestCompanyPrefixContains(t *testing.T) {
+
+	p := CompanyPrefix("0614141")
+	for code, want := range map[string]bool{
+		"614141000012":   true,
+		"00614141000012": true,
+		"50614141000994": true,
+		"4006381333931":  false,
+		"96385074":       false,
+	} {
+		if got := p.Contains(MustParse(code)); got != want {
+			t.Errorf("%s: wanted %v", code, want)
+		}
+	}
+	if p.Contains(GTIN{}) {
+		t.Error("zero GTIN")
+	}
+}
@@ -0,0 +1,147 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pad returns the GTIN as a string of the given type, adding or removing
+// leading zeroes, e.g. 0614141000012 for 614141000012 as GTIN-13
+func (gt GTIN) Pad(typ Type) (string, error) {
+	length := typ.Len()
+	if length == 0 {
+		return "", fmt.Errorf("%w %q", ErrType, typ)
+	}
+	if length < gt.MinimalType().Len() {
+		return "", fmt.Errorf("%w: %s is too short for %s", ErrNotConvertible, typ, gt)
+	}
+	return gt.String()[GTIN_LENGTH-length:], nil
+}
+
+// ParseISBN10 converts an ISBN-10, with or without hyphens, to its
+// Bookland GTIN-13 with prefix 978
+func ParseISBN10(isbn string) (GTIN, error) {
+
+	isbn = strings.NewReplacer("-", "", " ", "").Replace(isbn)
+	if len(isbn) != 10 {
+		return GTIN{}, fmt.Errorf("%w %d", ErrLength, len(isbn))
+	}
+
+	digits := make([]uint8, 9)
+	for n := 0; n < 9; n++ {
+		if isbn[n] < '0' || isbn[n] > '9' {
+			return GTIN{}, &PositionError{ErrDigit, isbn[n], n}
+		}
+		digits[n] = isbn[n] - '0'
+	}
+	want := ISBNMod11{}.CheckDigit(digits)
+	got := isbn[9]
+	if !(want == 10 && (got == 'X' || got == 'x') || want < 10 && got == '0'+want) {
+		return GTIN{}, ErrCheckDigit
+	}
+
+	payload := "978" + isbn[:9]
+	check, err := ComputeCheckDigit(payload)
+	if err != nil {
+		return GTIN{}, err
+	}
+	return Parse(payload + string('0'+check))
+}
+
+// ISBN10 returns the ISBN-10 of a Bookland GTIN with prefix 978. GTINs with
+// prefix 979 have no ISBN-10.
+func (gt GTIN) ISBN10() (string, error) {
+
+	s := gt.String()
+	if gt.MinimalType() != GTIN13 || s[1:4] != "978" {
+		return "", fmt.Errorf("%w: %s is not a 978 Bookland GTIN", ErrNotConvertible, gt)
+	}
+
+	var digits [9]uint8
+	copy(digits[:], gt.digits[4:13])
+	check := ISBNMod11{}.CheckDigit(digits[:])
+	if check == 10 {
+		return s[4:13] + "X", nil
+	}
+	return s[4:13] + string('0'+check), nil
+}
+
+// ParseUPCE expands an 8-digit UPC-E code, number system 0 or 1, to its
+// UPC-A GTIN-12
+func ParseUPCE(upce string) (GTIN, error) {
+
+	if len(upce) != 8 {
+		return GTIN{}, fmt.Errorf("%w %d", ErrLength, len(upce))
+	}
+	if strings.Trim(upce, "0123456789") != "" {
+		return GTIN{}, ErrDigit
+	}
+	if upce[0] != '0' && upce[0] != '1' {
+		return GTIN{}, fmt.Errorf("%w: UPC-E number system must be 0 or 1", ErrNotConvertible)
+	}
+
+	ns, d, check := upce[:1], upce[1:7], upce[7:]
+	var body string
+	switch d[5] {
+	case '0', '1', '2':
+		body = d[0:2] + d[5:6] + "0000" + d[2:5]
+	case '3':
+		body = d[0:3] + "00000" + d[3:5]
+	case '4':
+		body = d[0:4] + "00000" + d[4:5]
+	default:
+		body = d[0:5] + "0000" + d[5:6]
+	}
+	return Parse(ns + body + check)
+}
+
+// UPCE returns the 8-digit UPC-E code of a GTIN-12 that can be zero
+// suppressed
+func (gt GTIN) UPCE() (string, error) {
+
+	s := gt.String()
+	if gt.MinimalType() != GTIN12 || (s[2] != '0' && s[2] != '1') {
+		return "", fmt.Errorf("%w: %s has no UPC-E", ErrNotConvertible, gt)
+	}
+
+	ns, m, p, check := s[2:3], s[3:8], s[8:13], s[13:]
+	switch {
+	case m[2:] == "000" || m[2:] == "100" || m[2:] == "200":
+		if p[:2] == "00" {
+			return ns + m[:2] + p[2:] + m[2:3] + check, nil
+		}
+	case m[3:] == "00":
+		if p[:3] == "000" {
+			return ns + m[:3] + p[3:] + "3" + check, nil
+		}
+	case m[4:] == "0":
+		if p[:4] == "0000" {
+			return ns + m[:4] + p[4:] + "4" + check, nil
+		}
+	}
+	if p[:4] == "0000" && p[4] >= '5' {
+		return ns + m + p[4:] + check, nil
+	}
+	return "", fmt.Errorf("%w: %s has no UPC-E", ErrNotConvertible, gt)
+}
+
+// NewGTIN14 returns the GTIN-14 of a packaging level of a base item, such
+// as a case of a GTIN-13, with the given indicator digit 1-9 and a new
+// check digit
+func NewGTIN14(base GTIN, indicator uint8) (GTIN, error) {
+
+	if indicator < 1 || indicator > 9 {
+		return GTIN{}, fmt.Errorf("%w: indicator %d", ErrNotConvertible, indicator)
+	}
+	if err := checkStructure(base); err != nil {
+		return GTIN{}, err
+	}
+	if base.digits[0] != 0 {
+		return GTIN{}, fmt.Errorf("%w: %s already has indicator %d", ErrNotConvertible, base, base.digits[0])
+	}
+
+	gt := GTIN{typ: GTIN14, digits: base.digits}
+	gt.digits[0] = indicator
+	gt.digits[GTIN_LENGTH-1] = GS1Mod10{}.CheckDigit(gt.digits[:GTIN_LENGTH-1])
+	return gt, nil
+}
@@ -0,0 +1,132 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+
+	gt := MustParse("614141000012")
+
+	tests := []struct {
+		typ  Type
+		want string
+		err  error
+	}{
+		{GTIN12, "614141000012", nil},
+		{GTIN13, "0614141000012", nil},
+		{GTIN14, "00614141000012", nil},
+		{GTIN8, "", ErrNotConvertible},
+	}
+
+	for _, tt := range tests {
+		got, err := gt.Pad(tt.typ)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("%v: wanted %v %v, got %v %v", tt.typ, tt.want, tt.err, got, err)
+		}
+	}
+}
+
+func TestISBN(t *testing.T) {
+
+	tests := []struct {
+		isbn string
+		gtin string
+	}{
+		{"0-306-40615-2", "9780306406157"},
+		{"080442957X", "9780804429573"},
+		{"0198526636", "9780198526636"},
+	}
+
+	for _, tt := range tests {
+		gt, err := ParseISBN10(tt.isbn)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if gt.Short() != tt.gtin {
+			t.Errorf("wanted %v, got %v", tt.gtin, gt.Short())
+		}
+		isbn, err := gt.ISBN10()
+		if err != nil || !EqualString(MustParse(tt.gtin), tt.gtin) {
+			t.Error(err)
+		}
+		if back, _ := ParseISBN10(isbn); back != gt {
+			t.Errorf("round trip failed for %v", isbn)
+		}
+	}
+
+	if _, err := ParseISBN10("0306406153"); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("wanted %v, got %v", ErrCheckDigit, err)
+	}
+	if _, err := MustParse("9791234567896").ISBN10(); !errors.Is(err, ErrNotConvertible) {
+		t.Errorf("wanted %v, got %v", ErrNotConvertible, err)
+	}
+}
+
+func TestUPCE(t *testing.T) {
+
+	tests := []struct {
+		upce string
+		upca string
+	}{
+		{"04252614", "042100005264"},
+		{"01234505", "012000003455"},
+		{"01234531", "012300000451"},
+		{"01234543", "012340000053"},
+		{"01234558", "012345000058"},
+	}
+
+	for _, tt := range tests {
+		gt, err := ParseUPCE(tt.upce)
+		if err != nil {
+			t.Errorf("%v: %v", tt.upce, err)
+			continue
+		}
+		if gt.Short() != tt.upca {
+			t.Errorf("wanted %v, got %v", tt.upca, gt.Short())
+		}
+		upce, err := gt.UPCE()
+		if err != nil || upce != tt.upce {
+			t.Errorf("wanted %v, got %v %v", tt.upce, upce, err)
+		}
+	}
+
+	if _, err := MustParse("614141000012").UPCE(); !errors.Is(err, ErrNotConvertible) {
+		t.Errorf("wanted %v, got %v", ErrNotConvertible, err)
+	}
+}
+
+func TestNewGTIN14(t *testing.T) {
+
+	tests := []struct {
+		base      string
+		indicator uint8
+		want      string
+	}{
+		{"4006381333931", 1, "14006381333938"},
+		{"4006381333931", 5, "54006381333936"},
+		{"614141000012", 9, "90614141000015"},
+		{"96385074", 1, "10000096385071"},
+	}
+
+	for _, tt := range tests {
+		gt, err := NewGTIN14(MustParse(tt.base), tt.indicator)
+		if err != nil || gt.String() != tt.want || gt.Type() != GTIN14 || !gt.Valid() {
+			t.Errorf("%s/%d: wanted %s, got %s %v", tt.base, tt.indicator, tt.want, gt, err)
+		}
+	}
+
+	for _, indicator := range []uint8{0, 10} {
+		if _, err := NewGTIN14(MustParse("4006381333931"), indicator); !errors.Is(err, ErrNotConvertible) {
+			t.Errorf("indicator %d: wanted ErrNotConvertible, got %v", indicator, err)
+		}
+	}
+	if _, err := NewGTIN14(MustParse("14006381333938"), 2); !errors.Is(err, ErrNotConvertible) {
+		t.Errorf("wanted ErrNotConvertible for a GTIN-14 with indicator, got %v", err)
+	}
+	if _, err := NewGTIN14(GTIN{}, 1); !errors.Is(err, ErrType) {
+		t.Errorf("wanted ErrType for zero GTIN, got %v", err)
+	}
+}
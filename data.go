@@ -0,0 +1,26 @@
+package gtin
+
+//go:generate go run gen.go
+
+// The snapshots in data_tables.go, generated from data/, are used until a
+// newer dataset is set with SetGS1Prefixes/SetGCPLengths or loaded from a
+// file. The GCP snapshot only holds the ranges where no company prefixes
+// are allocated: GS1's GCP length file is not redistributed with the
+// package. Load it for CompanyPrefix to resolve allocated prefixes, or put
+// it in data/ and run go generate to embed it.
+
+// currentGS1Prefixes returns the table set by the caller, or the snapshot
+func currentGS1Prefixes() *GS1Prefixes {
+	if t := gs1Prefixes.Load(); t != nil {
+		return t
+	}
+	return gs1PrefixesSnapshot
+}
+
+// currentGCPLengths returns the table set by the caller, or the snapshot
+func currentGCPLengths() *GCPLengths {
+	if t := gcpLengths.Load(); t != nil {
+		return t
+	}
+	return gcpLengthsSnapshot
+}
@@ -0,0 +1,24 @@
+package gtin
+
+import (
+	"go/build"
+	"testing"
+)
+
+// TestTinyGoImports checks that TinyGo builds of the package don't import
+// encoding/json, reflect or OS dependent packages directly
+func TestTinyGoImports(t *testing.T) {
+
+	ctx := build.Default
+	ctx.BuildTags = []string{"tinygo"}
+	pkg, err := ctx.ImportDir(".", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, imp := range pkg.Imports {
+		switch imp {
+		case "encoding/json", "os", "reflect", "net/http", "embed", "log/slog":
+			t.Errorf("TinyGo build imports %s", imp)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package gtin
+
+import "slices"
+
+// Delta is the difference between two lists of GTINs, e.g. yesterday's and
+// today's feed. Each list holds GTIN-14s in ascending order.
+type Delta struct {
+	Added    []GTIN // In the new list only
+	Removed  []GTIN // In the old list only
+	Retained []GTIN // In both
+}
+
+// Diff compares two lists of GTINs. GTINs are compared as GTIN-14, so
+// 614141000012 and 00614141000012 are the same, and duplicates count
+// once. Zero values are skipped. The lists are compared as sorted
+// uint64s, see GTIN.Uint64, so two lists of a million GTINs take well
+// under a second.
+func Diff(old, new []GTIN) Delta {
+
+	a, b := NewSet(old...), NewSet(new...)
+	return Delta{
+		Added:    b.Difference(a).GTINs(),
+		Removed:  a.Difference(b).GTINs(),
+		Retained: a.Intersect(b).GTINs(),
+	}
+}
+
+// sortedKeys returns the distinct Uint64 values of the GTINs in ascending
+// order, leaving out zero values
+func sortedKeys(gts []GTIN) []uint64 {
+	keys := make([]uint64, 0, len(gts))
+	for _, gt := range gts {
+		if !gt.IsZero() {
+			keys = append(keys, gt.Uint64())
+		}
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// asGTIN14 returns the GTIN-14 of a Uint64 value
+func asGTIN14(n uint64) GTIN {
+	gt, _ := FromUint64(n, GTIN14)
+	return gt
+}
@@ -0,0 +1,41 @@
+package gtin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+
+	parse := func(codes ...string) []GTIN {
+		var gts []GTIN
+		for _, code := range codes {
+			gts = append(gts, MustParse(code))
+		}
+		return gts
+	}
+	strs := func(gts []GTIN) []string {
+		var s []string
+		for _, gt := range gts {
+			s = append(s, gt.String())
+		}
+		return s
+	}
+
+	old := parse("4006381333931", "614141000012", "96385074", "4006381333931")
+	new := append(parse("00614141000012", "10614141000019", "4006381333931"), GTIN{})
+	d := Diff(old, new)
+	if got := strs(d.Added); !reflect.DeepEqual(got, []string{"10614141000019"}) {
+		t.Errorf("added: got %v", got)
+	}
+	if got := strs(d.Removed); !reflect.DeepEqual(got, []string{"00000096385074"}) {
+		t.Errorf("removed: got %v", got)
+	}
+	if got := strs(d.Retained); !reflect.DeepEqual(got, []string{"00614141000012", "04006381333931"}) {
+		t.Errorf("retained: got %v", got)
+	}
+
+	if d := Diff(nil, old); len(d.Added) != 3 || len(d.Removed) != 0 || len(d.Retained) != 0 {
+		t.Errorf("got %+v", d)
+	}
+}
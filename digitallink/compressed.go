@@ -0,0 +1,353 @@
+package digitallink
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/ai"
+)
+
+// The compressed form of the GS1 Digital Link standard encodes the AIs and
+// values as a bit string, written in URI-safe base64 as one path segment.
+// Each AI is written as 4 bits per digit and followed by its value:
+//
+//	numeric, fixed length     the number in the bits needed for the length
+//	numeric, variable length  the length, then the number
+//	alphanumeric              a 3-bit encoding, the length, then the data
+//
+// Lengths take the bits needed for the maximum length of the AI. The bit
+// string is padded with zeros to whole base64 characters. The optimisation
+// codes, which stand for common sequences of AIs, and AIs of more than one
+// component, such as 8003, are not supported.
+
+// base64URL is the alphabet of compressed URIs, and of encoding safe64
+const base64URL = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// Encodings of alphanumeric values, by their 3-bit indicator
+var encodings = []struct {
+	alphabet string // Empty for numeric and 7-bit ASCII
+	bits     int    // Per character
+}{
+	{"", 0}, // Numeric
+	{"0123456789abcdef", 4},
+	{"0123456789ABCDEF", 4},
+	{base64URL, 6},
+	{"", 7}, // ASCII
+}
+
+// compound AIs have values of more than one component, each encoded on
+// its own
+var compound = map[string]bool{
+	"253": true, "255": true, "421": true, "423": true, "425": true,
+	"7003": true, "8001": true, "8003": true, "8006": true, "8008": true, "8026": true,
+}
+
+func isCompound(a string) bool {
+	return compound[a] || strings.HasPrefix(a, "391") || strings.HasPrefix(a, "393") || strings.HasPrefix(a, "703")
+}
+
+// CompressedURI returns the link in the compressed form under the given
+// base URI, or DefaultResolver if base is empty, e.g.
+// https://id.gs1.org/ARHKVAdpQg for GTIN 09780345418913
+func (l Link) CompressedURI(base string) (string, error) {
+
+	if base == "" {
+		base = DefaultResolver
+	}
+	elements := []ai.Element{{AI: AIGTIN, Value: l.GTIN.String()}}
+	for _, q := range [][2]string{{AICPV, l.CPV}, {AILot, l.Lot}, {AISerial, l.Serial}} {
+		if q[1] != "" {
+			elements = append(elements, ai.Element{AI: q[0], Value: q[1]})
+		}
+	}
+	ais := make([]string, 0, len(l.Attributes))
+	for a := range l.Attributes {
+		ais = append(ais, a)
+	}
+	sort.Strings(ais)
+	for _, a := range ais {
+		elements = append(elements, ai.Element{AI: a, Value: l.Attributes[a]})
+	}
+
+	var b bits
+	for _, e := range elements {
+		if err := b.element(e); err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(base, "/") + "/" + b.base64(), nil
+}
+
+// bits is a bit string, one byte per bit
+type bits []byte
+
+// add appends the n low bits of v
+func (b *bits) add(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, byte(v>>i&1))
+	}
+}
+
+// addNumber appends the digits as a number in the bits needed for their
+// length
+func (b *bits) addNumber(digits string) {
+	n, _ := new(big.Int).SetString(digits, 10)
+	size := numberBits(len(digits))
+	for i := size - 1; i >= 0; i-- {
+		*b = append(*b, byte(n.Bit(i)))
+	}
+}
+
+// numberBits returns the bits needed for numbers of n digits
+func numberBits(n int) int {
+	if n == 0 {
+		return 0
+	}
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	return max.Sub(max, big.NewInt(1)).BitLen()
+}
+
+// lengthBits returns the bits needed for lengths up to max
+func lengthBits(max int) int {
+	return big.NewInt(int64(max)).BitLen()
+}
+
+// element appends the AI and its value
+func (b *bits) element(e ai.Element) error {
+
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	if isCompound(e.AI) {
+		return fmt.Errorf("%w: AI %s has more than one component", ErrCompressed, e.AI)
+	}
+	for n := 0; n < len(e.AI); n++ {
+		b.add(uint64(e.AI[n]-'0'), 4)
+	}
+
+	min, max, numeric, _ := ai.Format(e.AI)
+	switch {
+	case numeric && min == max:
+		b.addNumber(e.Value)
+	case numeric:
+		b.add(uint64(len(e.Value)), lengthBits(max))
+		b.addNumber(e.Value)
+	default:
+		enc := encoding(e.Value)
+		b.add(uint64(enc), 3)
+		b.add(uint64(len(e.Value)), lengthBits(max))
+		switch {
+		case enc == 0:
+			b.addNumber(e.Value)
+		case encodings[enc].alphabet == "":
+			for n := 0; n < len(e.Value); n++ {
+				b.add(uint64(e.Value[n]), 7)
+			}
+		default:
+			for n := 0; n < len(e.Value); n++ {
+				c := strings.IndexByte(encodings[enc].alphabet, e.Value[n])
+				b.add(uint64(c), encodings[enc].bits)
+			}
+		}
+	}
+	return nil
+}
+
+// encoding returns the most compact encoding of the value
+func encoding(value string) int {
+	if strings.Trim(value, "0123456789") == "" {
+		return 0
+	}
+	for enc := 1; enc < len(encodings)-1; enc++ {
+		in := true
+		for n := 0; n < len(value) && in; n++ {
+			in = strings.IndexByte(encodings[enc].alphabet, value[n]) >= 0
+		}
+		if in {
+			return enc
+		}
+	}
+	return len(encodings) - 1
+}
+
+// base64 returns the bit string, padded with zeros, in URI-safe base64
+func (b bits) base64() string {
+	var s strings.Builder
+	for n := 0; n < len(b); n += 6 {
+		var c byte
+		for i := n; i < n+6; i++ {
+			c <<= 1
+			if i < len(b) {
+				c |= b[i]
+			}
+		}
+		s.WriteByte(base64URL[c])
+	}
+	return s.String()
+}
+
+// reader reads a bit string
+type reader struct {
+	b   bits
+	pos int
+}
+
+func (r *reader) read(n int) (uint64, error) {
+	if r.pos+n > len(r.b) {
+		return 0, fmt.Errorf("%w: truncated", ErrCompressed)
+	}
+	var v uint64
+	for ; n > 0; n-- {
+		v = v<<1 | uint64(r.b[r.pos])
+		r.pos++
+	}
+	return v, nil
+}
+
+// readNumber reads a number of the given digits
+func (r *reader) readNumber(digits int) (string, error) {
+	size := numberBits(digits)
+	if r.pos+size > len(r.b) {
+		return "", fmt.Errorf("%w: truncated", ErrCompressed)
+	}
+	n := new(big.Int)
+	for ; size > 0; size-- {
+		n.Lsh(n, 1)
+		n.SetBit(n, 0, uint(r.b[r.pos]))
+		r.pos++
+	}
+	s := n.String()
+	if len(s) > digits {
+		return "", fmt.Errorf("%w: %s has more than %d digits", ErrCompressed, s, digits)
+	}
+	return strings.Repeat("0", digits-len(s)) + s, nil
+}
+
+// element reads an AI and its value
+func (r *reader) element() (ai.Element, error) {
+
+	var e ai.Element
+	for len(e.AI) < 4 {
+		d, err := r.read(4)
+		if err != nil {
+			return e, err
+		}
+		if d > 9 {
+			return e, fmt.Errorf("%w: optimisation codes are not supported", ErrCompressed)
+		}
+		e.AI += string(byte('0' + d))
+		if len(e.AI) < 2 {
+			continue
+		}
+		if _, _, _, err := ai.Format(e.AI); err == nil {
+			break
+		}
+	}
+	min, max, numeric, err := ai.Format(e.AI)
+	if err != nil {
+		return e, fmt.Errorf("%w: %w", ErrCompressed, err)
+	}
+	if isCompound(e.AI) {
+		return e, fmt.Errorf("%w: AI %s has more than one component", ErrCompressed, e.AI)
+	}
+
+	switch {
+	case numeric && min == max:
+		e.Value, err = r.readNumber(max)
+	case numeric:
+		var n uint64
+		if n, err = r.read(lengthBits(max)); err == nil {
+			e.Value, err = r.readNumber(int(n))
+		}
+	default:
+		var enc, n uint64
+		if enc, err = r.read(3); err != nil {
+			return e, err
+		}
+		if n, err = r.read(lengthBits(max)); err != nil {
+			return e, err
+		}
+		switch {
+		case enc == 0:
+			e.Value, err = r.readNumber(int(n))
+		case enc >= uint64(len(encodings)):
+			err = fmt.Errorf("%w: encoding %d", ErrCompressed, enc)
+		default:
+			var s strings.Builder
+			for ; n > 0 && err == nil; n-- {
+				var c uint64
+				c, err = r.read(encodings[enc].bits)
+				if encodings[enc].alphabet == "" {
+					s.WriteByte(byte(c))
+				} else if int(c) < len(encodings[enc].alphabet) {
+					s.WriteByte(encodings[enc].alphabet[c])
+				}
+			}
+			e.Value = s.String()
+		}
+	}
+	if err != nil {
+		return e, err
+	}
+	if err := e.Validate(); err != nil {
+		return e, fmt.Errorf("%w: %w", ErrCompressed, err)
+	}
+	return e, nil
+}
+
+// decompress returns the elements of a compressed path segment
+func decompress(segment string) ([]ai.Element, error) {
+
+	var r reader
+	for n := 0; n < len(segment); n++ {
+		c := strings.IndexByte(base64URL, segment[n])
+		if c < 0 {
+			return nil, fmt.Errorf("%w: %q is not base64", ErrCompressed, segment[n])
+		}
+		r.b.add(uint64(c), 6)
+	}
+
+	// Fewer than 8 bits are padding
+	var elements []ai.Element
+	for len(r.b)-r.pos >= 8 {
+		e, err := r.element()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, e)
+	}
+	if len(elements) == 0 || elements[0].AI != AIGTIN {
+		return nil, fmt.Errorf("%w: no GTIN", ErrCompressed)
+	}
+	return elements, nil
+}
+
+// parseCompressed returns the link of the elements of a compressed URI
+func parseCompressed(elements []ai.Element) (Link, error) {
+
+	var l Link
+	var err error
+	for _, e := range elements {
+		switch e.AI {
+		case AIGTIN:
+			if l.GTIN, err = gtin.Parse(e.Value); err != nil {
+				return Link{}, err
+			}
+		case AICPV:
+			l.CPV = e.Value
+		case AILot:
+			l.Lot = e.Value
+		case AISerial:
+			l.Serial = e.Value
+		default:
+			if l.Attributes == nil {
+				l.Attributes = make(map[string]string)
+			}
+			l.Attributes[e.AI] = e.Value
+		}
+	}
+	return l, nil
+}
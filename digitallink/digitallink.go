@@ -0,0 +1,185 @@
+/*
+Package digitallink builds and parses GS1 Digital Link URIs, such as
+
+	https://id.gs1.org/01/09506000134352/10/ABC123?17=251231
+
+or in the compressed form, with the element strings binary encoded into a
+single path segment:
+
+	https://id.gs1.org/ARHKVAdpQg
+*/
+package digitallink
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// DefaultResolver is the base URI of the GS1 global resolver
+const DefaultResolver = "https://id.gs1.org"
+
+// Application Identifiers of the GTIN and its key qualifiers
+const (
+	AIGTIN   = "01"
+	AICPV    = "22" // Consumer product variant
+	AILot    = "10" // Batch or lot number
+	AISerial = "21" // Serial number
+)
+
+// Short names accepted in place of the numeric AIs when parsing
+var shortNames = map[string]string{
+	"gtin": AIGTIN,
+	"cpv":  AICPV,
+	"lot":  AILot,
+	"ser":  AISerial,
+}
+
+var (
+	// ErrSyntax is returned for URIs that are not GS1 Digital Links
+	ErrSyntax = errors.New("digitallink: not a GS1 Digital Link")
+
+	// ErrCompressed is returned for compressed URIs that don't decode, or
+	// use features of the compressed form this package doesn't support
+	ErrCompressed = fmt.Errorf("%w: invalid compressed form", ErrSyntax)
+)
+
+// Link is a GTIN with its key qualifiers and data attributes
+type Link struct {
+	GTIN       gtin.GTIN
+	CPV        string
+	Lot        string
+	Serial     string
+	Attributes map[string]string // Other AIs, by AI, e.g. "17" for the expiry date
+}
+
+// URI returns the link under the given base URI, or DefaultResolver if
+// base is empty. The GTIN is always written as 14 digits and qualifiers in
+// the order 22, 10, 21. Attributes are written as a query sorted by AI.
+func (l Link) URI(base string) string {
+
+	if base == "" {
+		base = DefaultResolver
+	}
+	var s strings.Builder
+	s.WriteString(strings.TrimSuffix(base, "/"))
+	s.WriteString("/" + AIGTIN + "/" + l.GTIN.String())
+	for _, q := range [][2]string{{AICPV, l.CPV}, {AILot, l.Lot}, {AISerial, l.Serial}} {
+		if q[1] != "" {
+			s.WriteString("/" + q[0] + "/" + url.PathEscape(q[1]))
+		}
+	}
+
+	if len(l.Attributes) > 0 {
+		ais := make([]string, 0, len(l.Attributes))
+		for ai := range l.Attributes {
+			ais = append(ais, ai)
+		}
+		sort.Strings(ais)
+		for n, ai := range ais {
+			if n == 0 {
+				s.WriteByte('?')
+			} else {
+				s.WriteByte('&')
+			}
+			s.WriteString(ai + "=" + url.QueryEscape(l.Attributes[ai]))
+		}
+	}
+	return s.String()
+}
+
+// Position of the GTIN and its qualifiers in the path
+var qualifierRank = map[string]int{AIGTIN: 0, AICPV: 1, AILot: 2, AISerial: 3}
+
+var numericAI = regexp.MustCompile(`^[0-9]{2,4}$`)
+
+// Parse returns the link of a Digital Link URI on any resolver, in the
+// uncompressed or compressed form. The GTIN must be valid; 8, 12 and 13
+// digit forms are accepted. Query parameters that are not AIs, such as
+// linkType, are ignored; see ParseQuery.
+func Parse(uri string) (Link, error) {
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return Link{}, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	start := -1
+	for n, seg := range segments {
+		if seg == AIGTIN || seg == "gtin" {
+			start = n
+			break
+		}
+	}
+	var l Link
+	if start < 0 {
+		// The compressed form, with no GTIN segment
+		elements, err := decompress(segments[len(segments)-1])
+		if err != nil {
+			return Link{}, err
+		}
+		if l, err = parseCompressed(elements); err != nil {
+			return Link{}, err
+		}
+		l.query(u)
+		return l, nil
+	}
+	segments = segments[start:]
+	if len(segments)%2 != 0 {
+		return Link{}, fmt.Errorf("%w: %q has an AI without value", ErrSyntax, uri)
+	}
+
+	last := -1
+	for n := 0; n < len(segments); n += 2 {
+		ai := segments[n]
+		if name, ok := shortNames[ai]; ok {
+			ai = name
+		}
+		value, err := url.PathUnescape(segments[n+1])
+		if err != nil {
+			return Link{}, fmt.Errorf("%w: %v", ErrSyntax, err)
+		}
+		rank, ok := qualifierRank[ai]
+		if !ok {
+			return Link{}, fmt.Errorf("%w: %s is not a GTIN qualifier", ErrSyntax, ai)
+		}
+		if rank <= last {
+			return Link{}, fmt.Errorf("%w: qualifiers must follow the GTIN in order 22, 10, 21", ErrSyntax)
+		}
+		last = rank
+		switch ai {
+		case AIGTIN:
+			if l.GTIN, err = gtin.Parse(value); err != nil {
+				return Link{}, err
+			}
+		case AICPV:
+			l.CPV = value
+		case AILot:
+			l.Lot = value
+		case AISerial:
+			l.Serial = value
+		}
+	}
+
+	l.query(u)
+	return l, nil
+}
+
+// query adds the AIs of the query of u to the attributes
+func (l *Link) query(u *url.URL) {
+	for ai, values := range u.Query() {
+		if !numericAI.MatchString(ai) || len(values) == 0 {
+			continue
+		}
+		if l.Attributes == nil {
+			l.Attributes = make(map[string]string)
+		}
+		l.Attributes[ai] = values[0]
+	}
+}
@@ -0,0 +1,117 @@
+package digitallink
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestURI(t *testing.T) {
+
+	tests := []struct {
+		link Link
+		base string
+		want string
+	}{
+		{Link{GTIN: gtin.MustParse("9506000134352")}, "",
+			"https://id.gs1.org/01/09506000134352"},
+		{Link{GTIN: gtin.MustParse("09506000134352"), Lot: "ABC 1/2", Serial: "12345"}, "https://example.com/",
+			"https://example.com/01/09506000134352/10/ABC%201%2F2/21/12345"},
+		{Link{GTIN: gtin.MustParse("614141000012"), CPV: "2A", Attributes: map[string]string{"17": "251231", "15": "250101"}}, "",
+			"https://id.gs1.org/01/00614141000012/22/2A?15=250101&17=251231"},
+	}
+
+	for _, test := range tests {
+		if got := test.link.URI(test.base); got != test.want {
+			t.Errorf("wanted %s, got %s", test.want, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+
+	l, err := Parse("https://example.com/shop/01/09506000134352/10/ABC%201%2F2/21/12345?17=251231&linkType=gs1:pip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.GTIN.String() != "09506000134352" || l.Lot != "ABC 1/2" || l.Serial != "12345" || l.CPV != "" {
+		t.Errorf("got %+v", l)
+	}
+	if len(l.Attributes) != 1 || l.Attributes["17"] != "251231" {
+		t.Errorf("wanted only attribute 17, got %v", l.Attributes)
+	}
+
+	l, err = Parse("https://id.gs1.org/gtin/9506000134352/lot/X1")
+	if err != nil || l.GTIN.String() != "09506000134352" || l.Lot != "X1" {
+		t.Errorf("short names: got %+v, %v", l, err)
+	}
+
+	// Round trip
+	uri := "https://id.gs1.org/01/00614141000012/22/2A/10/L1/21/S1?15=250101&17=251231"
+	if l, err = Parse(uri); err != nil || l.URI("") != uri {
+		t.Errorf("wanted %s, got %s, %v", uri, l.URI(""), err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+
+	tests := []struct {
+		uri  string
+		want error
+	}{
+		{"https://id.gs1.org/ARHKVAdp", ErrCompressed},
+		{"https://id.gs1.org/oAAAAAAAAA", ErrCompressed},
+		{"https://example.com/products/123", ErrSyntax},
+		{"https://id.gs1.org/01/09506000134352/10", ErrSyntax},
+		{"https://id.gs1.org/01/09506000134352/99/x", ErrSyntax},
+		{"https://id.gs1.org/01/09506000134352/21/S1/10/L1", ErrSyntax},
+		{"https://id.gs1.org/01/09506000134353", gtin.ErrCheckDigit},
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(test.uri); !errors.Is(err, test.want) {
+			t.Errorf("%s: wanted %v, got %v", test.uri, test.want, err)
+		}
+	}
+}
+
+func TestCompressed(t *testing.T) {
+
+	// The example of the GS1 Digital Link standard
+	l, err := Parse("https://id.gs1.org/ARHKVAdpQg")
+	if err != nil || l.GTIN.String() != "09780345418913" {
+		t.Errorf("got %+v, %v", l, err)
+	}
+	if uri, err := l.CompressedURI(""); err != nil || uri != "https://id.gs1.org/ARHKVAdpQg" {
+		t.Errorf("got %s, %v", uri, err)
+	}
+
+	// Every encoding of alphanumeric values, and variable numeric ones
+	links := []Link{
+		{GTIN: gtin.MustParse("9506000134352"), Lot: "123456", Serial: "abc0"},
+		{GTIN: gtin.MustParse("9506000134352"), CPV: "2A", Lot: "Ab-_9"},
+		{GTIN: gtin.MustParse("614141000012"), Serial: "S/N(1)", Attributes: map[string]string{"17": "251231", "30": "12", "3103": "000750"}},
+	}
+	for _, want := range links {
+		uri, err := want.CompressedURI("https://example.com/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Parse(uri + "?linkType=gs1:pip")
+		if err != nil || got.URI("") != want.URI("") {
+			t.Errorf("%s: wanted %s, got %s, %v", uri, want.URI(""), got.URI(""), err)
+		}
+	}
+
+	l = Link{GTIN: gtin.MustParse("9506000134352"), Attributes: map[string]string{"8003": "09506000134352X"}}
+	if _, err := l.CompressedURI(""); !errors.Is(err, ErrCompressed) {
+		t.Errorf("wanted ErrCompressed for a compound AI, got %v", err)
+	}
+
+	// A long serial in the last segment is still uncompressed
+	uri := "https://id.gs1.org/01/09506000134352/21/ABCDEFGHIJKLMNOPQRS"
+	if l, err := Parse(uri); err != nil || l.Serial != "ABCDEFGHIJKLMNOPQRS" {
+		t.Errorf("got %+v, %v", l, err)
+	}
+}
@@ -0,0 +1,180 @@
+package digitallink
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// LinkType is a link type of the GS1 Web Vocabulary in compact form, e.g.
+// gs1:pip, naming the kind of resource a resolver redirects to
+type LinkType string
+
+// Common link types
+const (
+	DefaultLink               LinkType = "gs1:defaultLink"
+	PIP                       LinkType = "gs1:pip" // Product information page
+	CertificationInfo         LinkType = "gs1:certificationInfo"
+	RecipeInfo                LinkType = "gs1:recipeInfo"
+	AllergenInfo              LinkType = "gs1:allergenInfo"
+	NutritionalInfo           LinkType = "gs1:nutritionalInfo"
+	Instructions              LinkType = "gs1:instructions"
+	SustainabilityInfo        LinkType = "gs1:sustainabilityInfo"
+	SafetyInfo                LinkType = "gs1:safetyInfo"
+	RecallStatus              LinkType = "gs1:recallStatus"
+	Promotion                 LinkType = "gs1:promotion"
+	RegisterProduct           LinkType = "gs1:registerProduct"
+	Review                    LinkType = "gs1:review"
+	Support                   LinkType = "gs1:support"
+	FAQs                      LinkType = "gs1:faqs"
+	RelatedVideo              LinkType = "gs1:relatedVideo"
+	MasterData                LinkType = "gs1:masterData"
+	Traceability              LinkType = "gs1:traceability"
+	EPCIS                     LinkType = "gs1:epcis"
+	SMPC                      LinkType = "gs1:smpc" // Summary of product characteristics
+	EPIL                      LinkType = "gs1:epil" // Electronic patient information leaflet
+	ProductSustainabilityInfo LinkType = "gs1:productSustainabilityInfo"
+
+	// LinkSet requests all links of a Digital Link as linkset JSON
+	LinkSet LinkType = "linkset"
+)
+
+// vocabulary is the namespace of the GS1 Web Vocabulary
+const vocabulary = "https://gs1.org/voc/"
+
+// Expand returns the link type as URI, as used by linksets, e.g.
+// https://gs1.org/voc/pip
+func (lt LinkType) Expand() string {
+	if name, ok := strings.CutPrefix(string(lt), "gs1:"); ok {
+		return vocabulary + name
+	}
+	return string(lt)
+}
+
+// compact returns the link type of a relation in a linkset
+func compact(relation string) LinkType {
+	if name, ok := strings.CutPrefix(relation, vocabulary); ok {
+		return LinkType("gs1:" + name)
+	}
+	return LinkType(relation)
+}
+
+// Query is a request to a resolver for the resource of a link type. The
+// zero LinkType requests the default link.
+type Query struct {
+	Link     Link
+	LinkType LinkType
+	Context  string // Resolver specific context, e.g. a market or user role
+}
+
+// URI returns the query under the given base URI, or DefaultResolver if
+// base is empty, with linkType and context following the attributes
+func (q Query) URI(base string) string {
+
+	uri := q.Link.URI(base)
+	for _, param := range [][2]string{{"linkType", string(q.LinkType)}, {"context", q.Context}} {
+		if param[1] == "" {
+			continue
+		}
+		if strings.Contains(uri, "?") {
+			uri += "&"
+		} else {
+			uri += "?"
+		}
+		uri += param[0] + "=" + strings.ReplaceAll(url.QueryEscape(param[1]), "%3A", ":")
+	}
+	return uri
+}
+
+// ParseQuery returns the query of a Digital Link URI, see Parse
+func ParseQuery(uri string) (Query, error) {
+	l, err := Parse(uri)
+	if err != nil {
+		return Query{}, err
+	}
+	u, _ := url.Parse(uri)
+	values := u.Query()
+	return Query{l, LinkType(values.Get("linkType")), values.Get("context")}, nil
+}
+
+// ErrLinkSet is returned for malformed linkset JSON
+var ErrLinkSet = errors.New("digitallink: invalid linkset")
+
+// Target is a resource a link points to
+type Target struct {
+	Href     string   `json:"href"`
+	Title    string   `json:"title,omitempty"`
+	Type     string   `json:"type,omitempty"` // Media type, e.g. text/html
+	HrefLang []string `json:"hreflang,omitempty"`
+	Context  []string `json:"context,omitempty"`
+}
+
+// Links are the links of one anchor, a Digital Link, in a linkset response
+type Links struct {
+	Anchor          string
+	ItemDescription string
+	Targets         map[LinkType][]Target // By link type, in compact form if in the GS1 Web Vocabulary
+}
+
+// ParseLinkSet parses a linkset JSON response (RFC 9264), as returned for
+// linkType=linkset
+func ParseLinkSet(data []byte) ([]Links, error) {
+
+	var doc struct {
+		LinkSet []map[string]json.RawMessage `json:"linkset"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkSet, err)
+	}
+	if doc.LinkSet == nil {
+		return nil, fmt.Errorf("%w: no linkset", ErrLinkSet)
+	}
+
+	sets := make([]Links, 0, len(doc.LinkSet))
+	for _, entry := range doc.LinkSet {
+		links := Links{Targets: make(map[LinkType][]Target)}
+		for key, value := range entry {
+			var err error
+			switch key {
+			case "anchor":
+				err = json.Unmarshal(value, &links.Anchor)
+			case "itemDescription":
+				err = json.Unmarshal(value, &links.ItemDescription)
+			default:
+				var targets []Target
+				if err = json.Unmarshal(value, &targets); err == nil {
+					links.Targets[compact(key)] = targets
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrLinkSet, key, err)
+			}
+		}
+		if links.Anchor == "" {
+			return nil, fmt.Errorf("%w: link without anchor", ErrLinkSet)
+		}
+		sets = append(sets, links)
+	}
+	return sets, nil
+}
+
+// Lookup returns the first target of the link type in the language, e.g.
+// "en" also matching "en-GB", or of any language if lang is empty or not
+// found
+func (l Links) Lookup(lt LinkType, lang string) (Target, bool) {
+	targets := l.Targets[lt]
+	for _, t := range targets {
+		for _, hl := range t.HrefLang {
+			primary, _, _ := strings.Cut(hl, "-")
+			if lang != "" && (strings.EqualFold(hl, lang) || strings.EqualFold(primary, lang)) {
+				return t, true
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return Target{}, false
+	}
+	return targets[0], true
+}
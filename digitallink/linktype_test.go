@@ -0,0 +1,88 @@
+package digitallink
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestQuery(t *testing.T) {
+
+	link := Link{GTIN: gtin.MustParse("9506000134352"), Lot: "L1", Attributes: map[string]string{"17": "251231"}}
+	tests := []struct {
+		query Query
+		want  string
+	}{
+		{Query{Link: link}, "https://id.gs1.org/01/09506000134352/10/L1?17=251231"},
+		{Query{Link: link, LinkType: PIP}, "https://id.gs1.org/01/09506000134352/10/L1?17=251231&linkType=gs1:pip"},
+		{Query{Link: Link{GTIN: link.GTIN}, LinkType: CertificationInfo, Context: "dk&se"},
+			"https://id.gs1.org/01/09506000134352?linkType=gs1:certificationInfo&context=dk%26se"},
+		{Query{Link: Link{GTIN: link.GTIN}, LinkType: LinkSet}, "https://id.gs1.org/01/09506000134352?linkType=linkset"},
+	}
+	for _, tt := range tests {
+		uri := tt.query.URI("")
+		if uri != tt.want {
+			t.Errorf("wanted %s, got %s", tt.want, uri)
+		}
+		if q, err := ParseQuery(uri); err != nil || q.URI("") != uri {
+			t.Errorf("%s: got %+v, %v", uri, q, err)
+		}
+	}
+
+	if q, err := ParseQuery("https://id.gs1.org/01/09506000134352?linkType=gs1%3ArecipeInfo"); err != nil || q.LinkType != RecipeInfo {
+		t.Errorf("got %+v, %v", q, err)
+	}
+	if _, err := ParseQuery("https://example.com/?linkType=gs1:pip"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("wanted ErrSyntax, got %v", err)
+	}
+	if PIP.Expand() != "https://gs1.org/voc/pip" || compact("https://gs1.org/voc/pip") != PIP || LinkType("describedby").Expand() != "describedby" {
+		t.Error("link types do not round trip")
+	}
+}
+
+const linkset = `{
+  "linkset": [{
+    "anchor": "https://id.gs1.org/01/09506000134352",
+    "itemDescription": "Dal Giardino Tomato Risotto",
+    "https://gs1.org/voc/defaultLink": [{"href": "https://example.com/risotto", "title": "Risotto"}],
+    "https://gs1.org/voc/pip": [
+      {"href": "https://example.com/sv/risotto", "title": "Risotto", "hreflang": ["sv"], "type": "text/html"},
+      {"href": "https://example.com/en/risotto", "title": "Risotto", "hreflang": ["en-GB"], "type": "text/html"}
+    ],
+    "describedby": [{"href": "https://example.com/risotto.json", "type": "application/json"}]
+  }]
+}`
+
+func TestParseLinkSet(t *testing.T) {
+
+	sets, err := ParseLinkSet([]byte(linkset))
+	if err != nil || len(sets) != 1 {
+		t.Fatalf("got %v, %v", sets, err)
+	}
+	links := sets[0]
+	if links.Anchor != "https://id.gs1.org/01/09506000134352" || links.ItemDescription != "Dal Giardino Tomato Risotto" || len(links.Targets) != 3 {
+		t.Errorf("got %+v", links)
+	}
+	if target, ok := links.Lookup(DefaultLink, ""); !ok || target.Href != "https://example.com/risotto" {
+		t.Errorf("got %+v", target)
+	}
+	if target, ok := links.Lookup(PIP, "en"); !ok || target.Href != "https://example.com/en/risotto" {
+		t.Errorf("got %+v", target)
+	}
+	if target, ok := links.Lookup(PIP, "fi"); !ok || target.Href != "https://example.com/sv/risotto" {
+		t.Errorf("got %+v", target)
+	}
+	if _, ok := links.Lookup(RecipeInfo, ""); ok {
+		t.Error("wanted no recipe")
+	}
+	if target, ok := links.Lookup("describedby", ""); !ok || target.Type != "application/json" {
+		t.Errorf("got %+v", target)
+	}
+
+	for _, data := range []string{`[]`, `{}`, `{"linkset": [{"https://gs1.org/voc/pip": []}]}`, `{"linkset": [{"anchor": 1}]}`} {
+		if _, err := ParseLinkSet([]byte(data)); !errors.Is(err, ErrLinkSet) {
+			t.Errorf("%s: wanted ErrLinkSet, got %v", data, err)
+		}
+	}
+}
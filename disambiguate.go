@@ -0,0 +1,93 @@
+package gtin
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Interpretation is a possible original of an ambiguous code, see
+// Disambiguate
+type Interpretation struct {
+	GTIN       GTIN
+	Type       Type    // The type the code most likely had
+	Reason     string  // How the code was derived from it
+	Confidence float64 // From 0 to 1, adding up to 1 over all interpretations
+}
+
+// Weights of the evidence for an interpretation. A given check digit that
+// is valid is strong evidence, as 1 in 10 random codes has one.
+const (
+	evidenceCheckDigit  = 8.0
+	evidenceDropped     = 1.0
+	evidenceKnownPrefix = 2.0
+	evidenceRestricted  = 0.5
+)
+
+// Disambiguate returns the likely originals of a bare 12 or 13 digit code
+// of unknown type, such as a feed column that lost its leading zeros or
+// its check digit, the most likely first:
+//
+//   - 12 digits: a UPC-A, which is the same as an EAN-13 without its
+//     leading zero, or an EAN-13 without its check digit
+//   - 13 digits: an EAN-13, a UPC-A if it starts with 0, or a GTIN-14
+//     without its check digit
+//
+// Valid check digits, GS1 prefixes of known member organizations and
+// prefixes legal in open trade make an interpretation more likely.
+func Disambiguate(code string) ([]Interpretation, error) {
+
+	if len(code) != 12 && len(code) != 13 {
+		return nil, fmt.Errorf("%w %d, wanted 12 or 13 digits", ErrLength, len(code))
+	}
+	for n := 0; n < len(code); n++ {
+		if !isDigit(code[n]) {
+			return nil, &PositionError{ErrDigit, code[n], n}
+		}
+	}
+
+	var found []Interpretation
+	var scores []float64
+	add := func(s string, typ Type, reason string, evidence float64) {
+		gt, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if _, err := gt.MemberOrganization(); err == nil {
+			evidence *= evidenceKnownPrefix
+		}
+		if !gt.Legal() {
+			evidence *= evidenceRestricted
+		}
+		found = append(found, Interpretation{GTIN: gt, Type: typ, Reason: reason})
+		scores = append(scores, evidence)
+	}
+
+	// The code as it is, with a valid check digit
+	switch {
+	case len(code) == 12:
+		add(code, GTIN12, "UPC-A", evidenceCheckDigit)
+	case code[0] == '0':
+		add(code, GTIN12, "UPC-A with a leading zero", evidenceCheckDigit)
+	default:
+		add(code, GTIN13, "EAN-13", evidenceCheckDigit)
+	}
+
+	// The code without its check digit
+	c, _ := ComputeCheckDigit(code)
+	dropped := fmt.Sprintf("%s%d", code, c)
+	if len(code) == 12 {
+		add(dropped, GTIN13, "EAN-13 without its check digit", evidenceDropped)
+	} else {
+		add(dropped, GTIN14, "GTIN-14 without its check digit", evidenceDropped)
+	}
+
+	total := 0.0
+	for _, s := range scores {
+		total += s
+	}
+	for n := range found {
+		found[n].Confidence = scores[n] / total
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Confidence > found[j].Confidence })
+	return found, nil
+}
@@ -0,0 +1,45 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDisambiguate(t *testing.T) {
+
+	tests := []struct {
+		code   string
+		gtin   string // Most likely
+		typ    Type
+		others int
+	}{
+		{"036000291452", "00036000291452", GTIN12, 1},
+		{"400638133393", "04006381333931", GTIN13, 0}, // Not a valid UPC-A
+		{"4006381333931", "04006381333931", GTIN13, 1},
+		{"0036000291452", "00036000291452", GTIN12, 1},
+		{"1400638133393", "14006381333938", GTIN14, 0},
+	}
+	for _, tt := range tests {
+		found, err := Disambiguate(tt.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != tt.others+1 || found[0].GTIN.String() != tt.gtin || found[0].Type != tt.typ {
+			t.Fatalf("%s: got %+v", tt.code, found)
+		}
+		total := 0.0
+		for _, in := range found {
+			total += in.Confidence
+		}
+		if total < 0.999 || total > 1.001 || (tt.others > 0 && found[0].Confidence < 0.8) {
+			t.Errorf("%s: confidences of %+v", tt.code, found)
+		}
+	}
+
+	if _, err := Disambiguate("96385074"); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+	if _, err := Disambiguate("40063813339x"); !errors.Is(err, ErrDigit) {
+		t.Errorf("wanted ErrDigit, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Application Identifiers of the GTIN and its instance qualifiers
+const (
+	AIGTIN         = "01"
+	AIBatchLot     = "10" // Batch or lot number
+	AISerialNumber = "21"
+)
+
+// maxQualifier is the longest serial or lot number
+const maxQualifier = 20
+
+// SGTIN is a serialized GTIN, identifying one instance of a trade item
+type SGTIN struct {
+	GTIN   GTIN
+	Serial string
+}
+
+// LGTIN is a GTIN with a batch or lot number, identifying a class of
+// trade item instances
+type LGTIN struct {
+	GTIN GTIN
+	Lot  string
+}
+
+// NewSGTIN returns the SGTIN of a GTIN and a serial number of 1 to 20
+// characters from GS1 character set 82
+func NewSGTIN(gt GTIN, serial string) (SGTIN, error) {
+	if err := checkQualifier(serial, "serial number"); err != nil {
+		return SGTIN{}, err
+	}
+	return SGTIN{gt, serial}, nil
+}
+
+// NewLGTIN returns the LGTIN of a GTIN and a lot number of 1 to 20
+// characters from GS1 character set 82
+func NewLGTIN(gt GTIN, lot string) (LGTIN, error) {
+	if err := checkQualifier(lot, "lot number"); err != nil {
+		return LGTIN{}, err
+	}
+	return LGTIN{gt, lot}, nil
+}
+
+func checkQualifier(s, name string) error {
+	if len(s) < 1 || len(s) > maxQualifier {
+		return fmt.Errorf("%w %d of %s", ErrLength, len(s), name)
+	}
+	for n := 0; n < len(s); n++ {
+		if strings.IndexByte(cset82, s[n]) < 0 {
+			return &PositionError{ErrCharacter, s[n], n}
+		}
+	}
+	return nil
+}
+
+// ElementString returns the SGTIN in human readable form, e.g.
+// (01)00614141123452(21)6789
+func (s SGTIN) ElementString() string {
+	return "(" + AIGTIN + ")" + s.GTIN.String() + "(" + AISerialNumber + ")" + s.Serial
+}
+
+// ElementString returns the LGTIN in human readable form, e.g.
+// (01)00614141123452(10)ABC
+func (l LGTIN) ElementString() string {
+	return "(" + AIGTIN + ")" + l.GTIN.String() + "(" + AIBatchLot + ")" + l.Lot
+}
+
+// EPCURI returns the EPC pure identity URI of the SGTIN, e.g.
+// urn:epc:id:sgtin:0614141.812345.6789. It needs the company prefix, see
+// GTIN.CompanyPrefix.
+func (s SGTIN) EPCURI() (string, error) {
+	cp, err := s.GTIN.CompanyPrefix()
+	if err != nil {
+		return "", err
+	}
+	return s.epcURI(cp), nil
+}
+
+func (s SGTIN) epcURI(cp string) string {
+	return "urn:epc:id:sgtin:" + cp + "." + epcReference(s.GTIN, cp) + "." + EscapeEPC(s.Serial)
+}
+
+// EPCClassURI returns the EPC class URI of the LGTIN, e.g.
+// urn:epc:class:lgtin:0614141.812345.ABC
+func (l LGTIN) EPCClassURI() (string, error) {
+	cp, err := l.GTIN.CompanyPrefix()
+	if err != nil {
+		return "", err
+	}
+	return l.epcClassURI(cp), nil
+}
+
+func (l LGTIN) epcClassURI(cp string) string {
+	return "urn:epc:class:lgtin:" + cp + "." + epcReference(l.GTIN, cp) + "." + EscapeEPC(l.Lot)
+}
+
+// EPCPattern returns the EPC pattern URI of all SGTINs of the GTIN, e.g.
+// urn:epc:idpat:sgtin:0614141.812345.*
+func (gt GTIN) EPCPattern() (string, error) {
+	cp, err := gt.CompanyPrefix()
+	if err != nil {
+		return "", err
+	}
+	return epcPattern(gt, cp), nil
+}
+
+func epcPattern(gt GTIN, cp string) string {
+	return "urn:epc:idpat:sgtin:" + cp + "." + epcReference(gt, cp) + ".*"
+}
+
+// epcReference returns the indicator digit followed by the item reference
+// of the GTIN with company prefix cp, as in EPC URIs
+func epcReference(gt GTIN, cp string) string {
+	digits := gt.String()
+	return digits[:1] + digits[1+len(cp):GTIN_LENGTH-1]
+}
+
+// epcEscapes are the characters escaped in EPC URIs
+var epcEscapes = strings.NewReplacer(`%`, "%25", `"`, "%22", `&`, "%26", `/`, "%2F", `<`, "%3C", `>`, "%3E", `?`, "%3F")
+
+// EscapeEPC escapes a serial or lot number for an EPC URI, as defined by
+// the EPC Tag Data Standard
+func EscapeEPC(s string) string {
+	return epcEscapes.Replace(s)
+}
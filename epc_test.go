@@ -0,0 +1,58 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSGTIN(t *testing.T) {
+
+	useTestGCPLengths(t)
+
+	// The example of the EPC Tag Data Standard
+	s, err := NewSGTIN(MustParse("80614141123458"), "6789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "(01)80614141123458(21)6789"; s.ElementString() != want {
+		t.Errorf("wanted %s, got %s", want, s.ElementString())
+	}
+	if uri, err := s.EPCURI(); err != nil || uri != "urn:epc:id:sgtin:0614141.812345.6789" {
+		t.Errorf("got %s, %v", uri, err)
+	}
+
+	s, _ = NewSGTIN(MustParse("80614141123458"), "A/B%1")
+	if uri, _ := s.EPCURI(); uri != "urn:epc:id:sgtin:0614141.812345.A%2FB%251" {
+		t.Errorf("got %s", uri)
+	}
+	if pattern, err := s.GTIN.EPCPattern(); err != nil || pattern != "urn:epc:idpat:sgtin:0614141.812345.*" {
+		t.Errorf("got %s, %v", pattern, err)
+	}
+
+	for _, serial := range []string{"", "123456789012345678901", "a b"} {
+		if _, err := NewSGTIN(s.GTIN, serial); !errors.Is(err, ErrLength) && !errors.Is(err, ErrCharacter) {
+			t.Errorf("%q: wanted an error, got %v", serial, err)
+		}
+	}
+	if _, err := (SGTIN{MustParse("2001234567893"), "1"}).EPCURI(); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("wanted ErrCompanyPrefix, got %v", err)
+	}
+}
+
+func TestLGTIN(t *testing.T) {
+
+	useTestGCPLengths(t)
+
+	l, err := NewLGTIN(MustParse("4006381333931"), "ABC-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "(01)04006381333931(10)ABC-1"; l.ElementString() != want {
+		t.Errorf("wanted %s, got %s", want, l.ElementString())
+	}
+	if uri, err := l.EPCClassURI(); err != nil || uri != "urn:epc:class:lgtin:4006381.033393.ABC-1" {
+		t.Errorf("got %s, %v", uri, err)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Package epcis builds the epcList and quantityList entries of EPCIS 2.0
+events, as JSON-LD fragments, from GTINs, SGTINs and LGTINs.
+
+EPCIS 2.0 accepts identifiers as EPC URIs, e.g.
+urn:epc:id:sgtin:0614141.812345.6789, or as GS1 Digital Link URIs, e.g.
+https://id.gs1.org/01/80614141123458/21/6789. A Builder writes either.
+EPC URIs need the company prefix of the GTIN, see gtin.LoadGCPLengths.
+*/
+package epcis
+
+import (
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/digitallink"
+)
+
+// QuantityElement is an entry of a quantityList
+type QuantityElement struct {
+	EPCClass string  `json:"epcClass"`
+	Quantity float64 `json:"quantity,omitempty"`
+	UOM      string  `json:"uom,omitempty"` // UN/ECE Rec 20 unit, e.g. KGM; empty for a count
+}
+
+// Builder writes identifiers as EPC URIs, or as Digital Link URIs if
+// DigitalLink is set
+type Builder struct {
+	DigitalLink bool
+	Resolver    string // Base of Digital Link URIs, digitallink.DefaultResolver if empty
+}
+
+// EPCList returns the epcList entries of the SGTINs
+func (b Builder) EPCList(sgtins ...gtin.SGTIN) ([]string, error) {
+
+	list := make([]string, 0, len(sgtins))
+	for _, s := range sgtins {
+		if b.DigitalLink {
+			list = append(list, digitallink.Link{GTIN: s.GTIN, Serial: s.Serial}.URI(b.Resolver))
+			continue
+		}
+		uri, err := s.EPCURI()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, uri)
+	}
+	return list, nil
+}
+
+// LotQuantity returns a quantityList entry of a quantity of a lot
+func (b Builder) LotQuantity(l gtin.LGTIN, quantity float64, uom string) (QuantityElement, error) {
+	if b.DigitalLink {
+		return QuantityElement{digitallink.Link{GTIN: l.GTIN, Lot: l.Lot}.URI(b.Resolver), quantity, uom}, nil
+	}
+	class, err := l.EPCClassURI()
+	if err != nil {
+		return QuantityElement{}, err
+	}
+	return QuantityElement{class, quantity, uom}, nil
+}
+
+// Quantity returns a quantityList entry of a quantity of a GTIN without
+// lot
+func (b Builder) Quantity(gt gtin.GTIN, quantity float64, uom string) (QuantityElement, error) {
+	if b.DigitalLink {
+		return QuantityElement{digitallink.Link{GTIN: gt}.URI(b.Resolver), quantity, uom}, nil
+	}
+	class, err := gt.EPCPattern()
+	if err != nil {
+		return QuantityElement{}, err
+	}
+	return QuantityElement{class, quantity, uom}, nil
+}
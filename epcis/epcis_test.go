@@ -0,0 +1,63 @@
+package epcis
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestMain(m *testing.M) {
+	if err := gtin.LoadGCPLengths("../testdata/gcpprefixformatlist.json"); err != nil {
+		panic(err)
+	}
+	m.Run()
+}
+
+func TestEPCList(t *testing.T) {
+
+	gt := gtin.MustParse("80614141123458")
+	a, _ := gtin.NewSGTIN(gt, "6789")
+	b, _ := gtin.NewSGTIN(gt, "6790")
+
+	list, err := Builder{}.EPCList(a, b)
+	want := []string{"urn:epc:id:sgtin:0614141.812345.6789", "urn:epc:id:sgtin:0614141.812345.6790"}
+	if err != nil || !reflect.DeepEqual(list, want) {
+		t.Errorf("wanted %v, got %v, %v", want, list, err)
+	}
+
+	list, err = Builder{DigitalLink: true}.EPCList(a)
+	if err != nil || list[0] != "https://id.gs1.org/01/80614141123458/21/6789" {
+		t.Errorf("got %v, %v", list, err)
+	}
+
+	unknown, _ := gtin.NewSGTIN(gtin.MustParse("2001234567893"), "1")
+	if _, err := (Builder{}).EPCList(unknown); err == nil {
+		t.Error("wanted an error without company prefix")
+	}
+}
+
+func TestQuantityList(t *testing.T) {
+
+	gt := gtin.MustParse("4006381333931")
+	lot, _ := gtin.NewLGTIN(gt, "998877")
+
+	q, err := Builder{}.LotQuantity(lot, 200, "KGM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(q)
+	if want := `{"epcClass":"urn:epc:class:lgtin:4006381.033393.998877","quantity":200,"uom":"KGM"}`; string(b) != want {
+		t.Errorf("wanted %s, got %s", want, b)
+	}
+
+	q, err = Builder{}.Quantity(gt, 12, "")
+	if err != nil || q.EPCClass != "urn:epc:idpat:sgtin:4006381.033393.*" {
+		t.Errorf("got %+v, %v", q, err)
+	}
+	q, _ = Builder{DigitalLink: true, Resolver: "https://example.com"}.LotQuantity(lot, 1, "")
+	if q.EPCClass != "https://example.com/01/04006381333931/10/998877" {
+		t.Errorf("got %+v", q)
+	}
+}
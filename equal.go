@@ -0,0 +1,18 @@
+package gtin
+
+// Equal returns true if a and b identify the same article, regardless of
+// the type they were created from. 614141000012, 0614141000012 and
+// 00614141000012 are all equal.
+func Equal(a, b GTIN) bool {
+	return a.digits == b.digits
+}
+
+// EqualString returns true if s converts to a GTIN equal to gt.
+// Strings that don't convert are never equal.
+func EqualString(gt GTIN, s string) bool {
+	other, err := Atog(s)
+	if err != nil {
+		return false
+	}
+	return Equal(gt, other)
+}
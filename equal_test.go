@@ -0,0 +1,30 @@
+package gtin
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+
+	gt := MustParse("614141000012")
+
+	tests := []struct {
+		got  string
+		want bool
+	}{
+		{"614141000012", true},
+		{"0614141000012", true},
+		{"00614141000012", true},
+		{"00614141000029", false},
+		{"0061414100001", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if EqualString(gt, tt.got) != tt.want {
+			t.Errorf("%v: wanted %v", tt.got, tt.want)
+		}
+	}
+
+	if !Equal(gt, MustParse("00614141000012")) {
+		t.Errorf("wanted equal")
+	}
+}
@@ -0,0 +1,94 @@
+package gtin
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error is a validation error with a stable, machine-readable code
+type Error struct {
+	code string
+	msg  string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Code returns the stable code of the error, e.g. GTIN_E001_LENGTH
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Coder is implemented by all errors of this package that carry a code
+type Coder interface {
+	error
+	Code() string
+}
+
+// Errors returned when parsing and validating. The codes never change
+// between versions; new errors get new codes.
+var (
+	ErrLength     error = &Error{"GTIN_E001_LENGTH", "invalid length"}
+	ErrDigit      error = &Error{"GTIN_E002_DIGIT", "invalid digit"}
+	ErrCheckDigit error = &Error{"GTIN_E003_CHECK_DIGIT", "invalid check digit"}
+	ErrType       error = &Error{"GTIN_E004_TYPE", "unknown type"}
+	ErrPadding    error = &Error{"GTIN_E005_PADDING", "invalid zero padding"}
+	ErrCarrier    error = &Error{"GTIN_E006_CARRIER", "no data carrier"}
+
+	ErrCompanyPrefix error = &Error{"GTIN_E007_COMPANY_PREFIX", "unknown company prefix"}
+	ErrPrefix        error = &Error{"GTIN_E008_PREFIX", "unknown GS1 prefix"}
+
+	// ErrNotConvertible is returned when a GTIN has no representation in
+	// the requested form
+	ErrNotConvertible error = &Error{"GTIN_E009_NOT_CONVERTIBLE", "not convertible"}
+
+	// ErrNotAllowed is returned by policy rules that restrict which GTINs
+	// are accepted
+	ErrNotAllowed error = &Error{"GTIN_E015_NOT_ALLOWED", "not allowed by policy"}
+
+	// ErrHierarchy is returned for packaging levels that don't fit their
+	// trade item hierarchy
+	ErrHierarchy error = &Error{"GTIN_E016_HIERARCHY", "invalid packaging hierarchy"}
+
+	// ErrExhausted is returned when all references of a prefix are used
+	ErrExhausted error = &Error{"GTIN_E017_EXHAUSTED", "references exhausted"}
+
+	// ErrCharacter is returned for characters outside the character set of
+	// an alphanumeric field
+	ErrCharacter error = &Error{"GTIN_E018_CHARACTER", "invalid character"}
+
+	// ErrRetired is returned for GTINs of retired trade items that must not
+	// be used again
+	ErrRetired error = &Error{"GTIN_E019_RETIRED", "retired GTIN"}
+
+	// ErrSymbology is returned for GTINs scanned from a symbology that must
+	// not carry them
+	ErrSymbology error = &Error{"GTIN_E020_SYMBOLOGY", "symbology not permitted"}
+)
+
+// PositionError is an invalid character in an input, e.g. a letter in a
+// GTIN. It matches its Err, ErrDigit or ErrCharacter, with errors.Is.
+type PositionError struct {
+	Err  error
+	Char byte
+	Pos  int // From 0
+}
+
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("%v %q at position %d", e.Err, e.Char, e.Pos)
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the code of the first error in err's tree that has
+// one, or an empty string
+func ErrorCode(err error) string {
+	var c Coder
+	if errors.As(err, &c) {
+		return c.Code()
+	}
+	return ""
+}
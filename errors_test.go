@@ -0,0 +1,42 @@
+package gtin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+
+	_, err := Atog("12a4")
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ErrLength, "GTIN_E001_LENGTH"},
+		{fmt.Errorf("%w at position 3", ErrDigit), "GTIN_E002_DIGIT"},
+		{ErrCheckDigit, "GTIN_E003_CHECK_DIGIT"},
+		{CouponPrefix05, "GTIN_E012_COUPON_PREFIX_05"},
+		{err, "GTIN_E001_LENGTH"},
+		{fmt.Errorf("other"), ""},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("wanted %v, got %v", tt.want, got)
+		}
+	}
+}
+
+func TestPositionError(t *testing.T) {
+
+	_, err := Atog("40063813339x1")
+	var pos *PositionError
+	if !errors.As(err, &pos) || pos.Pos != 11 || pos.Char != 'x' || !errors.Is(err, ErrDigit) {
+		t.Fatalf("got %#v", err)
+	}
+	if want := `invalid digit 'x' at position 11`; pos.Error() != want {
+		t.Errorf("wanted %q, got %q", want, pos.Error())
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Package extract finds GTINs in HTML product pages, for crawlers such as
+price comparison sites. It looks in
+
+	schema.org JSON-LD   <script type="application/ld+json">{"gtin13": "4006381333931"}</script>
+	schema.org microdata <span itemprop="gtin13">4006381333931</span>
+	Open Graph tags      <meta property="product:ean" content="4006381333931">
+	visible text         EAN: 4006381333931
+
+and returns the values that are valid GTINs, with where they were found.
+Pages are scanned with regular expressions rather than parsed, which is
+enough for the markup product pages use and tolerates broken HTML.
+*/
+package extract
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Source is the kind of markup a GTIN was found in
+type Source string
+
+// The sources
+const (
+	JSONLD    Source = "json-ld"
+	Microdata Source = "microdata"
+	OpenGraph Source = "opengraph"
+	Text      Source = "text"
+)
+
+// Match is a GTIN found in a page
+type Match struct {
+	GTIN     gtin.GTIN
+	Source   Source
+	Property string // The property or label, e.g. gtin13, product:ean or EAN
+	Offset   int    // Byte offset of the value in the page
+	Line     int    // Line of the value, starting at 1
+}
+
+// Properties holding GTINs in schema.org markup
+var schemaProperties = map[string]bool{"gtin": true, "gtin8": true, "gtin12": true, "gtin13": true, "gtin14": true}
+
+// Properties holding GTINs in Open Graph tags
+var ogProperties = map[string]bool{
+	"og:ean": true, "og:upc": true, "og:gtin": true,
+	"product:ean": true, "product:upc": true, "product:gtin": true, "product:isbn": true,
+}
+
+var (
+	tagPattern    = regexp.MustCompile(`<([a-zA-Z][a-zA-Z0-9]*)((?:[^>"']|"[^"]*"|'[^']*')*)>`)
+	attrPattern   = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	labelPattern  = regexp.MustCompile(`(?i)\b(EAN|GTIN|UPC)(?:-?(?:8|12|13|14))?(?:\s*(?:code|number|nr\.?|no\.?))?(?:\s|&nbsp;)*[:#](?:\s|&nbsp;)*([0-9](?:[0-9]|[ -][0-9]){6,17})`)
+	scriptPattern = regexp.MustCompile(`(?is)^.*?</script\s*>`)
+	stylePattern  = regexp.MustCompile(`(?is)^.*?</style\s*>`)
+)
+
+// HTML returns the GTINs of a page in the order they appear. Values that
+// are not valid GTINs are left out.
+func HTML(page []byte) []Match {
+
+	var matches []Match
+	add := func(value string, source Source, property string, offset int) {
+		value = strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(value))
+		if gt, err := gtin.Parse(value); err == nil {
+			line := 1 + bytes.Count(page[:offset], []byte("\n"))
+			matches = append(matches, Match{gt, source, property, offset, line})
+		}
+	}
+
+	text := 0 // Start of the text before the next tag
+	for _, loc := range tagPattern.FindAllSubmatchIndex(page, -1) {
+		if loc[0] < text {
+			continue // Inside a script or style element
+		}
+		texts(page[text:loc[0]], text, add)
+		text = loc[1]
+
+		name := strings.ToLower(string(page[loc[2]:loc[3]]))
+		attrs := attributes(page, loc[4], loc[5])
+
+		switch {
+		case name == "script" || name == "style":
+			end := scriptPattern
+			if name == "style" {
+				end = stylePattern
+			}
+			body := end.FindIndex(page[loc[1]:])
+			if body == nil {
+				return sorted(matches)
+			}
+			text = loc[1] + body[1]
+			if name == "script" && strings.EqualFold(attrs["type"].value, "application/ld+json") {
+				jsonLD(page, loc[1], text, add)
+			}
+		case schemaProperties[attrs["itemprop"].value]:
+			if content, ok := attrs["content"]; ok {
+				add(content.value, Microdata, attrs["itemprop"].value, content.offset)
+			} else if end := bytes.IndexByte(page[loc[1]:], '<'); end >= 0 {
+				add(string(page[loc[1]:loc[1]+end]), Microdata, attrs["itemprop"].value, loc[1])
+			}
+		case name == "meta":
+			property := attrs["property"].value
+			if property == "" {
+				property = attrs["name"].value
+			}
+			if content, ok := attrs["content"]; ok && ogProperties[strings.ToLower(property)] {
+				add(content.value, OpenGraph, strings.ToLower(property), content.offset)
+			}
+		}
+	}
+	texts(page[text:], text, add)
+	return sorted(matches)
+}
+
+// attribute is the value of an attribute and its offset in the page
+type attribute struct {
+	value  string
+	offset int
+}
+
+// attributes returns the attributes of the tag between start and end,
+// by lower case name
+func attributes(page []byte, start, end int) map[string]attribute {
+	attrs := make(map[string]attribute)
+	for _, loc := range attrPattern.FindAllSubmatchIndex(page[start:end], -1) {
+		from, to := start+loc[4], start+loc[5]
+		if page[from] == '"' || page[from] == '\'' {
+			from, to = from+1, to-1
+		}
+		attrs[strings.ToLower(string(page[start+loc[2]:start+loc[3]]))] = attribute{string(page[from:to]), from}
+	}
+	return attrs
+}
+
+// texts adds the labelled GTINs of the text at offset
+func texts(text []byte, offset int, add func(string, Source, string, int)) {
+	for _, loc := range labelPattern.FindAllSubmatchIndex(text, -1) {
+		add(string(text[loc[4]:loc[5]]), Text, strings.ToUpper(string(text[loc[2]:loc[3]])), offset+loc[4])
+	}
+}
+
+// jsonLD adds the GTINs of a JSON-LD script between start and end
+func jsonLD(page []byte, start, end int, add func(string, Source, string, int)) {
+
+	script := page[start:end]
+	script = script[:bytes.LastIndex(script, []byte("</"))]
+	d := json.NewDecoder(bytes.NewReader(script))
+	d.UseNumber()
+	var doc any
+	if d.Decode(&doc) != nil {
+		return
+	}
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case []any:
+			for _, e := range v {
+				walk(e)
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				var value string
+				switch s := v[key].(type) {
+				case string:
+					value = s
+				case json.Number:
+					value = s.String()
+				default:
+					walk(s)
+					continue
+				}
+				if schemaProperties[key] {
+					add(value, JSONLD, key, start+max(bytes.Index(script, []byte(value)), 0))
+				}
+			}
+		}
+	}
+	walk(doc)
+}
+
+// sorted returns the matches in page order
+func sorted(matches []Match) []Match {
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Offset < matches[j].Offset })
+	return matches
+}
@@ -0,0 +1,81 @@
+package extract
+
+import (
+	"testing"
+)
+
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta property="og:title" content="Pen">
+<meta property="product:ean" content="4006381333931">
+<meta name='og:upc' content=614141000012>
+<script type="application/ld+json">
+{"@context": "https://schema.org", "@type": "Product", "name": "Pen",
+ "offers": {"@type": "Offer", "gtin14": "10614141000019"}, "gtin13": 4006381333931}
+</script>
+<script>var ean = "EAN: 4006381333931";</script>
+<style>.ean:after { content: "EAN: 4006381333931" }</style>
+</head>
+<body>
+<div itemscope itemtype="https://schema.org/Product">
+  <span itemprop="gtin8">96385074</span>
+  <meta itemprop="gtin12" content="614141000012">
+  <span itemprop="gtin13">4006381333932</span>
+</div>
+<p>EAN:&nbsp;400 6381 333931, UPC-12 code: 614141-000012, EAN 4006381333931, gtin# 96385074</p>
+</body>
+</html>`
+
+func TestHTML(t *testing.T) {
+
+	want := []struct {
+		gtin     string
+		source   Source
+		property string
+		line     int
+	}{
+		{"04006381333931", OpenGraph, "product:ean", 5},
+		{"00614141000012", OpenGraph, "og:upc", 6},
+		{"10614141000019", JSONLD, "gtin14", 9},
+		{"04006381333931", JSONLD, "gtin13", 9},
+		{"00000096385074", Microdata, "gtin8", 16},
+		{"00614141000012", Microdata, "gtin12", 17},
+		{"04006381333931", Text, "EAN", 20},
+		{"00614141000012", Text, "UPC", 20},
+		{"00000096385074", Text, "GTIN", 20},
+	}
+
+	matches := HTML([]byte(page))
+	if len(matches) != len(want) {
+		t.Fatalf("wanted %d matches, got %+v", len(want), matches)
+	}
+	for n, m := range matches {
+		w := want[n]
+		if m.GTIN.String() != w.gtin || m.Source != w.source || m.Property != w.property || m.Line != w.line {
+			t.Errorf("%d: wanted %+v, got %+v", n, w, m)
+		}
+		if value := page[m.Offset : m.Offset+3]; value[0] < '0' || value[0] > '9' {
+			t.Errorf("%d: offset %d is not at the value: %q", n, m.Offset, value)
+		}
+	}
+}
+
+func TestHTMLBroken(t *testing.T) {
+
+	for _, page := range []string{
+		``,
+		`<p>EAN: 4006381333931`,
+		`<script type="application/ld+json">{"gtin13": "4006381333931"`,
+		`<script type="application/ld+json">{"gtin13": </script><p>EAN: 4006381333931</p>`,
+		`<meta property="product:ean" content="4006381333931"`,
+	} {
+		matches := HTML([]byte(page))
+		if len(matches) > 1 {
+			t.Errorf("%s: got %+v", page, matches)
+		}
+	}
+	if matches := HTML([]byte(`<p>EAN: 4006381333931`)); len(matches) != 1 {
+		t.Errorf("wanted text without tags, got %+v", matches)
+	}
+}
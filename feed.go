@@ -0,0 +1,134 @@
+package gtin
+
+import (
+	"sort"
+	"strings"
+)
+
+// FeedItem is a record of a product feed
+type FeedItem struct {
+	SKU   string
+	GTIN  string // As in the feed
+	Brand string
+	MPN   string // Manufacturer part number
+}
+
+// Issue is a data-quality problem of a feed item
+type Issue string
+
+// The issues a feed is scored on
+const (
+	IssueInvalid     Issue = "invalid GTIN"
+	IssuePlaceholder Issue = "placeholder GTIN"
+	IssueDuplicate   Issue = "GTIN shared by several SKUs"
+	IssueNoGTIN      Issue = "neither GTIN nor MPN"
+	IssueNoBrand     Issue = "no brand"
+)
+
+// issueWeights is the share of an item's score each issue deducts
+var issueWeights = map[Issue]float64{
+	IssueInvalid:     1,
+	IssuePlaceholder: 1,
+	IssueDuplicate:   0.5,
+	IssueNoGTIN:      0.5,
+	IssueNoBrand:     0.2,
+}
+
+// Deduction is the points one issue cost a feed, and the items that have it
+type Deduction struct {
+	Issue  Issue    `json:"issue"`
+	Points float64  `json:"points"`
+	SKUs   []string `json:"skus"`
+}
+
+// FeedScore is the data-quality score of a feed. The score is 100 for a
+// feed without issues and goes down to 0 as issues add up, each item
+// losing at most its share.
+type FeedScore struct {
+	Items      int         `json:"items"`
+	Score      float64     `json:"score"`
+	Deductions []Deduction `json:"deductions"` // Most points first
+}
+
+// ScoreFeed reads items until the channel is closed and scores the feed.
+// Each item has an equal share of 100 points. Invalid and placeholder GTINs,
+// such as all zeros or 1234567890128, cost the item's whole share, a GTIN
+// also listed for another SKU half of it, and so on, see the Issue
+// constants.
+func ScoreFeed(items <-chan FeedItem) FeedScore {
+
+	var (
+		count  int
+		issues = make(map[Issue][]string)
+		lost   = make(map[string]float64) // Share lost by SKU, up to 1
+		owners = make(map[uint64][]string)
+	)
+	deduct := func(sku string, issue Issue) {
+		issues[issue] = append(issues[issue], sku)
+		lost[sku] += issueWeights[issue]
+	}
+
+	for item := range items {
+		count++
+		code := strings.TrimSpace(item.GTIN)
+		switch gt, err := Parse(code); {
+		case code == "":
+			if strings.TrimSpace(item.MPN) == "" {
+				deduct(item.SKU, IssueNoGTIN)
+			}
+		case err != nil:
+			deduct(item.SKU, IssueInvalid)
+		case isPlaceholder(gt):
+			deduct(item.SKU, IssuePlaceholder)
+		default:
+			owners[gt.Uint64()] = append(owners[gt.Uint64()], item.SKU)
+		}
+		if strings.TrimSpace(item.Brand) == "" {
+			deduct(item.SKU, IssueNoBrand)
+		}
+	}
+
+	for _, skus := range owners {
+		if len(skus) > 1 {
+			for _, sku := range skus {
+				deduct(sku, IssueDuplicate)
+			}
+		}
+	}
+
+	score := FeedScore{Items: count, Score: 100, Deductions: []Deduction{}}
+	if count == 0 {
+		return score
+	}
+	share := 100 / float64(count)
+	var total float64
+	for _, l := range lost {
+		total += min(l, 1) * share
+	}
+	score.Score = max(100-total, 0)
+
+	for issue, skus := range issues {
+		sort.Strings(skus)
+		score.Deductions = append(score.Deductions, Deduction{issue, float64(len(skus)) * issueWeights[issue] * share, skus})
+	}
+	sort.Slice(score.Deductions, func(i, j int) bool {
+		a, b := score.Deductions[i], score.Deductions[j]
+		return a.Points > b.Points || a.Points == b.Points && a.Issue < b.Issue
+	})
+	return score
+}
+
+// isPlaceholder reports whether the payload of the GTIN is one repeated
+// digit, e.g. 0000000000000, or a run of consecutive digits, e.g.
+// 123456789012 of 1234567890128
+func isPlaceholder(gt GTIN) bool {
+
+	payload := gt.digits[GTIN_LENGTH-gt.typ.Len() : GTIN_LENGTH-1]
+	same, up, down := true, true, true
+	for n := 1; n < len(payload); n++ {
+		same = same && payload[n] == payload[0]
+		up = up && payload[n] == (payload[n-1]+1)%10
+		down = down && payload[n] == (payload[n-1]+9)%10
+	}
+	return same || up || down
+}
@@ -0,0 +1,59 @@
+package gtin
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestScoreFeed(t *testing.T) {
+
+	items := make(chan FeedItem)
+	go func() {
+		for _, item := range []FeedItem{
+			{SKU: "A", GTIN: "4006381333931", Brand: "Faber"},
+			{SKU: "B", GTIN: "4006381333932", Brand: "Faber"}, // Wrong check digit
+			{SKU: "C", GTIN: "0000000000000", Brand: "Acme"},  // Placeholder
+			{SKU: "D", GTIN: "1234567890128", Brand: "Acme"},  // Placeholder
+			{SKU: "E", GTIN: "614141000012", Brand: "Acme"},
+			{SKU: "F", GTIN: "00614141000012"}, // Duplicate of E, no brand
+			{SKU: "G", MPN: "X-100", Brand: "Acme"},
+			{SKU: "H", Brand: "Acme"}, // Neither GTIN nor MPN
+		} {
+			items <- item
+		}
+		close(items)
+	}()
+
+	score := ScoreFeed(items)
+	// Lost shares: B 1, C 1, D 1, E 0.5, F 0.7, H 0.5 = 4.7 of 8
+	if want := 100 - 4.7*12.5; score.Items != 8 || math.Abs(score.Score-want) > 1e-9 {
+		t.Errorf("wanted score %v of 8 items, got %v of %d", want, score.Score, score.Items)
+	}
+
+	got := make(map[Issue][]string)
+	for _, d := range score.Deductions {
+		got[d.Issue] = d.SKUs
+	}
+	want := map[Issue][]string{
+		IssuePlaceholder: {"C", "D"},
+		IssueInvalid:     {"B"},
+		IssueDuplicate:   {"E", "F"},
+		IssueNoGTIN:      {"H"},
+		IssueNoBrand:     {"F"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+	if score.Deductions[0].Issue != IssuePlaceholder || score.Deductions[0].Points != 25 {
+		t.Errorf("wanted placeholders first, got %+v", score.Deductions[0])
+	}
+}
+
+func TestScoreEmptyFeed(t *testing.T) {
+	items := make(chan FeedItem)
+	close(items)
+	if score := ScoreFeed(items); score.Score != 100 || score.Items != 0 {
+		t.Errorf("got %+v", score)
+	}
+}
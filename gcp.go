@@ -0,0 +1,62 @@
+package gtin
+
+import "sync/atomic"
+
+// GCPLengths maps GS1 prefixes to the length of the GS1 Company Prefixes
+// allocated under them, as published by GS1 in the GCP length file
+// https://www.gs1.org/standards/bc-epc-interop
+type GCPLengths struct {
+	Date     string
+	prefixes map[string]int
+	longest  int
+}
+
+// Lookup returns the GS1 Company Prefix length for a number in GTIN-13
+// format, or false if no prefix matches. A length of 0 means no company
+// prefixes are allocated under the matching GS1 prefix.
+func (t *GCPLengths) Lookup(digits string) (int, bool) {
+	for n := min(t.longest, len(digits)); n > 0; n-- {
+		if length, ok := t.prefixes[digits[:n]]; ok {
+			return length, true
+		}
+	}
+	return 0, false
+}
+
+// Len returns the number of prefixes in the table
+func (t *GCPLengths) Len() int {
+	return len(t.prefixes)
+}
+
+// gcpLengths is the table used by CompanyPrefix
+var gcpLengths atomic.Pointer[GCPLengths]
+
+// SetGCPLengths replaces the table used by CompanyPrefix. Setting nil
+// restores the embedded snapshot.
+func SetGCPLengths(t *GCPLengths) {
+	gcpLengths.Store(t)
+}
+
+// CompanyPrefix returns the GS1 Company Prefix of the GTIN in GTIN-13
+// format, e.g. 0614141 for 00614141000012. It needs a full GCP length
+// table, see LoadGCPLengths.
+func (gt GTIN) CompanyPrefix() (string, error) {
+	return companyPrefix(currentGCPLengths(), gt)
+}
+
+// companyPrefix returns the company prefix of the GTIN from the table
+func companyPrefix(t *GCPLengths, gt GTIN) (string, error) {
+
+	if gt.IsZero() || gt.MinimalType() == GTIN8 {
+		// GTIN-8s have GS1-8 prefixes, not company prefixes
+		return "", ErrCompanyPrefix
+	}
+
+	// Skip the indicator digit or padding zero
+	digits := gt.String()[1:]
+	length, ok := t.Lookup(digits)
+	if !ok || length == 0 {
+		return "", ErrCompanyPrefix
+	}
+	return digits[:length], nil
+}
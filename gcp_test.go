@@ -0,0 +1,37 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompanyPrefix(t *testing.T) {
+
+	if err := LoadGCPLengths("testdata/gcpprefixformatlist.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		got  string
+		want string
+		err  error
+	}{
+		{"614141000012", "0614141", nil},
+		{"50614141000994", "0614141", nil},
+		{"4006381333931", "4006381", nil},
+		{"7350053850019", "735005385", nil},
+		{"7310865004703", "7310865", nil},
+		{"2001234567893", "", ErrCompanyPrefix},
+		{"96385074", "", ErrCompanyPrefix},
+		{"5012345678900", "", ErrCompanyPrefix},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.got).CompanyPrefix()
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("%v: wanted %v %v, got %v %v", tt.got, tt.want, tt.err, got, err)
+		}
+	}
+}
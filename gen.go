@@ -0,0 +1,77 @@
+//go:build ignore
+
+// Gen writes data_tables.go, the embedded snapshots of the datasets in
+// data/ as Go tables, so the package needs no JSON parsing at run time.
+//
+//	go generate
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+)
+
+func main() {
+
+	var prefixes struct {
+		Date  string `json:"date"`
+		Entry []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+			Name string `json:"name"`
+		} `json:"entry"`
+	}
+	read("data/gs1prefixes.json", &prefixes)
+	sort.Slice(prefixes.Entry, func(i, j int) bool { return prefixes.Entry[i].From < prefixes.Entry[j].From })
+
+	var gcp struct {
+		List struct {
+			Date  string `json:"date"`
+			Entry []struct {
+				Prefix    string `json:"prefix"`
+				GCPLength int    `json:"gcpLength"`
+			} `json:"entry"`
+		} `json:"GCPPrefixFormatList"`
+	}
+	read("data/gcpprefixformatlist.json", &gcp)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen.go from data/; DO NOT EDIT.\n\npackage gtin\n\n")
+
+	fmt.Fprintf(&b, "var gs1PrefixesSnapshot = &GS1Prefixes{\nDate: %q,\nranges: []prefixRange{\n", prefixes.Date)
+	for _, e := range prefixes.Entry {
+		fmt.Fprintf(&b, "{%q, %q, %q},\n", e.From, e.To, e.Name)
+	}
+	fmt.Fprintf(&b, "},\n}\n\n")
+
+	longest := 0
+	fmt.Fprintf(&b, "var gcpLengthsSnapshot = &GCPLengths{\nDate: %q,\nprefixes: map[string]int{\n", gcp.List.Date)
+	for _, e := range gcp.List.Entry {
+		fmt.Fprintf(&b, "%q: %d,\n", e.Prefix, e.GCPLength)
+		longest = max(longest, len(e.Prefix))
+	}
+	fmt.Fprintf(&b, "},\nlongest: %d,\n}\n", longest)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("data_tables.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func read(path string, v any) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		log.Fatalf("%s: %v", path, err)
+	}
+}
@@ -0,0 +1,118 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+const GLN_LENGTH = 13
+
+// Application Identifiers of physical locations
+const (
+	AIPhysicalLocation = "414" // GLN of a physical location
+	AIGLNExtension     = "254" // Extension component of a GLN
+)
+
+// maxGLNExtension is the longest GLN extension component
+const maxGLNExtension = 20
+
+// cset82 is the GS1 AI encodable character set 82, used by the GLN
+// extension component
+const cset82 = "!\"%&'()*+,-./0123456789:;<=>?ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+// GLN is a Global Location Number, optionally with an extension component
+// identifying a sub-location such as a shelf or a dock door
+type GLN struct {
+	digits    [GLN_LENGTH]uint8
+	extension string
+}
+
+// ParseGLN parses a 13-digit GLN and verifies its check digit
+func ParseGLN(input string) (GLN, error) {
+
+	var g GLN
+	if len(input) != GLN_LENGTH {
+		return GLN{}, fmt.Errorf("%w %d", ErrLength, len(input))
+	}
+	for n := 0; n < GLN_LENGTH; n++ {
+		if input[n] < '0' || input[n] > '9' {
+			return GLN{}, &PositionError{ErrDigit, input[n], n}
+		}
+		g.digits[n] = input[n] - '0'
+	}
+	if (GS1Mod10{}).CheckDigit(g.digits[:GLN_LENGTH-1]) != g.digits[GLN_LENGTH-1] {
+		return GLN{}, ErrCheckDigit
+	}
+	return g, nil
+}
+
+// WithExtension returns the GLN with an extension component (AI 254) of 1
+// to 20 characters from GS1 character set 82
+func (g GLN) WithExtension(extension string) (GLN, error) {
+	if err := checkGLNExtension(extension); err != nil {
+		return GLN{}, err
+	}
+	g.extension = extension
+	return g, nil
+}
+
+func checkGLNExtension(extension string) error {
+	if len(extension) < 1 || len(extension) > maxGLNExtension {
+		return fmt.Errorf("%w %d of GLN extension", ErrLength, len(extension))
+	}
+	for n := 0; n < len(extension); n++ {
+		if strings.IndexByte(cset82, extension[n]) < 0 {
+			return &PositionError{ErrCharacter, extension[n], n}
+		}
+	}
+	return nil
+}
+
+// Extension returns the extension component, or an empty string
+func (g GLN) Extension() string {
+	return g.extension
+}
+
+// String returns the 13 digits of the GLN, without extension
+func (g GLN) String() string {
+	var b strings.Builder
+	for _, d := range g.digits {
+		b.WriteByte('0' + d)
+	}
+	return b.String()
+}
+
+// ElementString returns the GLN as a physical location (AI 414) with its
+// extension (AI 254), if any, in human readable form, e.g.
+// (414)0614141000005(254)DOCK-3
+func (g GLN) ElementString() string {
+	s := "(" + AIPhysicalLocation + ")" + g.String()
+	if g.extension != "" {
+		s += "(" + AIGLNExtension + ")" + g.extension
+	}
+	return s
+}
+
+// ParseGLNElementString parses a physical location element string with an
+// optional extension, as returned by ElementString
+func ParseGLNElementString(input string) (GLN, error) {
+
+	prefix := "(" + AIPhysicalLocation + ")"
+	if !strings.HasPrefix(input, prefix) || len(input) < len(prefix)+GLN_LENGTH {
+		return GLN{}, fmt.Errorf("%w: not a (%s) element string", ErrType, AIPhysicalLocation)
+	}
+	g, err := ParseGLN(input[len(prefix) : len(prefix)+GLN_LENGTH])
+	if err != nil {
+		return GLN{}, err
+	}
+
+	rest := input[len(prefix)+GLN_LENGTH:]
+	if rest == "" {
+		return g, nil
+	}
+	ext, ok := strings.CutPrefix(rest, "("+AIGLNExtension+")")
+	if !ok {
+		return GLN{}, fmt.Errorf("%w: unexpected %q", ErrType, rest)
+	}
+	return g.WithExtension(ext)
+}
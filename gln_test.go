@@ -0,0 +1,79 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGLN(t *testing.T) {
+
+	g, err := ParseGLN("0614141000005")
+	if err != nil || g.String() != "0614141000005" || g.Extension() != "" {
+		t.Errorf("got %s, %v", g, err)
+	}
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"061414100000", ErrLength},
+		{"06141410000a5", ErrDigit},
+		{"0614141000006", ErrCheckDigit},
+	}
+	for _, tt := range tests {
+		if _, err := ParseGLN(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.input, tt.want, err)
+		}
+	}
+}
+
+func TestGLNExtension(t *testing.T) {
+
+	g, err := mustParseGLN("0614141000005").WithExtension("DOCK-3/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Extension() != "DOCK-3/a" || g.String() != "0614141000005" {
+		t.Errorf("got %s %s", g, g.Extension())
+	}
+	want := "(414)0614141000005(254)DOCK-3/a"
+	if g.ElementString() != want {
+		t.Errorf("wanted %s, got %s", want, g.ElementString())
+	}
+	if parsed, err := ParseGLNElementString(want); err != nil || parsed != g {
+		t.Errorf("round trip: got %v, %v", parsed, err)
+	}
+	if s := mustParseGLN("0614141000005").ElementString(); s != "(414)0614141000005" {
+		t.Errorf("got %s", s)
+	}
+
+	tests := []struct {
+		extension string
+		want      error
+	}{
+		{"", ErrLength},
+		{"123456789012345678901", ErrLength},
+		{"DOCK 3", ErrCharacter},
+		{"DÖCK", ErrCharacter},
+	}
+	for _, tt := range tests {
+		if _, err := mustParseGLN("0614141000005").WithExtension(tt.extension); !errors.Is(err, tt.want) {
+			t.Errorf("%q: wanted %v, got %v", tt.extension, tt.want, err)
+		}
+	}
+
+	for _, input := range []string{"0614141000005", "(414)0614141000005(10)X", "(414)0614141000005(254)", "(414)0614141000006"} {
+		if _, err := ParseGLNElementString(input); err == nil {
+			t.Errorf("%s: wanted error", input)
+		}
+	}
+}
+
+// mustParseGLN is like ParseGLN but panics on invalid input, for tests
+func mustParseGLN(input string) GLN {
+	g, err := ParseGLN(input)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
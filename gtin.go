@@ -29,6 +29,11 @@ GTIN-14
 - Not barcodes
 - First digit indicates whether it's for packaging levels (1-8) or measures (9)
 - GS1 Prefix starts at second digit
+
+The package builds with TinyGo, e.g. for barcode scanner firmware. TinyGo
+builds leave out ParseGS1Prefixes, ParseGCPLengths and the Load functions,
+which need encoding/json and a file system, and use the built-in dataset
+snapshots. The lookup subpackages are not available with TinyGo.
 */
 package gtin
 
@@ -41,17 +46,25 @@ import (
 
 const GTIN_LENGTH = 14
 
+// GTIN is an immutable GTIN-14 value that remembers the type it was
+// created from. Use Atog, Parse or New to create one; the zero value is
+// not a valid GTIN.
 type GTIN struct {
-	Type   string
-	Digits [GTIN_LENGTH]uint8
+	typ       Type
+	digits    [GTIN_LENGTH]uint8
+	corrected bool
+	repaired  bool
 }
 
+// Type is the GTIN type, given by the number of significant digits
+type Type string
+
 // The different GTIN types
 const (
-	GTIN8  string = "GTIN-8"  // 8 digits
-	GTIN12 string = "GTIN-12" // 12 digits
-	GTIN13 string = "GTIN-13" // 13 digits
-	GTIN14 string = "GTIN-14" // 14 digits
+	GTIN8  Type = "GTIN-8"  // 8 digits
+	GTIN12 Type = "GTIN-12" // 12 digits
+	GTIN13 Type = "GTIN-13" // 13 digits
+	GTIN14 Type = "GTIN-14" // 14 digits
 )
 
 const (
@@ -62,107 +75,332 @@ const (
 	UNKNOWN string = "UNKNOWN"
 )
 
+// New returns a GTIN of the given type from zero-padded GTIN-14 digits.
+// It returns an error if a digit is not 0-9 or if digits are set outside
+// the type, but it does not check the check digit.
+func New(typ Type, digits [GTIN_LENGTH]uint8) (GTIN, error) {
+	gt := GTIN{typ: typ, digits: digits}
+	if err := checkStructure(gt); err != nil {
+		return GTIN{}, err
+	}
+	return gt, nil
+}
+
+// Type returns the type the GTIN was created from
+func (gt GTIN) Type() Type {
+	return gt.typ
+}
+
+// Digits returns a copy of the zero-padded GTIN-14 digits
+func (gt GTIN) Digits() [GTIN_LENGTH]uint8 {
+	return gt.digits
+}
+
+// At returns the digit at position i of the zero-padded GTIN-14.
+// It panics if i is out of range.
+func (gt GTIN) At(i int) uint8 {
+	return gt.digits[i]
+}
+
+// CheckDigit returns the last digit
+func (gt GTIN) CheckDigit() uint8 {
+	return gt.digits[GTIN_LENGTH-1]
+}
+
+// Corrected returns true if Parse replaced a wrong check digit,
+// see FixCheckDigit
+func (gt GTIN) Corrected() bool {
+	return gt.corrected
+}
+
+// Repaired returns true if Parse reconstructed the digits of a value
+// mangled by a spreadsheet, see RepairSpreadsheet
+func (gt GTIN) Repaired() bool {
+	return gt.repaired
+}
+
+// IsZero returns true for the zero value, which is not a GTIN
+func (gt GTIN) IsZero() bool {
+	return gt.typ == ""
+}
+
 // String returns GTIN-14 as a string
 func (gt GTIN) String() string {
 	var s strings.Builder
-	for _, m := range gt.Digits {
+	for _, m := range gt.digits {
+		if m == 10 {
+			// A check digit of 10, see ISBNMod11
+			s.WriteByte('X')
+			continue
+		}
 		s.WriteString(strconv.Itoa(int(m)))
 	}
 	return s.String()
 }
 
+// Short returns the GTIN without zero padding, as 8, 12, 13 or 14 digits
+// depending on MinimalType
+func (gt GTIN) Short() string {
+	return gt.String()[GTIN_LENGTH-typeLengths[gt.MinimalType()]:]
+}
+
 // checkCheckDigit returns an error if the checkdigit is not valid
 // https://www.gs1.org/services/how-calculate-check-digit-manually
 // https://www.gs1us.org/tools/check-digit-calculator
 func checkCheckDigit(gt GTIN) error {
-	var multpliers = [GTIN_LENGTH - 1]uint8{3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3}
-	var checksum int
-	for n, m := range multpliers {
-		checksum += int(gt.Digits[n] * m)
-	}
-	var checkdigit uint8
-	if (checksum % 10) == 0 {
-		// checksum is equal to a multiple of ten
-		checkdigit = 0
-	} else {
-		//subtract from the higher multiple of ten
-		checkdigit = uint8(int((checksum+10)/10)*10 - checksum)
+	if (GS1Mod10{}).CheckDigit(gt.digits[:GTIN_LENGTH-1]) != gt.digits[GTIN_LENGTH-1] {
+		return ErrCheckDigit
 	}
 
-	if checkdigit != gt.Digits[GTIN_LENGTH-1] {
-		return fmt.Errorf("invalid check digit")
+	return nil
+}
+
+// LegalityResult tells which GS1 prefix rule, if any, a GTIN breaks
+type LegalityResult int
+
+// The GS1 prefix rules
+const (
+	PrefixLegal          LegalityResult = iota // No rule fired
+	RestrictedPrefix                           // GS1 prefix 02, 04 or 2
+	CouponPrefix9899                           // GS1 prefix 98-99
+	CouponPrefix05                             // GS1 prefix 05
+	GS18RestrictedPrefix                       // GS1-8 prefix 0 or 2
+	GS18ReservedPrefix                         // GS1-8 prefix 97-99
+)
+
+// String describes the rule
+func (r LegalityResult) String() string {
+	switch r {
+	case PrefixLegal:
+		return "legal GS1 prefix"
+	case RestrictedPrefix:
+		return "GS1 restricted prefix 02, 04 or 2"
+	case CouponPrefix9899:
+		return "GS1 coupon prefix 98-99"
+	case CouponPrefix05:
+		return "GS1 coupon prefix 05"
+	case GS18RestrictedPrefix:
+		return "GS1-8 restricted prefix 0 or 2"
+	case GS18ReservedPrefix:
+		return "GS1-8 reserved prefix 97-99"
 	}
+	return UNKNOWN
+}
 
-	return nil
+// Error makes a broken rule usable as an error
+func (r LegalityResult) Error() string {
+	return r.String()
+}
+
+// Code returns the stable code of the broken rule
+func (r LegalityResult) Code() string {
+	switch r {
+	case RestrictedPrefix:
+		return "GTIN_E010_RESTRICTED_PREFIX"
+	case CouponPrefix9899:
+		return "GTIN_E011_COUPON_PREFIX_98_99"
+	case CouponPrefix05:
+		return "GTIN_E012_COUPON_PREFIX_05"
+	case GS18RestrictedPrefix:
+		return "GTIN_E013_GS18_RESTRICTED_PREFIX"
+	case GS18ReservedPrefix:
+		return "GTIN_E014_GS18_RESERVED_PREFIX"
+	}
+	return ""
 }
 
-// isRestrictedPrefix returns true if the GS1 prefix is restricted or a coupon code
-func checkGS1Prefix(gt GTIN) error {
+// checkGS1Prefix returns the rule fired if the GS1 prefix is restricted or a coupon code
+func checkGS1Prefix(gt GTIN) LegalityResult {
 
-	if gt.Type != GTIN14 && gt.Type != GTIN13 {
+	switch gt.typ {
+	case GTIN8:
+		return checkGS18Prefix(gt)
+	case GTIN12, GTIN13, GTIN14:
+	default:
 		// Other GTIN types don't have GS1 prefixes
-		return nil
+		return PrefixLegal
 	}
 
-	var prefix int
-	if gt.Type == GTIN14 {
-		// In GTIN14, first digit is indicator
-		prefix = 1
-	}
+	// GS1 prefix starts after the padding zero in GTIN13 and after the
+	// indicator digit in GTIN14. A GTIN12 has the implied GS1 prefix 0
+	// followed by its number system digit.
+	prefix := 1
 
-	if gt.Digits[prefix] == 2 || (gt.Digits[prefix] == 0 && (gt.Digits[prefix+1] >= 2 && gt.Digits[prefix+1] <= 4)) {
+	if gt.digits[prefix] == 2 || (gt.digits[prefix] == 0 && (gt.digits[prefix+1] == 2 || gt.digits[prefix+1] == 4)) {
 		// Restricted prefixes 02, 04, or 2
-		return errors.New("GS1 restricted prefix 02, 04 or 2")
+		return RestrictedPrefix
 	}
-	if gt.Digits[prefix] == 9 && (gt.Digits[prefix+1] == 8 || gt.Digits[prefix+1] == 9) {
+	if gt.digits[prefix] == 9 && (gt.digits[prefix+1] == 8 || gt.digits[prefix+1] == 9) {
 		// Coupon prefixes 98-99
-		return errors.New("GS1 coupon prefix 98-99")
+		return CouponPrefix9899
 	}
-	if gt.Digits[prefix] == 0 && gt.Digits[prefix+1] == 5 {
+	if gt.digits[prefix] == 0 && gt.digits[prefix+1] == 5 {
 		// Coupon prefixes 05
-		return errors.New("GS1 coupon prefix 05")
+		return CouponPrefix05
 	}
-	return nil
+	return PrefixLegal
+}
+
+// checkGS18Prefix returns the rule fired if the GS1-8 prefix of a GTIN-8 is
+// restricted or not allocated by GS1 Global Office
+// https://www.gs1.org/standards/id-keys/company-prefix
+func checkGS18Prefix(gt GTIN) LegalityResult {
+
+	// GTIN8 is padded with six zeroes
+	prefix := GTIN_LENGTH - 8
+
+	if gt.digits[prefix] == 0 || gt.digits[prefix] == 2 {
+		// Velocity codes 0 and restricted circulation numbers 2
+		return GS18RestrictedPrefix
+	}
+	if gt.digits[prefix] == 9 && gt.digits[prefix+1] >= 7 {
+		// 970-999 are reserved by GS1 Global Office
+		return GS18ReservedPrefix
+	}
+	return PrefixLegal
 }
 
+// Valid returns true if the GTIN is well-formed and has a valid GS1
+// modulo-10 check digit, whatever scheme it was parsed with
 func (gt GTIN) Valid() bool {
-	return checkCheckDigit(gt) == nil
+	return checkStructure(gt) == nil && checkCheckDigit(gt) == nil
+}
+
+// Legality returns which GS1 prefix rule, if any, the GTIN breaks
+func (gt GTIN) Legality() LegalityResult {
+	return checkGS1Prefix(gt)
 }
 
+// Legal returns true if the GTIN breaks no GS1 prefix rule. Use a
+// PrefixRule to allow restricted ranges used internally.
 func (gt GTIN) Legal() bool {
-	return checkGS1Prefix(gt) == nil
+	return gt.Legality() == PrefixLegal
+}
+
+// The UPC-A number systems, given by the first digit of a GTIN-12
+const (
+	UPCRegular        string = "REGULAR"         // 0, 1, 6, 7, 8
+	UPCVariableWeight string = "VARIABLE-WEIGHT" // 2
+	UPCDrug           string = "DRUG"            // 3
+	UPCInStore        string = "IN-STORE"        // 4
+	UPCCoupon         string = "COUPON"          // 5
+	UPCReserved       string = "RESERVED"        // 9
+)
+
+// NumberSystem returns the UPC-A number system of a GTIN-12, or UNKNOWN
+// for other GTIN types
+func (gt GTIN) NumberSystem() string {
+
+	if gt.typ != GTIN12 {
+		return UNKNOWN
+	}
+
+	switch gt.digits[GTIN_LENGTH-12] {
+	case 0, 1, 6, 7, 8:
+		return UPCRegular
+	case 2:
+		return UPCVariableWeight
+	case 3:
+		return UPCDrug
+	case 4:
+		return UPCInStore
+	case 5:
+		return UPCCoupon
+	case 9:
+		return UPCReserved
+	}
+	return UNKNOWN
 }
 
-// Carrier returns data carrier of the GTIN
+// The contexts a GTIN can be scanned in
+const (
+	POS       string = "POS"        // Retail point-of-sale
+	TradeUnit string = "TRADE-UNIT" // General distribution, not crossing POS
+)
+
+// Carrier returns data carrier of the GTIN. GTIN-14s with a packaging
+// indicator are trade units, everything else is assumed to cross POS.
 func (gt GTIN) Carrier() string {
+	if gt.typ == GTIN14 && gt.digits[0] != 0 {
+		return gt.CarrierFor(TradeUnit)
+	}
+	return gt.CarrierFor(POS)
+}
+
+// CarrierFor returns the data carrier of the GTIN in the given context,
+// POS or TradeUnit, based on the parsed type:
+//
+//	Type                     POS                  TRADE-UNIT
+//	GTIN-8                   EAN-8                EAN-8
+//	GTIN-12                  UPC-A                UPC-A
+//	GTIN-13                  EAN-13               EAN-13
+//	GTIN-14, indicator 0     EAN-8/UPC-A/EAN-13   ITF-14
+//	GTIN-14, indicator 1-9   UNKNOWN              ITF-14
+//
+// A GTIN-14 with indicator 0 is a zero-padded GTIN-8, 12 or 13, and at POS
+// it is carried by the symbol of that type. Packaging levels and variable
+// measure items are never scanned at POS.
+func (gt GTIN) CarrierFor(context string) string {
+
+	switch gt.typ {
+	case GTIN8:
+		return EAN8
+	case GTIN12:
+		return UPCA
+	case GTIN13:
+		return EAN13
+	case GTIN14:
+		if context == TradeUnit {
+			return ITF14
+		}
+		if gt.digits[0] != 0 {
+			return UNKNOWN
+		}
+	default:
+		return UNKNOWN
+	}
+
+	switch gt.MinimalType() {
+	case GTIN13:
+		return EAN13
+	case GTIN12:
+		return UPCA
+	case GTIN8:
+		return EAN8
+	}
+	return UNKNOWN
+}
+
+// MinimalType returns the shortest type that can hold the GTIN without
+// losing digits, e.g. GTIN-12 for 00614141000029. A 13-digit form starting
+// with 00000 is a GTIN-8.
+func (gt GTIN) MinimalType() Type {
+
+	if gt.IsZero() {
+		return gt.typ
+	}
 
 	var zeroes int
-	for _, c := range gt.Digits {
+	for _, c := range gt.digits {
 		if c == 0 {
 			zeroes++
 		} else {
 			break
 		}
 	}
-	switch zeroes {
-	case 0:
-		return ITF14
-	case 1:
-		return EAN13
-	case 2:
-		return UPCA
-	case 3:
-		return UPCA
-	case 4:
-		return UPCA
-	case 6:
-		return EAN8
+	switch {
+	case zeroes == 0:
+		return GTIN14
+	case zeroes == 1:
+		return GTIN13
+	case zeroes < 6:
+		return GTIN12
 	}
-	return UNKNOWN
+	return GTIN8
 }
 
 // getGTINType returns the GTIN type based on length
-func getGTINType(input string) (string, error) {
+func getGTINType(input string) (Type, error) {
 	switch len(input) {
 	case 8:
 		return GTIN8, nil
@@ -173,14 +411,15 @@ func getGTINType(input string) (string, error) {
 	case 14:
 		return GTIN14, nil
 	default:
-		return "", fmt.Errorf("invalid length")
+		return "", fmt.Errorf("%w %d", ErrLength, len(input))
 	}
 }
 
 // Atog converts a string to GTIN-14
-// 1. Checks the GS1 prefix
+// 1. Checks the length
 // 2. Converts to 14 digits
-// 3. Checks check digit
+//
+// All problems found are returned together, joined with errors.Join.
 func Atog(input string) (GTIN, error) {
 
 	var (
@@ -188,35 +427,147 @@ func Atog(input string) (GTIN, error) {
 		ch   byte
 		pos  int
 		curr int
+		errs []error
 	)
 	var err error
 
 	// Type
-	gtin.Type, err = getGTINType(input)
+	gtin.typ, err = getGTINType(input)
 	if err != nil {
-		return gtin, err
+		errs = append(errs, err)
 	}
 
+	// With an invalid length the digits are checked but not kept
 	curr = GTIN_LENGTH - len(input)
 	for {
-		if pos >= len(input) || curr >= GTIN_LENGTH {
+		if pos >= len(input) {
 			break
 		}
 
+		var digit uint8
 		ch = input[pos]
 		if '0' <= ch && ch <= '9' {
-			gtin.Digits[curr] = ch - '0'
-		} else if ch == 'X' {
-			// Special case for ISBN
-			gtin.Digits[curr] = 10
+			digit = ch - '0'
 		} else {
 			// we only accept numbers
-			return gtin, fmt.Errorf("invalid digit")
+			errs = append(errs, &PositionError{ErrDigit, ch, pos})
+		}
+		if gtin.typ != "" {
+			gtin.digits[curr] = digit
 		}
 
 		pos++
 		curr++
 	}
 
+	return gtin, errors.Join(errs...)
+}
+
+// Option configures Parse
+type Option func(*options)
+
+type options struct {
+	scheme ChecksumScheme
+	fix    bool
+	repair bool
+}
+
+// WithChecksum selects the check digit algorithm used by Parse.
+// The default is GS1Mod10.
+func WithChecksum(scheme ChecksumScheme) Option {
+	return func(o *options) {
+		o.scheme = scheme
+	}
+}
+
+// FixCheckDigit makes Parse replace a wrong check digit with the correct
+// one instead of failing. The result is flagged as Corrected.
+func FixCheckDigit() Option {
+	return func(o *options) {
+		o.fix = true
+	}
+}
+
+// Parse converts a string to GTIN-14 like Atog and then verifies the
+// check digit with the selected ChecksumScheme. A check digit of 10 is
+// written as X, see ISBNMod11; such numbers are not GTINs to Valid.
+func Parse(input string, opts ...Option) (GTIN, error) {
+
+	o := options{scheme: GS1Mod10{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	repaired := false
+	if o.repair {
+		input, repaired = repairSpreadsheet(input)
+	}
+	var last byte
+	if len(input) > 0 {
+		last = input[len(input)-1]
+	}
+	x := last == 'X' || last == 'x'
+	if x {
+		input = input[:len(input)-1] + "0"
+	}
+	gtin, err := Atog(input)
+	gtin.repaired = repaired
+	if err != nil {
+		return gtin, err
+	}
+
+	// Only the significant digits take part in the check
+	digits := gtin.digits[GTIN_LENGTH-len(input):]
+	checkdigit := o.scheme.CheckDigit(digits[:len(digits)-1])
+	got := digits[len(digits)-1]
+	if x {
+		got = 10
+	}
+	if checkdigit != got {
+		if x && !o.fix {
+			// Only a scheme giving 10 takes an X
+			return gtin, &PositionError{ErrDigit, last, len(input) - 1}
+		}
+		if !o.fix || checkdigit > 9 {
+			return gtin, ErrCheckDigit
+		}
+		gtin.digits[GTIN_LENGTH-1] = checkdigit
+		gtin.corrected = true
+		return gtin, nil
+	}
+	gtin.digits[GTIN_LENGTH-1] = checkdigit
+
 	return gtin, nil
 }
+
+// ParseAny is like Parse for strings, byte slices and numbers, such as
+// numeric database columns. Numbers have lost their leading zeros, so they
+// are zero padded to the shortest GTIN length that fits: 8, 12, 13 or 14.
+func ParseAny[T string | []byte | uint64](v T, opts ...Option) (GTIN, error) {
+
+	switch v := any(v).(type) {
+	case string:
+		return Parse(v, opts...)
+	case []byte:
+		return Parse(string(v), opts...)
+	case uint64:
+		s := strconv.FormatUint(v, 10)
+		for _, length := range []int{8, 12, 13, 14} {
+			if len(s) <= length {
+				return Parse(strings.Repeat("0", length-len(s))+s, opts...)
+			}
+		}
+		return GTIN{}, fmt.Errorf("%w %d", ErrLength, len(s))
+	}
+	panic("unreachable")
+}
+
+// MustParse is like Parse but panics if the input is not a valid GTIN.
+// It simplifies the initialization of package variables and test tables.
+func MustParse(input string) GTIN {
+	gtin, err := Parse(input)
+	if err != nil {
+		panic(`gtin: Parse(` + strconv.Quote(input) + `): ` + err.Error())
+	}
+	return gtin
+}
@@ -1,7 +1,7 @@
 package gtin
 
 import (
-	"fmt"
+	"errors"
 	"testing"
 )
 
@@ -9,11 +9,11 @@ func TestIsValid(t *testing.T) {
 	tests := []struct {
 		got GTIN
 	}{
-		{GTIN{GTIN13, [GTIN_LENGTH]uint8{0, 9, 7, 8, 0, 6, 7, 0, 0, 2, 2, 1, 5, 1}}},
-		{GTIN{GTIN13, [GTIN_LENGTH]uint8{0, 0, 0, 4, 1, 2, 5, 0, 5, 0, 0, 7, 3, 5}}},
-		{GTIN{GTIN13, [GTIN_LENGTH]uint8{0, 9, 7, 8, 1, 9, 4, 9, 0, 0, 3, 7, 2, 7}}},
-		{GTIN{GTIN13, [GTIN_LENGTH]uint8{0, 9, 7, 8, 0, 5, 9, 3, 2, 9, 7, 0, 6, 3}}},
-		{GTIN{GTIN13, [GTIN_LENGTH]uint8{0, 9, 2, 9, 1, 0, 4, 1, 5, 0, 0, 2, 1, 0}}},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 9, 7, 8, 0, 6, 7, 0, 0, 2, 2, 1, 5, 1}}},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 0, 0, 4, 1, 2, 5, 0, 5, 0, 0, 7, 3, 5}}},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 9, 7, 8, 1, 9, 4, 9, 0, 0, 3, 7, 2, 7}}},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 9, 7, 8, 0, 5, 9, 3, 2, 9, 7, 0, 6, 3}}},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 9, 2, 9, 1, 0, 4, 1, 5, 0, 0, 2, 1, 0}}},
 	}
 
 	for _, tt := range tests {
@@ -41,15 +41,328 @@ func TestAtog(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
-		if tt.want != result.String() {
+		if tt.want != string(result.Type())+" "+result.String() {
 			t.Errorf("wanted %v, got %v", tt.want, result)
 		}
 	}
 }
 
-func TestGetCode(t *testing.T) {
+func TestCarrier(t *testing.T) {
 
-	c, _ := Atog("08719076050360")
-	fmt.Println(c.Carrier())
+	tests := []struct {
+		got       string
+		pos       string
+		tradeUnit string
+	}{
+		{"08719076050360", EAN13, ITF14},
+		{"00614141000029", UPCA, ITF14},
+		{"00000096385074", EAN8, ITF14},
+		{"50614141000994", UNKNOWN, ITF14},
+		{"0614141000012", EAN13, EAN13},
+		{"614141000012", UPCA, UPCA},
+		{"96385074", EAN8, EAN8},
+	}
+
+	for _, tt := range tests {
+		c, err := Atog(tt.got)
+		if err != nil {
+			t.Error(err)
+		}
+		if tt.pos != c.CarrierFor(POS) {
+			t.Errorf("%v: wanted %v, got %v", tt.got, tt.pos, c.CarrierFor(POS))
+		}
+		if tt.tradeUnit != c.CarrierFor(TradeUnit) {
+			t.Errorf("%v: wanted %v, got %v", tt.got, tt.tradeUnit, c.CarrierFor(TradeUnit))
+		}
+	}
+
+	c, _ := Atog("50614141000994")
+	if c.Carrier() != ITF14 {
+		t.Errorf("wanted %v, got %v", ITF14, c.Carrier())
+	}
+}
+
+func TestLegal(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want bool
+	}{
+		{"96385074", true},
+		{"614141000012", true},
+		{"312345678906", true},
+		{"212345678900", false},
+		{"412345678908", false},
+		{"512345678907", false},
+		{"01234565", false},
+		{"20123451", false},
+		{"97012349", false},
+		{"4006381333931", true},
+		{"2001234567893", false},
+		{"9876543210982", false},
+		{"0512345678900", false},
+		{"14006381333938", true},
+		{"10212345678901", false},
+	}
+
+	for _, tt := range tests {
+		result, err := Atog(tt.got)
+		if err != nil {
+			t.Error(err)
+		}
+		if tt.want != result.Legal() {
+			t.Errorf("%v: wanted %v, got %v", tt.got, tt.want, result.Legal())
+		}
+	}
+}
+
+func TestNumberSystem(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{"614141000012", UPCRegular},
+		{"212345678900", UPCVariableWeight},
+		{"312345678906", UPCDrug},
+		{"412345678908", UPCInStore},
+		{"512345678907", UPCCoupon},
+		{"4006381333931", UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		result, err := Atog(tt.got)
+		if err != nil {
+			t.Error(err)
+		}
+		if tt.want != result.NumberSystem() {
+			t.Errorf("%v: wanted %v, got %v", tt.got, tt.want, result.NumberSystem())
+		}
+	}
+}
+
+func TestLegality(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want LegalityResult
+	}{
+		{"4006381333931", PrefixLegal},
+		{"2001234567893", RestrictedPrefix},
+		{"0412345678903", RestrictedPrefix},
+		{"9876543210982", CouponPrefix9899},
+		{"0512345678900", CouponPrefix05},
+		{"01234565", GS18RestrictedPrefix},
+		{"97012349", GS18ReservedPrefix},
+	}
+
+	for _, tt := range tests {
+		result, err := Atog(tt.got)
+		if err != nil {
+			t.Error(err)
+		}
+		if tt.want != result.Legality() {
+			t.Errorf("%v: wanted %v, got %v", tt.got, tt.want, result.Legality())
+		}
+	}
+}
+
+func TestAtogErrors(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want []error
+	}{
+		{"12a4", []error{ErrLength, ErrDigit}},
+		{"12345", []error{ErrLength}},
+		{"4006381b3393a", []error{ErrDigit}},
+	}
+
+	for _, tt := range tests {
+		_, err := Atog(tt.got)
+		for _, want := range tt.want {
+			if !errors.Is(err, want) {
+				t.Errorf("%v: wanted %v, got %v", tt.got, want, err)
+			}
+		}
+	}
+
+	_, err := Atog("4006381b3393a")
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 2 {
+		t.Errorf("wanted 2 errors, got %d", n)
+	}
+}
+
+func TestNew(t *testing.T) {
+
+	tests := []struct {
+		typ    Type
+		digits [GTIN_LENGTH]uint8
+		err    error
+	}{
+		{GTIN13, [GTIN_LENGTH]uint8{0, 4, 0, 0, 6, 3, 8, 1, 3, 3, 3, 9, 3, 1}, nil},
+		{GTIN12, [GTIN_LENGTH]uint8{1, 0, 6, 1, 4, 1, 4, 1, 0, 0, 0, 0, 1, 2}, ErrPadding},
+		{GTIN13, [GTIN_LENGTH]uint8{0, 4, 0, 0, 6, 3, 8, 1, 3, 3, 3, 9, 3, 10}, ErrDigit},
+		{"GTIN-9", [GTIN_LENGTH]uint8{}, ErrType},
+	}
 
+	for _, tt := range tests {
+		gt, err := New(tt.typ, tt.digits)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("wanted %v, got %v", tt.err, err)
+		}
+		if err == nil && (gt.Type() != tt.typ || gt.Digits() != tt.digits) {
+			t.Errorf("wanted %v, got %v", tt.digits, gt)
+		}
+		if err != nil && !gt.IsZero() {
+			t.Errorf("wanted zero value, got %v", gt)
+		}
+	}
+
+	var zero GTIN
+	if zero.Valid() {
+		t.Errorf("zero value must not be valid")
+	}
+}
+
+func TestMustParse(t *testing.T) {
+
+	if got := MustParse("614141000012").String(); got != "00614141000012" {
+		t.Errorf("wanted 00614141000012, got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	MustParse("614141000013")
+}
+
+func TestParseAny(t *testing.T) {
+
+	tests := []struct {
+		got  GTIN
+		err  error
+		want string
+		typ  Type
+	}{
+		{must(ParseAny("614141000012")), nil, "00614141000012", GTIN12},
+		{must(ParseAny([]byte("4006381333931"))), nil, "04006381333931", GTIN13},
+		{must(ParseAny(uint64(614141000012))), nil, "00614141000012", GTIN12},
+		{must(ParseAny(uint64(96385074))), nil, "00000096385074", GTIN8},
+		{must(ParseAny(uint64(50614141000994))), nil, "50614141000994", GTIN14},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want || tt.got.Type() != tt.typ {
+			t.Errorf("wanted %s %s, got %s %s", tt.typ, tt.want, tt.got.Type(), tt.got)
+		}
+	}
+
+	// A 9 to 11 digit number is padded to a GTIN-12
+	if gt, err := ParseAny(uint64(1234567895)); err != nil || gt.Type() != GTIN12 {
+		t.Errorf("got %s %v, %v", gt.Type(), gt, err)
+	}
+	if _, err := ParseAny(uint64(123456789012345)); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+	if _, err := ParseAny([]byte("614141000013")); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+	if gt, err := ParseAny(uint64(614141000013), FixCheckDigit()); err != nil || !gt.Corrected() {
+		t.Errorf("wanted corrected GTIN, got %v, %v", gt, err)
+	}
+}
+
+func must(gt GTIN, err error) GTIN {
+	if err != nil {
+		panic(err)
+	}
+	return gt
+}
+
+func TestFixCheckDigit(t *testing.T) {
+
+	gt, err := Parse("614141000013", FixCheckDigit())
+	if err != nil {
+		t.Error(err)
+	}
+	if gt.String() != "00614141000012" || !gt.Corrected() {
+		t.Errorf("wanted corrected 00614141000012, got %v", gt)
+	}
+
+	gt, err = Parse("614141000012", FixCheckDigit())
+	if err != nil {
+		t.Error(err)
+	}
+	if gt.Corrected() {
+		t.Errorf("valid input must not be flagged as corrected")
+	}
+}
+
+func TestMinimalType(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want Type
+	}{
+		{"00614141000029", GTIN12},
+		{"50614141000994", GTIN14},
+		{"08719076050360", GTIN13},
+		{"00000096385074", GTIN8},
+		{"0614141000012", GTIN12},
+		{"00001234567895", GTIN12},
+		{"96385074", GTIN8},
+	}
+
+	for _, tt := range tests {
+		result, err := Atog(tt.got)
+		if err != nil {
+			t.Error(err)
+		}
+		if tt.want != result.MinimalType() {
+			t.Errorf("%v: wanted %v, got %v", tt.got, tt.want, result.MinimalType())
+		}
+	}
+}
+
+func TestShort(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{"00614141000029", "614141000029"},
+		{"50614141000994", "50614141000994"},
+		{"08719076050360", "8719076050360"},
+		{"00000096385074", "96385074"},
+		{"0614141000012", "614141000012"},
+	}
+
+	for _, tt := range tests {
+		result, err := Atog(tt.got)
+		if err != nil {
+			t.Error(err)
+		}
+		if tt.want != result.Short() {
+			t.Errorf("wanted %v, got %v", tt.want, result.Short())
+		}
+	}
+}
+
+func TestDigitAccessors(t *testing.T) {
+
+	gt := MustParse("50614141000994")
+	if gt.At(0) != 5 || gt.At(2) != 6 {
+		t.Errorf("wrong digit")
+	}
+	if gt.CheckDigit() != 4 {
+		t.Errorf("wanted 4, got %v", gt.CheckDigit())
+	}
+
+	digits := gt.Digits()
+	digits[0] = 9
+	if gt.At(0) != 5 {
+		t.Errorf("Digits must return a copy")
+	}
 }
@@ -0,0 +1,18 @@
+package gtin
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Hash64 returns a stable 64-bit FNV-1a hash of the GTIN, computed over
+// the big-endian bytes of Uint64(). The hash never changes between
+// versions and ignores the type, so 614141000012 and 00614141000012
+// land on the same shard.
+func (gt GTIN) Hash64() uint64 {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], gt.Uint64())
+	h := fnv.New64a()
+	h.Write(b[:])
+	return h.Sum64()
+}
@@ -0,0 +1,21 @@
+package gtin
+
+import "testing"
+
+func TestHash64(t *testing.T) {
+
+	a := MustParse("614141000012")
+	b := MustParse("00614141000012")
+	c := MustParse("00614141000029")
+
+	if a.Hash64() != b.Hash64() {
+		t.Errorf("padding must not change the hash")
+	}
+	if a.Hash64() == c.Hash64() {
+		t.Errorf("different GTINs should have different hashes")
+	}
+	// The hash is part of the API and must never change
+	if a.Hash64() != 0x2bf82e61c159818a {
+		t.Errorf("wanted %#x, got %#x", uint64(0x2bf82e61c159818a), a.Hash64())
+	}
+}
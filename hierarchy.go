@@ -0,0 +1,95 @@
+package gtin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Hierarchy is a trade item hierarchy, e.g. for GDSN feeds: a base unit
+// and the packaging levels holding it, such as cases and pallets. Each
+// level is a GTIN-14 with the company prefix and item reference of the
+// base unit and its own indicator digit 1-8.
+type Hierarchy struct {
+	Base   GTIN
+	Levels []GTIN
+}
+
+// Add adds a packaging level to the hierarchy, if it fits
+func (h *Hierarchy) Add(level GTIN) error {
+	if err := h.check(level); err != nil {
+		return err
+	}
+	for _, l := range h.Levels {
+		if l.digits[0] == level.digits[0] {
+			return fmt.Errorf("%w: indicator %d is already used by %s", ErrHierarchy, l.digits[0], l)
+		}
+	}
+	h.Levels = append(h.Levels, level)
+	sort.Slice(h.Levels, func(i, j int) bool { return h.Levels[i].digits[0] < h.Levels[j].digits[0] })
+	return nil
+}
+
+// Level returns the packaging level with the given indicator digit
+func (h *Hierarchy) Level(indicator uint8) (GTIN, bool) {
+	for _, l := range h.Levels {
+		if l.digits[0] == indicator {
+			return l, true
+		}
+	}
+	return GTIN{}, false
+}
+
+// Validate checks the base unit and that all levels share its company
+// prefix and item reference, with distinct indicators. It returns all
+// problems found, joined.
+func (h *Hierarchy) Validate() error {
+
+	if err := h.checkBase(); err != nil {
+		return err
+	}
+	var errs []error
+	seen := make(map[uint8]bool)
+	for _, l := range h.Levels {
+		if err := h.check(l); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if seen[l.digits[0]] {
+			errs = append(errs, fmt.Errorf("%w: indicator %d is used twice", ErrHierarchy, l.digits[0]))
+		}
+		seen[l.digits[0]] = true
+	}
+	return errors.Join(errs...)
+}
+
+// checkBase returns an error unless the base is a valid GTIN without
+// indicator
+func (h *Hierarchy) checkBase() error {
+	if !h.Base.Valid() {
+		return fmt.Errorf("%w: invalid base %s", ErrHierarchy, h.Base)
+	}
+	if h.Base.digits[0] != 0 {
+		return fmt.Errorf("%w: base %s has indicator %d", ErrHierarchy, h.Base, h.Base.digits[0])
+	}
+	return nil
+}
+
+// check returns an error unless level is a packaging level of the base
+func (h *Hierarchy) check(level GTIN) error {
+
+	if err := h.checkBase(); err != nil {
+		return err
+	}
+	if !level.Valid() {
+		return fmt.Errorf("%w: invalid level %s", ErrHierarchy, level)
+	}
+	if level.digits[0] < 1 || level.digits[0] > 8 {
+		return fmt.Errorf("%w: %s needs indicator 1-8, not %d", ErrHierarchy, level, level.digits[0])
+	}
+	// Digits between indicator and check digit
+	if [GTIN_LENGTH - 2]uint8(level.digits[1:GTIN_LENGTH-1]) != [GTIN_LENGTH - 2]uint8(h.Base.digits[1:GTIN_LENGTH-1]) {
+		return fmt.Errorf("%w: %s has another company prefix or item reference than %s", ErrHierarchy, level, h.Base)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHierarchy(t *testing.T) {
+
+	h := Hierarchy{Base: MustParse("4006381333931")}
+	for _, code := range []string{"54006381333936", "14006381333938"} {
+		if err := h.Add(MustParse(code)); err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+	}
+	if len(h.Levels) != 2 || h.Levels[0].String() != "14006381333938" {
+		t.Errorf("wanted levels sorted by indicator, got %v", h.Levels)
+	}
+	if l, ok := h.Level(5); !ok || l.String() != "54006381333936" {
+		t.Errorf("wrong level 5 %v", l)
+	}
+	if _, ok := h.Level(2); ok {
+		t.Error("wanted no level 2")
+	}
+	if err := h.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	for _, code := range []string{
+		"14006381333938", // Indicator used
+		"14006381333921", // Other item reference
+		"04006381333931", // No indicator
+		"94006381333934", // Variable measure
+		"10000096385071", // Other company
+		"14006381333939", // Check digit
+	} {
+		gt, _ := Atog(code)
+		if err := h.Add(gt); !errors.Is(err, ErrHierarchy) {
+			t.Errorf("%s: wanted ErrHierarchy, got %v", code, err)
+		}
+	}
+	if len(h.Levels) != 2 {
+		t.Errorf("wanted 2 levels, got %d", len(h.Levels))
+	}
+
+	// GTIN-8 base units
+	h = Hierarchy{Base: MustParse("96385074")}
+	if err := h.Add(MustParse("10000096385071")); err != nil {
+		t.Error(err)
+	}
+
+	// Validate reports all problems
+	h = Hierarchy{Base: MustParse("4006381333931"), Levels: []GTIN{
+		MustParse("14006381333938"), MustParse("14006381333938"), MustParse("10000096385071"),
+	}}
+	err := h.Validate()
+	if !errors.Is(err, ErrHierarchy) || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Errorf("wanted 2 errors, got %v", err)
+	}
+	h.Base = MustParse("14006381333938")
+	if err := h.Validate(); !errors.Is(err, ErrHierarchy) {
+		t.Errorf("wanted error for base with indicator, got %v", err)
+	}
+}
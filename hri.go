@@ -0,0 +1,53 @@
+package gtin
+
+import "strings"
+
+// group splits digits into space-separated groups of the given sizes
+func group(digits string, sizes ...int) string {
+	var s strings.Builder
+	for n, size := range sizes {
+		if n > 0 {
+			s.WriteByte(' ')
+		}
+		s.WriteString(digits[:size])
+		digits = digits[size:]
+	}
+	return s.String()
+}
+
+// HRI returns the human readable interpretation as printed below the
+// barcode of the GTIN's Carrier():
+//
+//	EAN-13   4 006381 333931
+//	UPC-A    6 14141 00001 2
+//	EAN-8    9638 5074
+//	ITF-14   50614141000994
+//
+// Values without a carrier are returned as 14 digits.
+func (gt GTIN) HRI() string {
+	return gt.formatHRI(false)
+}
+
+// HRIGrouped is like HRI but prints ITF-14 with four-group spacing,
+// 5 061414 100099 4.
+func (gt GTIN) HRIGrouped() string {
+	return gt.formatHRI(true)
+}
+
+func (gt GTIN) formatHRI(grouped bool) string {
+
+	s := gt.String()
+	switch gt.Carrier() {
+	case EAN13:
+		return group(s[1:], 1, 6, 6)
+	case UPCA:
+		return group(s[2:], 1, 5, 5, 1)
+	case EAN8:
+		return group(s[6:], 4, 4)
+	case ITF14:
+		if grouped {
+			return group(s, 1, 6, 6, 1)
+		}
+	}
+	return s
+}
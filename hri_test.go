@@ -0,0 +1,28 @@
+package gtin
+
+import "testing"
+
+func TestHRI(t *testing.T) {
+
+	tests := []struct {
+		got     string
+		want    string
+		grouped string
+	}{
+		{"4006381333931", "4 006381 333931", "4 006381 333931"},
+		{"614141000012", "6 14141 00001 2", "6 14141 00001 2"},
+		{"00614141000029", "6 14141 00002 9", "6 14141 00002 9"},
+		{"96385074", "9638 5074", "9638 5074"},
+		{"50614141000994", "50614141000994", "5 061414 100099 4"},
+	}
+
+	for _, tt := range tests {
+		gt := MustParse(tt.got)
+		if got := gt.HRI(); got != tt.want {
+			t.Errorf("wanted %v, got %v", tt.want, got)
+		}
+		if got := gt.HRIGrouped(); got != tt.grouped {
+			t.Errorf("wanted %v, got %v", tt.grouped, got)
+		}
+	}
+}
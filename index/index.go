@@ -0,0 +1,120 @@
+/*
+Package index implements a compact on-disk set of GTINs, for membership
+checks against billions of GTINs without a database.
+
+An index file holds the GTINs as sorted uint64s, see gtin.GTIN.Uint64, in
+blocks of up to BlockSize values. The first value of each block is kept in
+a skip index at the end of the file, the others are stored as uvarint
+deltas to their predecessor, which for dense numbering is one byte each.
+
+	magic       "GTINIDX1"
+	blocks      uvarint deltas, one block after the other
+	skip index  per block: first value, offset of the block (uint64 each)
+	footer      skip index offset, count (uint64), blocks, block size
+	            (uint32), magic
+
+All fixed-size numbers are big-endian. A Reader keeps the skip index in
+memory, 16 bytes per block, and reads one block per lookup.
+*/
+package index
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/peterstark72/gtin"
+)
+
+// DefaultBlockSize is the number of values per block if none is given
+const DefaultBlockSize = 1024
+
+const (
+	magic      = "GTINIDX1"
+	footerSize = 8 + 8 + 4 + 4 + 8 // Ends with the magic
+)
+
+var (
+	// ErrUnsorted is returned when GTINs are not added in ascending order
+	ErrUnsorted = errors.New("index: GTINs not in ascending order")
+
+	// ErrFormat is returned for files that are not valid indexes
+	ErrFormat = errors.New("index: invalid format")
+)
+
+// Writer writes an index. GTINs must be added in ascending order of their
+// Uint64 value; duplicates are skipped.
+type Writer struct {
+	w         *bufio.Writer
+	blockSize int
+	offset    uint64
+	count     uint64
+	last      uint64
+	skip      []uint64 // First value and offset of each block
+	err       error
+}
+
+// NewWriter returns a Writer with blocks of blockSize values, or
+// DefaultBlockSize if blockSize is less than 1
+func NewWriter(w io.Writer, blockSize int) *Writer {
+	if blockSize < 1 {
+		blockSize = DefaultBlockSize
+	}
+	iw := &Writer{w: bufio.NewWriter(w), blockSize: blockSize}
+	iw.write([]byte(magic))
+	return iw
+}
+
+// write writes b unless there was an error before
+func (w *Writer) write(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+		w.offset += uint64(len(b))
+	}
+}
+
+// Add adds a GTIN
+func (w *Writer) Add(gt gtin.GTIN) error {
+
+	if w.err != nil {
+		return w.err
+	}
+	n := gt.Uint64()
+	switch {
+	case w.count > 0 && n == w.last:
+		return nil
+	case w.count > 0 && n < w.last:
+		return ErrUnsorted
+	}
+
+	if w.count%uint64(w.blockSize) == 0 {
+		w.skip = append(w.skip, n, w.offset)
+	} else {
+		w.write(binary.AppendUvarint(nil, n-w.last))
+	}
+	w.last = n
+	w.count++
+	return w.err
+}
+
+// Close writes the skip index and footer and flushes. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+
+	skipOffset := w.offset
+	var b []byte
+	for _, v := range w.skip {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	b = binary.BigEndian.AppendUint64(b, skipOffset)
+	b = binary.BigEndian.AppendUint64(b, w.count)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(w.skip)/2))
+	b = binary.BigEndian.AppendUint32(b, uint32(w.blockSize))
+	b = append(b, magic...)
+	w.write(b)
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
@@ -0,0 +1,59 @@
+package index
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+// item returns the GTIN-13 of the item reference with the prefix 400638133
+func item(ref int) gtin.GTIN {
+	payload := fmt.Sprintf("400638133%03d", ref)
+	check, _ := gtin.ComputeCheckDigit(payload)
+	return gtin.MustParse(fmt.Sprintf("%s%d", payload, check))
+}
+
+// gtins returns n GTINs, every step item references apart
+func gtins(n, step int) []gtin.GTIN {
+	var gts []gtin.GTIN
+	for ref := 0; len(gts) < n; ref += step {
+		gts = append(gts, item(ref))
+	}
+	return gts
+}
+
+// build writes an index of gts
+func build(t *testing.T, gts []gtin.GTIN, blockSize int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf, blockSize)
+	for _, gt := range gts {
+		if err := w.Add(gt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriter(t *testing.T) {
+
+	gts := gtins(100, 1)
+	b := build(t, append(gts, gts[99]), 16) // The duplicate is skipped
+
+	// 100 values in 7 blocks, 93 one-byte deltas
+	if want := len(magic) + 93 + 7*16 + footerSize; len(b) != want {
+		t.Errorf("wanted %d bytes, got %d", want, len(b))
+	}
+
+	w := NewWriter(new(bytes.Buffer), 0)
+	w.Add(gts[1])
+	if err := w.Add(gts[0]); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("wanted ErrUnsorted, got %v", err)
+	}
+}
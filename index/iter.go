@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package index
+
+import (
+	"iter"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Between returns an iterator over the GTINs from from to to, inclusive,
+// see Range. A read error ends the iteration with a zero GTIN and the
+// error.
+//
+//	for gt, err := range r.Between(from, to) {
+func (r *Reader) Between(from, to gtin.GTIN) iter.Seq2[gtin.GTIN, error] {
+	return func(yield func(gtin.GTIN, error) bool) {
+		more := true
+		err := r.Range(from, to, func(gt gtin.GTIN) bool {
+			more = yield(gt, nil)
+			return more
+		})
+		if err != nil && more {
+			yield(gtin.GTIN{}, err)
+		}
+	}
+}
+
+// All returns an iterator over all GTINs of the index, see Between
+func (r *Reader) All() iter.Seq2[gtin.GTIN, error] {
+	return func(yield func(gtin.GTIN, error) bool) {
+		c := &cursor{r: r}
+		for {
+			n, ok, err := c.next()
+			if err != nil {
+				yield(gtin.GTIN{}, err)
+				return
+			}
+			if !ok {
+				return
+			}
+			gt, err := gtin.FromUint64(n, gtin.GTIN14)
+			if err != nil {
+				yield(gtin.GTIN{}, ErrFormat)
+				return
+			}
+			if !yield(gt, nil) {
+				return
+			}
+		}
+	}
+}
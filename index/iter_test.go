@@ -0,0 +1,84 @@
+//go:build go1.23
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestIterators(t *testing.T) {
+
+	gts := gtins(100, 2)
+	b := build(t, gts, 16)
+	r, err := NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := 0
+	for gt, err := range r.All() {
+		if err != nil || gt.Uint64() != gts[n].Uint64() {
+			t.Errorf("%d: got %s, %v", n, gt, err)
+		}
+		n++
+	}
+	if n != 100 {
+		t.Errorf("wanted 100 GTINs, got %d", n)
+	}
+
+	var got []gtin.GTIN
+	for gt, err := range r.Between(item(10), item(20)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, gt)
+		if len(got) == 3 {
+			break
+		}
+	}
+	if len(got) != 3 || got[0].Uint64() != item(10).Uint64() || got[2].Uint64() != item(14).Uint64() {
+		t.Errorf("got %v", got)
+	}
+
+	// Read errors end the iteration
+	f := &failingReader{ReaderAt: bytes.NewReader(b)}
+	r, _ = NewReader(f, int64(len(b)))
+	f.fail = true
+	var errs []error
+	for gt, err := range r.All() {
+		errs = append(errs, err)
+		if !gt.IsZero() {
+			t.Errorf("got %s", gt)
+		}
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errRead) {
+		t.Errorf("wanted one read error, got %v", errs)
+	}
+	errs = nil
+	for _, err := range r.Between(item(10), item(20)) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errRead) {
+		t.Errorf("wanted one read error, got %v", errs)
+	}
+}
+
+var errRead = errors.New("read failed")
+
+// failingReader fails once fail is set
+type failingReader struct {
+	io.ReaderAt
+	fail bool
+}
+
+func (f *failingReader) ReadAt(p []byte, off int64) (int, error) {
+	if f.fail {
+		return 0, errRead
+	}
+	return f.ReaderAt.ReadAt(p, off)
+}
@@ -0,0 +1,166 @@
+package index
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Reader queries an index
+type Reader struct {
+	r         io.ReaderAt
+	count     uint64
+	blockSize int
+	firsts    []uint64 // First value of each block
+	offsets   []uint64 // Offset of each block, and of the skip index
+	closer    io.Closer
+}
+
+// Open opens the index file at path
+func Open(path string) (*Reader, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r, err := NewReader(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r.closer = f
+	return r, nil
+}
+
+// NewReader reads the skip index of the index in r, which is size bytes
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+
+	if size < int64(len(magic)+footerSize) {
+		return nil, ErrFormat
+	}
+	footer := make([]byte, footerSize)
+	if _, err := r.ReadAt(footer, size-footerSize); err != nil {
+		return nil, err
+	}
+	if string(footer[footerSize-len(magic):]) != magic {
+		return nil, ErrFormat
+	}
+	skipOffset := binary.BigEndian.Uint64(footer)
+	blocks := uint64(binary.BigEndian.Uint32(footer[16:]))
+	ir := &Reader{
+		r:         r,
+		count:     binary.BigEndian.Uint64(footer[8:]),
+		blockSize: int(binary.BigEndian.Uint32(footer[20:])),
+	}
+	if skipOffset+16*blocks != uint64(size-footerSize) || ir.blockSize < 1 ||
+		(ir.count+uint64(ir.blockSize)-1)/uint64(ir.blockSize) != blocks {
+		return nil, ErrFormat
+	}
+
+	skip := make([]byte, 16*blocks)
+	if _, err := r.ReadAt(skip, int64(skipOffset)); err != nil {
+		return nil, err
+	}
+	ir.firsts = make([]uint64, blocks)
+	ir.offsets = make([]uint64, blocks+1)
+	for n := range ir.firsts {
+		ir.firsts[n] = binary.BigEndian.Uint64(skip[16*n:])
+		ir.offsets[n] = binary.BigEndian.Uint64(skip[16*n+8:])
+	}
+	ir.offsets[blocks] = skipOffset
+	return ir, nil
+}
+
+// Close closes the file of a Reader returned by Open
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Len returns the number of GTINs in the index
+func (r *Reader) Len() int {
+	return int(r.count)
+}
+
+// block returns the values of block n
+func (r *Reader) block(n int) ([]uint64, error) {
+
+	b := make([]byte, r.offsets[n+1]-r.offsets[n])
+	if _, err := r.r.ReadAt(b, int64(r.offsets[n])); err != nil {
+		return nil, err
+	}
+	values := make([]uint64, 1, r.blockSize)
+	values[0] = r.firsts[n]
+	for len(b) > 0 {
+		delta, k := binary.Uvarint(b)
+		if k <= 0 {
+			return nil, ErrFormat
+		}
+		values = append(values, values[len(values)-1]+delta)
+		b = b[k:]
+	}
+	return values, nil
+}
+
+// find returns the block that would hold n, or -1 if n is before the
+// first block
+func (r *Reader) find(n uint64) int {
+	return sort.Search(len(r.firsts), func(i int) bool { return r.firsts[i] > n }) - 1
+}
+
+// Contains reports whether the GTIN, in any of its forms, is in the index
+func (r *Reader) Contains(gt gtin.GTIN) (bool, error) {
+
+	n := gt.Uint64()
+	b := r.find(n)
+	if b < 0 {
+		return false, nil
+	}
+	values, err := r.block(b)
+	if err != nil {
+		return false, err
+	}
+	i := sort.Search(len(values), func(i int) bool { return values[i] >= n })
+	return i < len(values) && values[i] == n, nil
+}
+
+// Range calls fn for each GTIN in the index from from to to, inclusive,
+// in ascending order, until fn returns false. GTINs are returned as
+// GTIN-14s.
+func (r *Reader) Range(from, to gtin.GTIN, fn func(gtin.GTIN) bool) error {
+
+	lo, hi := from.Uint64(), to.Uint64()
+	for b := max(r.find(lo), 0); b < len(r.firsts) && r.firsts[b] <= hi; b++ {
+		values, err := r.block(b)
+		if err != nil {
+			return err
+		}
+		for _, n := range values {
+			if n < lo {
+				continue
+			}
+			if n > hi {
+				return nil
+			}
+			gt, err := gtin.FromUint64(n, gtin.GTIN14)
+			if err != nil {
+				return ErrFormat
+			}
+			if !fn(gt) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package index
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestReader(t *testing.T) {
+
+	gts := gtins(300, 3)
+	b := build(t, gts, 16)
+	r, err := NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 300 {
+		t.Errorf("wanted 300 GTINs, got %d", r.Len())
+	}
+
+	case14, _ := gtin.NewGTIN14(item(3), 1)
+	for _, test := range []struct {
+		gt   gtin.GTIN
+		want bool
+	}{
+		{item(0), true},                          // First
+		{item(51), true},                         // Not first of its block
+		{item(897), true},                        // Last
+		{gtin.MustParse(item(3).String()), true}, // Other form
+		{item(1), false},                         // Between
+		{gtin.MustParse("96385074"), false},      // Before
+		{item(900), false},                       // After
+		{case14, false},
+	} {
+		if ok, err := r.Contains(test.gt); ok != test.want || err != nil {
+			t.Errorf("%s: wanted %v, got %v, %v", test.gt, test.want, ok, err)
+		}
+	}
+
+	var got []gtin.GTIN
+	err = r.Range(item(45), item(51), func(gt gtin.GTIN) bool {
+		got = append(got, gt)
+		return true
+	})
+	if err != nil || len(got) != 3 || !gtin.Equal(got[0], item(45)) || !gtin.Equal(got[2], item(51)) {
+		t.Errorf("got %v, %v", got, err)
+	}
+
+	n := 0
+	r.Range(gtin.MustParse("96385074"), item(999), func(gtin.GTIN) bool {
+		n++
+		return n < 50
+	})
+	if n != 50 {
+		t.Errorf("wanted Range to stop after 50, got %d", n)
+	}
+}
+
+func TestOpen(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "gtins.idx")
+	os.WriteFile(path, build(t, nil, 0), 0o644)
+	r, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if ok, err := r.Contains(gtin.MustParse("4006381333931")); ok || err != nil || r.Len() != 0 {
+		t.Errorf("got %v, %v for an empty index", ok, err)
+	}
+
+	os.WriteFile(path, []byte("not an index, but long enough to have a footer"), 0o644)
+	if _, err := Open(path); !errors.Is(err, ErrFormat) {
+		t.Errorf("wanted ErrFormat, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package index
+
+import "github.com/peterstark72/gtin"
+
+// cursor reads the values of an index in order, one block at a time
+type cursor struct {
+	r      *Reader
+	block  int
+	values []uint64
+}
+
+// next returns the next value, or false at the end
+func (c *cursor) next() (uint64, bool, error) {
+	for len(c.values) == 0 {
+		if c.block == len(c.r.firsts) {
+			return 0, false, nil
+		}
+		values, err := c.r.block(c.block)
+		if err != nil {
+			return 0, false, err
+		}
+		c.values = values
+		c.block++
+	}
+	n := c.values[0]
+	c.values = c.values[1:]
+	return n, true, nil
+}
+
+// Union writes the GTINs in a or b to w
+func Union(w *Writer, a, b *Reader) error {
+	return merge(w, a, b, true, true, true)
+}
+
+// Intersect writes the GTINs in both a and b to w
+func Intersect(w *Writer, a, b *Reader) error {
+	return merge(w, a, b, false, true, false)
+}
+
+// Difference writes the GTINs in a but not in b to w
+func Difference(w *Writer, a, b *Reader) error {
+	return merge(w, a, b, true, false, false)
+}
+
+// merge streams the values of a and b to w in one pass, keeping the values
+// only in a, in both or only in b. Only one block of each index is in
+// memory at a time, so indexes of any size can be combined. The caller
+// closes w.
+func merge(w *Writer, a, b *Reader, onlyA, both, onlyB bool) error {
+
+	ca, cb := &cursor{r: a}, &cursor{r: b}
+	x, okA, err := ca.next()
+	if err != nil {
+		return err
+	}
+	y, okB, err := cb.next()
+	if err != nil {
+		return err
+	}
+
+	for okA || okB {
+		var n uint64
+		var keep bool
+		switch {
+		case !okB || okA && x < y:
+			n, keep = x, onlyA
+			x, okA, err = ca.next()
+		case !okA || y < x:
+			n, keep = y, onlyB
+			y, okB, err = cb.next()
+		default:
+			n, keep = x, both
+			if x, okA, err = ca.next(); err == nil {
+				y, okB, err = cb.next()
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if !keep {
+			continue
+		}
+		gt, err := gtin.FromUint64(n, gtin.GTIN14)
+		if err != nil {
+			return ErrFormat
+		}
+		if err := w.Add(gt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
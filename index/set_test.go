@@ -0,0 +1,70 @@
+package index
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestSetOperations(t *testing.T) {
+
+	open := func(gts []gtin.GTIN) *Reader {
+		b := build(t, gts, 16)
+		r, err := NewReader(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	evens, thirds := gtins(200, 2), gtins(150, 3)
+	a, b := open(evens), open(thirds)
+	set := gtin.NewSet(evens...)
+	other := gtin.NewSet(thirds...)
+
+	for _, test := range []struct {
+		name string
+		op   func(*Writer, *Reader, *Reader) error
+		want gtin.Set
+	}{
+		{"union", Union, set.Union(other)},
+		{"intersect", Intersect, set.Intersect(other)},
+		{"difference", Difference, set.Difference(other)},
+	} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, 8)
+		if err := test.op(w, a, b); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+		r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []gtin.GTIN
+		r.Range(item(0), item(999), func(gt gtin.GTIN) bool {
+			got = append(got, gt)
+			return true
+		})
+		if len(got) != test.want.Len() {
+			t.Errorf("%s: wanted %d GTINs, got %d", test.name, test.want.Len(), len(got))
+		}
+		for _, gt := range got {
+			if !test.want.Contains(gt) {
+				t.Errorf("%s: %s is not in the result", test.name, gt)
+			}
+		}
+	}
+
+	// Combining with an empty index
+	var buf bytes.Buffer
+	w := NewWriter(&buf, 0)
+	empty := open(nil)
+	if err := Union(w, empty, b); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	if r, _ := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len())); r.Len() != b.Len() {
+		t.Errorf("wanted %d GTINs, got %d", b.Len(), r.Len())
+	}
+}
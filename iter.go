@@ -0,0 +1,47 @@
+//go:build go1.23
+
+package gtin
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// Iterators need Go 1.23. Earlier versions have the callback APIs only:
+// Set.Each, Set.EachPrefix and ScanGTINs.
+
+// All returns an iterator over the GTINs in ascending order, as GTIN-14s:
+//
+//	for gt := range set.All() {
+func (s Set) All() iter.Seq[GTIN] {
+	return s.Each
+}
+
+// Prefix returns an iterator over the GTINs numbered from the company
+// prefix, see EachPrefix
+func (s Set) Prefix(p CompanyPrefix) iter.Seq[GTIN] {
+	return func(yield func(GTIN) bool) {
+		s.EachPrefix(p, yield)
+	}
+}
+
+// ScanResults returns an iterator over the candidate GTINs in r, see
+// ScanGTINs, parsed with the options. A read error ends the iteration with
+// a zero GTIN and the error.
+//
+//	for gt, err := range gtin.ScanResults(r) {
+func ScanResults(r io.Reader, opts ...Option) iter.Seq2[GTIN, error] {
+	return func(yield func(GTIN, error) bool) {
+		s := bufio.NewScanner(r)
+		s.Split(ScanGTINs)
+		for s.Scan() {
+			if !yield(Parse(s.Text(), opts...)) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(GTIN{}, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+//go:build go1.23
+
+package gtin
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSetAll(t *testing.T) {
+
+	s := NewSet(MustParse("4006381333931"), MustParse("614141000012"), MustParse("10614141000019"))
+	var got []string
+	for gt := range s.All() {
+		got = append(got, gt.String())
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := []string{"00614141000012", "04006381333931"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+
+	got = nil
+	for gt := range s.Prefix("0614141") {
+		got = append(got, gt.String())
+	}
+	if want := []string{"00614141000012", "10614141000019"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestScanResults(t *testing.T) {
+
+	var valid, invalid int
+	for _, err := range ScanResults(strings.NewReader("4006381333931, 4006381333932; x 614141000012")) {
+		if err != nil {
+			invalid++
+		} else {
+			valid++
+		}
+	}
+	if valid != 2 || invalid != 1 {
+		t.Errorf("got %d valid, %d invalid", valid, invalid)
+	}
+
+	var last error
+	for _, err := range ScanResults(iotest.ErrReader(errors.New("broken"))) {
+		last = err
+	}
+	if last == nil || last.Error() != "broken" {
+		t.Errorf("wanted the read error, got %v", last)
+	}
+}
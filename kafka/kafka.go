@@ -0,0 +1,102 @@
+/*
+Package kafka serializes GTIN message keys for Kafka in the compact 6-byte
+form of gtin.GTIN.Key, and validates them on consume.
+
+The package doesn't import a Kafka client. Serializer and Deserializer
+have the Serialize and Deserialize methods of confluent-kafka-go's serde
+package, Key implements sarama's Encoder, and Marshal and Unmarshal work
+with the []byte keys of segmentio/kafka-go.
+*/
+package kafka
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Marshal returns the key of the GTIN
+func Marshal(gt gtin.GTIN) []byte {
+	key := gt.Key()
+	return key[:]
+}
+
+// Unmarshal returns the GTIN-14 of a key, validated by policy, or by
+// gtin.DefaultPolicy() if policy is nil. Only errors of the policy fail.
+func Unmarshal(key []byte, policy *gtin.Policy) (gtin.GTIN, error) {
+
+	gt, err := gtin.FromKey(key)
+	if err != nil {
+		return gtin.GTIN{}, err
+	}
+	if policy == nil {
+		p := gtin.DefaultPolicy()
+		policy = &p
+	}
+	if report := policy.Validate(gt); !report.OK() {
+		return gtin.GTIN{}, errors.Join(report.Errors...)
+	}
+	return gt, nil
+}
+
+// Serializer serializes GTIN keys
+type Serializer struct{}
+
+// Serialize serializes a gtin.GTIN or *gtin.GTIN
+func (Serializer) Serialize(topic string, msg any) ([]byte, error) {
+	switch gt := msg.(type) {
+	case gtin.GTIN:
+		return Marshal(gt), nil
+	case *gtin.GTIN:
+		return Marshal(*gt), nil
+	}
+	return nil, fmt.Errorf("kafka: topic %s: can't serialize %T as a GTIN", topic, msg)
+}
+
+// Close does nothing
+func (Serializer) Close() {}
+
+// Deserializer deserializes and validates GTIN keys
+type Deserializer struct {
+	Policy *gtin.Policy // gtin.DefaultPolicy() if nil
+}
+
+// Deserialize returns the gtin.GTIN of the payload
+func (d Deserializer) Deserialize(topic string, payload []byte) (any, error) {
+	gt, err := Unmarshal(payload, d.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: topic %s: %w", topic, err)
+	}
+	return gt, nil
+}
+
+// DeserializeInto deserializes the payload into msg, a *gtin.GTIN
+func (d Deserializer) DeserializeInto(topic string, payload []byte, msg any) error {
+	p, ok := msg.(*gtin.GTIN)
+	if !ok {
+		return fmt.Errorf("kafka: topic %s: can't deserialize a GTIN into %T", topic, msg)
+	}
+	gt, err := Unmarshal(payload, d.Policy)
+	if err != nil {
+		return fmt.Errorf("kafka: topic %s: %w", topic, err)
+	}
+	*p = gt
+	return nil
+}
+
+// Close does nothing
+func (Deserializer) Close() {}
+
+// Key is a GTIN message key for producers that take an Encoder
+type Key gtin.GTIN
+
+// Encode returns the key of the GTIN
+func (k Key) Encode() ([]byte, error) {
+	return Marshal(gtin.GTIN(k)), nil
+}
+
+// Length returns the length of the encoded key
+func (Key) Length() int {
+	return gtin.KEY_SIZE
+}
@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestSerde(t *testing.T) {
+
+	gt := gtin.MustParse("4006381333931")
+	payload, err := Serializer{}.Serialize("orders", &gt)
+	if err != nil || len(payload) != gtin.KEY_SIZE {
+		t.Fatalf("got %x, %v", payload, err)
+	}
+
+	msg, err := Deserializer{}.Deserialize("orders", payload)
+	if err != nil || !gtin.Equal(msg.(gtin.GTIN), gt) {
+		t.Errorf("got %v, %v", msg, err)
+	}
+	var into gtin.GTIN
+	if err := (Deserializer{}).DeserializeInto("orders", payload, &into); err != nil || !gtin.Equal(into, gt) {
+		t.Errorf("got %v, %v", into, err)
+	}
+
+	if _, err := (Serializer{}).Serialize("orders", "4006381333931"); err == nil {
+		t.Error("wanted an error for a string")
+	}
+	if err := (Deserializer{}).DeserializeInto("orders", payload, new(string)); err == nil {
+		t.Error("wanted an error for a *string")
+	}
+}
+
+func TestValidateOnConsume(t *testing.T) {
+
+	wrong, _ := gtin.Atog("4006381333932")
+	if _, err := (Deserializer{}).Deserialize("orders", Marshal(wrong)); !errors.Is(err, gtin.ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+	if _, err := (Deserializer{}).Deserialize("orders", []byte{1}); !errors.Is(err, gtin.ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+
+	policy := gtin.DefaultPolicy()
+	policy.Errors = append(policy.Errors, gtin.AllowCompanyPrefixes("0614141"))
+	d := Deserializer{Policy: &policy}
+	if _, err := d.Deserialize("orders", Marshal(gtin.MustParse("4006381333931"))); !errors.Is(err, gtin.ErrNotAllowed) {
+		t.Errorf("wanted ErrNotAllowed, got %v", err)
+	}
+}
+
+func TestKey(t *testing.T) {
+
+	k := Key(gtin.MustParse("614141000012"))
+	b, err := k.Encode()
+	if err != nil || len(b) != k.Length() {
+		t.Errorf("got %x, %v", b, err)
+	}
+}
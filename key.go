@@ -0,0 +1,65 @@
+package gtin
+
+import "fmt"
+
+// KEY_SIZE is the number of bytes of a key. Every GTIN-14 is less than
+// 10^14 < 2^48, so it fits in 6 bytes.
+const KEY_SIZE = 6
+
+// Key returns the GTIN as a 6-byte big-endian number, for keys in
+// key-value stores such as LMDB or Badger. Keys sort bytewise in the
+// numeric order of the GTINs. Like Uint64, the type is not part of the key.
+func (gt GTIN) Key() [KEY_SIZE]byte {
+	var key [KEY_SIZE]byte
+	n := gt.Uint64()
+	for i := KEY_SIZE - 1; i >= 0; i-- {
+		key[i] = byte(n)
+		n >>= 8
+	}
+	return key
+}
+
+// FromKey returns the GTIN-14 of a key. It does not check the check digit.
+func FromKey(key []byte) (GTIN, error) {
+
+	if len(key) != KEY_SIZE {
+		return GTIN{}, fmt.Errorf("%w %d of key", ErrLength, len(key))
+	}
+	var n uint64
+	for _, b := range key {
+		n = n<<8 | uint64(b)
+	}
+	return FromUint64(n, GTIN14)
+}
+
+// AppendRecord appends a record of size bytes to dst: the key of the GTIN
+// followed by the payload, padded with zeros. Fixed-size records keep
+// values of key-value stores, or files of records, addressable by offset.
+// It returns ErrLength if the payload doesn't fit.
+func AppendRecord(dst []byte, gt GTIN, payload []byte, size int) ([]byte, error) {
+
+	if KEY_SIZE+len(payload) > size {
+		return dst, fmt.Errorf("%w %d of payload, at most %d", ErrLength, len(payload), size-KEY_SIZE)
+	}
+	key := gt.Key()
+	dst = append(dst, key[:]...)
+	dst = append(dst, payload...)
+	for n := KEY_SIZE + len(payload); n < size; n++ {
+		dst = append(dst, 0)
+	}
+	return dst, nil
+}
+
+// ParseRecord splits a record into its GTIN-14 and its payload, including
+// any padding. The payload shares memory with record.
+func ParseRecord(record []byte) (GTIN, []byte, error) {
+
+	if len(record) < KEY_SIZE {
+		return GTIN{}, nil, fmt.Errorf("%w %d of record", ErrLength, len(record))
+	}
+	gt, err := FromKey(record[:KEY_SIZE])
+	if err != nil {
+		return GTIN{}, nil, err
+	}
+	return gt, record[KEY_SIZE:], nil
+}
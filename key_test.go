@@ -0,0 +1,61 @@
+package gtin
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+
+	codes := []string{"4006381333931", "96385074", "10614141000019", "614141000012", "99999999999997"}
+	var keys [][]byte
+	for _, code := range codes {
+		gt := MustParse(code)
+		key := gt.Key()
+		back, err := FromKey(key[:])
+		if err != nil || !Equal(back, gt) || back.Type() != GTIN14 {
+			t.Errorf("%s: got %v, %v", code, back, err)
+		}
+		keys = append(keys, key[:])
+	}
+
+	// Keys sort like the numbers
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	var got []string
+	for _, key := range keys {
+		gt, _ := FromKey(key)
+		got = append(got, gt.String())
+	}
+	if !sort.StringsAreSorted(got) {
+		t.Errorf("keys out of order: %v", got)
+	}
+
+	if _, err := FromKey([]byte{1, 2, 3}); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+	if _, err := FromKey([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength for 2^48-1, got %v", err)
+	}
+}
+
+func TestRecord(t *testing.T) {
+
+	gt := MustParse("4006381333931")
+	record, err := AppendRecord(nil, gt, []byte{0x2a, 7}, 16)
+	if err != nil || len(record) != 16 {
+		t.Fatalf("got %x, %v", record, err)
+	}
+	back, payload, err := ParseRecord(record)
+	if err != nil || !Equal(back, gt) || !bytes.Equal(payload, []byte{0x2a, 7, 0, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Errorf("got %v, %x, %v", back, payload, err)
+	}
+
+	if _, err := AppendRecord(nil, gt, make([]byte, 11), 16); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+	if _, _, err := ParseRecord(record[:5]); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Parsing and loading of dataset files needs encoding/json and os, which
+// are left out of TinyGo builds. TinyGo builds use the generated snapshots.
+
+// ParseGS1Prefixes reads a prefix table in JSON format:
+//
+//	{"date": "2024-06-01", "entry": [{"from": "000", "to": "019", "name": "GS1 US"}, ...]}
+func ParseGS1Prefixes(r io.Reader) (*GS1Prefixes, error) {
+
+	var f struct {
+		Date  string        `json:"date"`
+		Entry []prefixRange `json:"entry"`
+	}
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("gtin: GS1 prefix table: %w", err)
+	}
+	if len(f.Entry) == 0 {
+		return nil, errors.New("gtin: GS1 prefix table has no entries")
+	}
+	for _, e := range f.Entry {
+		if len(e.From) != 3 || len(e.To) != 3 || e.From > e.To {
+			return nil, fmt.Errorf("gtin: GS1 prefix table: invalid range %s-%s", e.From, e.To)
+		}
+	}
+	sort.Slice(f.Entry, func(i, j int) bool { return f.Entry[i].From < f.Entry[j].From })
+	return &GS1Prefixes{Date: f.Date, ranges: f.Entry}, nil
+}
+
+// LoadGS1Prefixes reads a prefix table from a local path and makes it the
+// table used by MemberOrganization
+func LoadGS1Prefixes(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t, err := ParseGS1Prefixes(f)
+	if err != nil {
+		return err
+	}
+	SetGS1Prefixes(t)
+	return nil
+}
+
+// gcpFile is the JSON format of GS1's gcpprefixformatlist.json
+type gcpFile struct {
+	List struct {
+		Date  string `json:"date"`
+		Entry []struct {
+			Prefix    string `json:"prefix"`
+			GCPLength int    `json:"gcpLength"`
+		} `json:"entry"`
+	} `json:"GCPPrefixFormatList"`
+}
+
+// ParseGCPLengths reads a GCP length file in GS1's JSON format
+func ParseGCPLengths(r io.Reader) (*GCPLengths, error) {
+
+	var f gcpFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("gtin: GCP length file: %w", err)
+	}
+	if len(f.List.Entry) == 0 {
+		return nil, errors.New("gtin: GCP length file has no entries")
+	}
+
+	t := &GCPLengths{Date: f.List.Date, prefixes: make(map[string]int, len(f.List.Entry))}
+	for _, e := range f.List.Entry {
+		if e.GCPLength < 0 || e.GCPLength > 12 {
+			return nil, fmt.Errorf("gtin: GCP length file: invalid length %d for %s", e.GCPLength, e.Prefix)
+		}
+		t.prefixes[e.Prefix] = e.GCPLength
+		if len(e.Prefix) > t.longest {
+			t.longest = len(e.Prefix)
+		}
+	}
+	return t, nil
+}
+
+// LoadGCPLengths reads a GCP length file from a local path and makes it
+// the table used by CompanyPrefix
+func LoadGCPLengths(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t, err := ParseGCPLengths(f)
+	if err != nil {
+		return err
+	}
+	SetGCPLengths(t)
+	return nil
+}
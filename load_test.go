@@ -0,0 +1,116 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// useTestGCPLengths sets the GCP length table of testdata until the test ends
+func useTestGCPLengths(t *testing.T) {
+	t.Helper()
+	if err := LoadGCPLengths("testdata/gcpprefixformatlist.json"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetGCPLengths(nil) })
+}
+
+func TestSnapshotsGenerated(t *testing.T) {
+
+	f, err := os.Open("data/gs1prefixes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	prefixes, err := ParseGS1Prefixes(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(prefixes, gs1PrefixesSnapshot) {
+		t.Error("data_tables.go is out of date with data/gs1prefixes.json, run go generate")
+	}
+
+	g, err := os.Open("data/gcpprefixformatlist.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	lengths, err := ParseGCPLengths(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(lengths, gcpLengthsSnapshot) {
+		t.Error("data_tables.go is out of date with data/gcpprefixformatlist.json, run go generate")
+	}
+}
+
+func TestParseCompanyPrefix(t *testing.T) {
+
+	f, err := os.Open("testdata/gcpprefixformatlist.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lengths, err := ParseGCPLengths(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetGCPLengths(lengths)
+	defer SetGCPLengths(nil)
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"0614141", nil},
+		{"4006381", nil},
+		{"735005000", nil},
+		{"999999999", nil}, // Not in the table
+		{"400638", ErrCompanyPrefix},
+		{"02123456", ErrCompanyPrefix},
+		{"061", ErrLength},
+		{"0614141000001", ErrLength},
+		{"06141a1", ErrDigit},
+	}
+	for _, tt := range tests {
+		p, err := ParseCompanyPrefix(tt.input)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.input, tt.want, err)
+		}
+		if err == nil && string(p) != tt.input {
+			t.Errorf("%s: got %s", tt.input, p)
+		}
+	}
+}
+
+func TestSnapshotOverride(t *testing.T) {
+
+	defer SetGCPLengths(nil)
+
+	SetGCPLengths(nil)
+	if _, err := MustParse("4006381333931").CompanyPrefix(); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("snapshot should not know 4006381, got %v", err)
+	}
+
+	if err := LoadGCPLengths("testdata/gcpprefixformatlist.json"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := MustParse("4006381333931").CompanyPrefix(); got != "4006381" {
+		t.Errorf("wanted 4006381, got %v", got)
+	}
+}
+
+func TestSSCCCompanyPrefix(t *testing.T) {
+
+	useTestGCPLengths(t)
+	s, _ := ParseSSCC("106141411234567897")
+	if cp, err := s.CompanyPrefix(); err != nil || cp != "0614141" {
+		t.Errorf("got %s, %v", cp, err)
+	}
+	if _, err := (SSCC{}).CompanyPrefix(); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("wanted ErrCompanyPrefix, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// ErrNotStarted is the error of batch lookups that were not started
+// because the context deadline was closer than a lookup takes. It matches
+// context.DeadlineExceeded with errors.Is.
+var ErrNotStarted = fmt.Errorf("lookup: not started before the deadline: %w", context.DeadlineExceeded)
+
+// Result is the outcome of one lookup in a batch
+type Result[T any] struct {
+	GTIN  gtin.GTIN
+	Value T
+	Err   error
+}
+
+// Batch looks up the GTINs with up to workers concurrent calls and returns
+// the results in the order of gts. It is deadline-aware: once the time
+// left until the context deadline is shorter than the average lookup so
+// far, the remaining GTINs are not looked up and get ErrNotStarted, so
+// the batch returns within the budget with the results it could get.
+// After cancellation the remaining GTINs get the context's error.
+func Batch[T any](ctx context.Context, gts []gtin.GTIN, workers int, lookup func(context.Context, gtin.GTIN) (T, error)) []Result[T] {
+	return BatchProgress(ctx, gts, workers, lookup, nil)
+}
+
+// BatchProgress is Batch, calling progress, if not nil, after each GTIN
+func BatchProgress[T any](ctx context.Context, gts []gtin.GTIN, workers int, lookup func(context.Context, gtin.GTIN) (T, error), progress gtin.ProgressFunc) []Result[T] {
+
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]Result[T], len(gts))
+	deadline, hasDeadline := ctx.Deadline()
+
+	var (
+		mu    sync.Mutex
+		total time.Duration
+		done  int
+	)
+	// report calls progress after a GTIN, serialized by its own lock so
+	// that slow callbacks don't hold up tooLate
+	var (
+		reportMu sync.Mutex
+		state    = gtin.Progress{Total: len(gts)}
+		start    = time.Now()
+	)
+	report := func(err error) {
+		if progress == nil {
+			return
+		}
+		reportMu.Lock()
+		defer reportMu.Unlock()
+		state.Done++
+		if err != nil {
+			state.Errors++
+		}
+		state.Elapsed = time.Since(start)
+		progress(state)
+	}
+	// tooLate reports whether a lookup started now would likely miss the
+	// deadline
+	tooLate := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return hasDeadline && done > 0 && time.Until(deadline) < total/time.Duration(done)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range next {
+				results[n].GTIN = gts[n]
+				switch err := ctx.Err(); {
+				case err != nil:
+					results[n].Err = err
+				case tooLate():
+					results[n].Err = ErrNotStarted
+				default:
+					start := time.Now()
+					results[n].Value, results[n].Err = lookup(ctx, gts[n])
+					mu.Lock()
+					total += time.Since(start)
+					done++
+					mu.Unlock()
+				}
+				report(results[n].Err)
+			}
+		}()
+	}
+	for n := range gts {
+		next <- n
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+// VerifyAll verifies the GTINs in a deadline-aware batch, see Batch
+func VerifyAll(ctx context.Context, v Verifier, gts []gtin.GTIN, workers int) []Result[*Verification] {
+	return Batch(ctx, gts, workers, v.Verify)
+}
+
+// PartiesByGTIN resolves the parties of the GTINs in a deadline-aware
+// batch, see Batch
+func PartiesByGTIN(ctx context.Context, r PartyResolver, gts []gtin.GTIN, workers int) []Result[*Party] {
+	return Batch(ctx, gts, workers, r.PartyByGTIN)
+}
+
+// Products looks up product data of the GTINs in a deadline-aware batch,
+// see Batch
+func Products(ctx context.Context, s ProductSource, gts []gtin.GTIN, workers int) []Result[*ProductInfo] {
+	return Batch(ctx, gts, workers, s.Product)
+}
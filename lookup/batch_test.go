@@ -0,0 +1,104 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestBatch(t *testing.T) {
+
+	gts := []gtin.GTIN{
+		gtin.MustParse("4006381333931"),
+		gtin.MustParse("614141000012"),
+		gtin.MustParse("96385074"),
+	}
+	results := Products(context.Background(), &countingSource{}, gts, 2)
+	if len(results) != 3 {
+		t.Fatalf("wanted 3 results, got %d", len(results))
+	}
+	for n, r := range results {
+		if !gtin.Equal(r.GTIN, gts[n]) {
+			t.Errorf("result %d is for %s", n, r.GTIN)
+		}
+	}
+	if results[0].Err != nil || results[0].Value.Name != "Test" || !errors.Is(results[1].Err, ErrNotFound) {
+		t.Errorf("got %+v", results)
+	}
+}
+
+func TestBatchDeadline(t *testing.T) {
+
+	gts := make([]gtin.GTIN, 20)
+	for n := range gts {
+		gts[n] = gtin.MustParse("4006381333931")
+	}
+
+	// Each lookup takes 20ms, so about 5 fit a 110ms budget
+	ctx, cancel := context.WithTimeout(context.Background(), 110*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	results := Products(ctx, &countingSource{delay: 20 * time.Millisecond}, gts, 1)
+	// Allow some scheduling slack
+	if elapsed := time.Since(start); elapsed > 130*time.Millisecond {
+		t.Errorf("batch took %v, longer than its budget", elapsed)
+	}
+
+	ok, notStarted := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Err == nil:
+			ok++
+		case errors.Is(r.Err, ErrNotStarted):
+			notStarted++
+			if !errors.Is(r.Err, context.DeadlineExceeded) {
+				t.Error("ErrNotStarted should match context.DeadlineExceeded")
+			}
+		}
+	}
+	if ok < 3 || ok+notStarted != len(gts) {
+		t.Errorf("got %d ok and %d not started of %d", ok, notStarted, len(gts))
+	}
+
+	// A cancelled context starts nothing
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	src := &countingSource{}
+	for _, r := range Products(ctx, src, gts, 4) {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("wanted context.Canceled, got %v", r.Err)
+		}
+	}
+	if src.calls.Load() != 0 {
+		t.Errorf("wanted no calls, got %d", src.calls.Load())
+	}
+}
+
+func TestBatchProgress(t *testing.T) {
+
+	gts := []gtin.GTIN{
+		gtin.MustParse("4006381333931"),
+		gtin.MustParse("614141000012"),
+		gtin.MustParse("96385074"),
+		gtin.MustParse("614141000012"),
+	}
+	var reports []gtin.Progress
+	src := &countingSource{}
+	BatchProgress(context.Background(), gts, 3, src.Product, func(p gtin.Progress) {
+		reports = append(reports, p)
+	})
+	if len(reports) != len(gts) {
+		t.Fatalf("wanted %d reports, got %d", len(gts), len(reports))
+	}
+	for n, p := range reports {
+		if p.Done != n+1 || p.Total != len(gts) {
+			t.Errorf("report %d: got %+v", n, p)
+		}
+	}
+	if last := reports[len(reports)-1]; last.Errors != 2 {
+		t.Errorf("wanted 2 errors, got %+v", last)
+	}
+}
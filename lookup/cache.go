@@ -0,0 +1,180 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Cache is a TTL cache with a bounded number of entries. Concurrent Gets
+// of the same key share one fetch. Results and ErrNotFound are cached,
+// other errors are not.
+type Cache[T any] struct {
+	TTL        time.Duration
+	MaxEntries int // Zero means no limit
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     list.List // Front is most recently used
+	calls   map[string]*call[T]
+}
+
+type entry[T any] struct {
+	key     string
+	value   T
+	err     error
+	expires time.Time
+}
+
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+	retry bool // The fetch was cancelled by its caller or panicked
+}
+
+// NewCache returns a cache with the given TTL and maximum number of entries
+func NewCache[T any](ttl time.Duration, maxEntries int) *Cache[T] {
+	return &Cache[T]{TTL: ttl, MaxEntries: maxEntries}
+}
+
+// Get returns the cached value for key, or calls fetch to get it. Waiters
+// on a fetch that its caller cancelled, or that panicked, fetch again.
+func (c *Cache[T]) Get(ctx context.Context, key string, fetch func(context.Context) (T, error)) (T, error) {
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+		c.calls = make(map[string]*call[T])
+	}
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry[T])
+		if time.Now().Before(e.expires) {
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			return e.value, e.err
+		}
+		c.remove(el)
+	}
+	if cl, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-cl.done:
+			if cl.retry && ctx.Err() == nil {
+				return c.Get(ctx, key, fetch)
+			}
+			return cl.value, cl.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+	cl := &call[T]{done: make(chan struct{}), retry: true}
+	c.calls[key] = cl
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		if !cl.retry && (cl.err == nil || errors.Is(cl.err, ErrNotFound)) {
+			c.add(&entry[T]{key: key, value: cl.value, err: cl.err, expires: time.Now().Add(c.TTL)})
+		}
+		c.mu.Unlock()
+		close(cl.done)
+	}()
+
+	cl.value, cl.err = fetch(ctx)
+	cl.retry = cl.err != nil && ctx.Err() != nil
+	return cl.value, cl.err
+}
+
+// Len returns the number of cached entries, including expired ones not
+// yet evicted
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache[T]) add(e *entry[T]) {
+	c.entries[e.key] = c.lru.PushFront(e)
+	for c.MaxEntries > 0 && c.lru.Len() > c.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache[T]) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*entry[T]).key)
+}
+
+// Verifier is implemented by VerifiedClient
+type Verifier interface {
+	Verify(ctx context.Context, gt gtin.GTIN) (*Verification, error)
+}
+
+// PartyResolver is implemented by GEPIRClient
+type PartyResolver interface {
+	PartyByGTIN(ctx context.Context, gt gtin.GTIN) (*Party, error)
+	PartyByPrefix(ctx context.Context, prefix string) (*Party, error)
+}
+
+type cachedVerifier struct {
+	v     Verifier
+	cache *Cache[*Verification]
+}
+
+// CacheVerifier decorates v with a cache
+func CacheVerifier(v Verifier, ttl time.Duration, maxEntries int) Verifier {
+	return &cachedVerifier{v, NewCache[*Verification](ttl, maxEntries)}
+}
+
+func (c *cachedVerifier) Verify(ctx context.Context, gt gtin.GTIN) (*Verification, error) {
+	return c.cache.Get(ctx, gt.String(), func(ctx context.Context) (*Verification, error) {
+		return c.v.Verify(ctx, gt)
+	})
+}
+
+type cachedPartyResolver struct {
+	r     PartyResolver
+	cache *Cache[*Party]
+}
+
+// CachePartyResolver decorates r with a cache
+func CachePartyResolver(r PartyResolver, ttl time.Duration, maxEntries int) PartyResolver {
+	return &cachedPartyResolver{r, NewCache[*Party](ttl, maxEntries)}
+}
+
+func (c *cachedPartyResolver) PartyByGTIN(ctx context.Context, gt gtin.GTIN) (*Party, error) {
+	return c.cache.Get(ctx, "gtin/"+gt.String(), func(ctx context.Context) (*Party, error) {
+		return c.r.PartyByGTIN(ctx, gt)
+	})
+}
+
+func (c *cachedPartyResolver) PartyByPrefix(ctx context.Context, prefix string) (*Party, error) {
+	return c.cache.Get(ctx, "prefix/"+prefix, func(ctx context.Context) (*Party, error) {
+		return c.r.PartyByPrefix(ctx, prefix)
+	})
+}
+
+type cachedProductSource struct {
+	s     ProductSource
+	cache *Cache[*ProductInfo]
+}
+
+// CacheProductSource decorates s with a cache
+func CacheProductSource(s ProductSource, ttl time.Duration, maxEntries int) ProductSource {
+	return &cachedProductSource{s, NewCache[*ProductInfo](ttl, maxEntries)}
+}
+
+func (c *cachedProductSource) Product(ctx context.Context, gt gtin.GTIN) (*ProductInfo, error) {
+	return c.cache.Get(ctx, gt.String(), func(ctx context.Context) (*ProductInfo, error) {
+		return c.s.Product(ctx, gt)
+	})
+}
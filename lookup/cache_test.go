@@ -0,0 +1,133 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+type countingSource struct {
+	calls atomic.Int32
+	delay time.Duration
+}
+
+func (s *countingSource) Product(ctx context.Context, gt gtin.GTIN) (*ProductInfo, error) {
+	s.calls.Add(1)
+	time.Sleep(s.delay)
+	if gt.String() == "00614141000012" {
+		return nil, ErrNotFound
+	}
+	return &ProductInfo{GTIN: gt, Name: "Test"}, nil
+}
+
+func TestCacheSingleflight(t *testing.T) {
+
+	src := &countingSource{delay: 20 * time.Millisecond}
+	c := CacheProductSource(src, time.Minute, 10)
+	gt := gtin.MustParse("4006381333931")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if p, err := c.Product(context.Background(), gt); err != nil || p.Name != "Test" {
+				t.Errorf("wrong result %v, %v", p, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if src.calls.Load() != 1 {
+		t.Errorf("wanted 1 call, got %d", src.calls.Load())
+	}
+
+	// Not found is cached too
+	for i := 0; i < 2; i++ {
+		if _, err := c.Product(context.Background(), gtin.MustParse("614141000012")); !errors.Is(err, ErrNotFound) {
+			t.Errorf("wanted %v, got %v", ErrNotFound, err)
+		}
+	}
+	if src.calls.Load() != 2 {
+		t.Errorf("wanted 2 calls, got %d", src.calls.Load())
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+
+	c := NewCache[int](10*time.Millisecond, 2)
+	var calls int
+	fetch := func(context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+	ctx := context.Background()
+
+	c.Get(ctx, "a", fetch)
+	c.Get(ctx, "a", fetch)
+	if calls != 1 {
+		t.Errorf("wanted 1 call, got %d", calls)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if v, _ := c.Get(ctx, "a", fetch); v != 2 {
+		t.Errorf("expired entry not refetched")
+	}
+
+	c.Get(ctx, "b", fetch)
+	c.Get(ctx, "c", fetch)
+	if c.Len() != 2 {
+		t.Errorf("wanted 2 entries, got %d", c.Len())
+	}
+}
+
+func TestCacheSharedFetch(t *testing.T) {
+
+	c := NewCache[int](time.Minute, 0)
+	started := make(chan struct{})
+
+	// The first caller cancels, or panics, while another waits
+	for _, fail := range []string{"cancel", "panic"} {
+		key := fail
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			defer func() { recover() }()
+			c.Get(ctx, key, func(ctx context.Context) (int, error) {
+				close(started)
+				if key == "panic" {
+					time.Sleep(10 * time.Millisecond)
+					panic(key)
+				}
+				<-ctx.Done()
+				return 0, ctx.Err()
+			})
+		}()
+		<-started
+		started = make(chan struct{})
+
+		waiter := make(chan int)
+		go func() {
+			v, err := c.Get(context.Background(), key, func(context.Context) (int, error) { return 2, nil })
+			if err != nil {
+				t.Errorf("%s: %v", key, err)
+			}
+			waiter <- v
+		}()
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+
+		select {
+		case v := <-waiter:
+			if v != 2 {
+				t.Errorf("%s: wanted the waiter's own fetch, got %d", key, v)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: waiter hangs", key)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/peterstark72/gtin"
+)
+
+// GCPLengthsURL is where GS1 publishes the GCP length file
+const GCPLengthsURL = "https://www.gs1.org/sites/default/files/docs/gcp_length/gcpprefixformatlist.json"
+
+// FetchGCPLengths downloads and parses the GCP length file. An empty url
+// means GCPLengthsURL and a nil client means http.DefaultClient.
+func FetchGCPLengths(ctx context.Context, client *http.Client, url string) (*gtin.GCPLengths, error) {
+
+	if url == "" {
+		url = GCPLengthsURL
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode}
+	}
+	return gtin.ParseGCPLengths(resp.Body)
+}
+
+// UpdateGCPLengths downloads the GCP length file from GS1 and makes it
+// the table used by gtin.GTIN.CompanyPrefix
+func UpdateGCPLengths(ctx context.Context) error {
+	t, err := FetchGCPLengths(ctx, nil, "")
+	if err != nil {
+		return fmt.Errorf("lookup: GCP length file: %w", err)
+	}
+	gtin.SetGCPLengths(t)
+	return nil
+}
@@ -0,0 +1,24 @@
+package lookup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchGCPLengths(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "../testdata/gcpprefixformatlist.json")
+	}))
+	defer srv.Close()
+
+	table, err := FetchGCPLengths(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if length, ok := table.Lookup("4006381333931"); !ok || length != 7 {
+		t.Errorf("wanted 7, got %v", length)
+	}
+}
@@ -0,0 +1,153 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// ErrQuotaExceeded is returned when a member organization's GEPIR
+// service has refused requests because the daily quota is used up
+var ErrQuotaExceeded = errors.New("lookup: GEPIR quota exceeded")
+
+// QuotaError tells which GEPIR service refused and for how long
+type QuotaError struct {
+	URL        string
+	RetryAfter time.Time
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%v at %s until %s", ErrQuotaExceeded, e.URL, e.RetryAfter.Format(time.RFC3339))
+}
+
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// defaultQuotaWait is used when a service doesn't send Retry-After
+const defaultQuotaWait = time.Hour
+
+// Address is the postal address of a party
+type Address struct {
+	Street      string `json:"streetAddressOne"`
+	City        string `json:"city"`
+	PostalCode  string `json:"postalCode"`
+	CountryCode string `json:"countryCode"`
+}
+
+// Party is the company owning a GTIN or company prefix
+type Party struct {
+	GLN           string  `json:"gln"`
+	Name          string  `json:"partyName"`
+	Address       Address `json:"address"`
+	CompanyPrefix string  `json:"gs1CompanyPrefix"`
+}
+
+// GEPIRClient resolves GTINs and company prefixes to the owning party.
+// Requests are routed to the GEPIR service of the member organization
+// that allocated the GS1 prefix.
+type GEPIRClient struct {
+	// Routes maps GS1 prefixes, e.g. "400" or "73", to the base URL of a
+	// member organization's GEPIR service. The longest matching prefix wins.
+	Routes map[string]string
+
+	// DefaultURL is used when no route matches, and as fallback when a
+	// member organization's quota is exceeded
+	DefaultURL string
+
+	APIKey     string
+	HTTPClient *http.Client
+
+	// Throttle applies to every member organization's service
+	Throttle *Throttle
+
+	mu        sync.Mutex
+	exhausted map[string]time.Time
+}
+
+// route returns the base URL for a GS1 prefix
+func (c *GEPIRClient) route(prefix string) string {
+	var best string
+	for p := range c.Routes {
+		if strings.HasPrefix(prefix, p) && len(p) > len(best) {
+			best = p
+		}
+	}
+	if best == "" {
+		return c.DefaultURL
+	}
+	return c.Routes[best]
+}
+
+// PartyByGTIN returns the party owning the GTIN
+func (c *GEPIRClient) PartyByGTIN(ctx context.Context, gt gtin.GTIN) (*Party, error) {
+	// GS1 prefix starts after the padding zero or indicator digit
+	return c.party(ctx, gt.String()[1:], "gtin/"+gt.String())
+}
+
+// PartyByPrefix returns the party owning the GS1 company prefix
+func (c *GEPIRClient) PartyByPrefix(ctx context.Context, prefix string) (*Party, error) {
+	return c.party(ctx, prefix, "prefix/"+url.PathEscape(prefix))
+}
+
+func (c *GEPIRClient) party(ctx context.Context, prefix, path string) (*Party, error) {
+
+	base := c.route(prefix)
+	if base == "" {
+		return nil, fmt.Errorf("lookup: no GEPIR service for prefix %s", prefix)
+	}
+
+	p, err := c.get(ctx, base, path)
+	var quota *QuotaError
+	if errors.As(err, &quota) && c.DefaultURL != "" && base != c.DefaultURL {
+		// Fall back to the global service while the MO is exhausted
+		return c.get(ctx, c.DefaultURL, path)
+	}
+	return p, err
+}
+
+func (c *GEPIRClient) get(ctx context.Context, base, path string) (*Party, error) {
+
+	c.mu.Lock()
+	until, ok := c.exhausted[base]
+	c.mu.Unlock()
+	if ok && time.Now().Before(until) {
+		// Don't hammer a service that already refused
+		return nil, &QuotaError{URL: base, RetryAfter: until}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(base, "/")+"/party/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.APIKey != "" {
+		req.Header.Set("APIKey", c.APIKey)
+	}
+
+	var p Party
+	err = doJSON(ctx, c.HTTPClient, c.Throttle, req, &p)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		until := time.Now().Add(apiErr.retryAfter(defaultQuotaWait))
+		c.mu.Lock()
+		if c.exhausted == nil {
+			c.exhausted = make(map[string]time.Time)
+		}
+		c.exhausted[base] = until
+		c.mu.Unlock()
+		return nil, &QuotaError{URL: base, RetryAfter: until}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
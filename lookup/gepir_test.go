@@ -0,0 +1,68 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestGEPIR(t *testing.T) {
+
+	var calls atomic.Int32
+	de := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer de.Close()
+
+	global := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/party/gtin/04006381333931":
+			w.Write([]byte(`{"partyName": "STABILO International GmbH", "address": {"city": "Heroldsberg", "countryCode": "DE"}}`))
+		case "/party/prefix/7350053":
+			w.Write([]byte(`{"partyName": "Example AB", "gs1CompanyPrefix": "7350053"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer global.Close()
+
+	c := &GEPIRClient{
+		Routes:     map[string]string{"40": de.URL},
+		DefaultURL: global.URL,
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		p, err := c.PartyByGTIN(ctx, gtin.MustParse("4006381333931"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Name != "STABILO International GmbH" || p.Address.City != "Heroldsberg" {
+			t.Errorf("wrong party %+v", p)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("exhausted service called %d times", calls.Load())
+	}
+
+	p, err := c.PartyByPrefix(ctx, "7350053")
+	if err != nil || p.Name != "Example AB" {
+		t.Errorf("wrong party %+v, %v", p, err)
+	}
+
+	if _, err := c.PartyByPrefix(ctx, "7350000"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("wanted %v, got %v", ErrNotFound, err)
+	}
+
+	c.DefaultURL = ""
+	if _, err := c.PartyByGTIN(ctx, gtin.MustParse("4006381333931")); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("wanted %v, got %v", ErrQuotaExceeded, err)
+	}
+}
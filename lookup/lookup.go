@@ -0,0 +1,72 @@
+//go:build !tinygo
+
+/*
+Package lookup implements clients for remote GTIN data sources, such as
+the GS1 registry and open product databases.
+
+All clients take a context and use http.DefaultClient unless another
+*http.Client is configured.
+*/
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrNotFound is returned when a source has no data for the GTIN
+var ErrNotFound = errors.New("lookup: not found")
+
+// APIError is returned when a source responds with an unexpected HTTP status
+type APIError struct {
+	StatusCode int
+	Body       string
+	RetryAfter string // The Retry-After header, if any
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("lookup: HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// retryAfter returns the wait given by the Retry-After header in seconds
+func (e *APIError) retryAfter(fallback time.Duration) time.Duration {
+	if s, err := strconv.Atoi(e.RetryAfter); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+	return fallback
+}
+
+// maxErrorBody limits how much of an error response is kept
+const maxErrorBody = 512
+
+// doJSON sends the request through the throttle and decodes a JSON
+// response into v
+func doJSON(ctx context.Context, client *http.Client, throttle *Throttle, req *http.Request, v any) error {
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := throttle.do(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: resp.Header.Get("Retry-After"),
+		}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
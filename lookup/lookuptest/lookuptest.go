@@ -0,0 +1,184 @@
+//go:build !tinygo
+
+/*
+Package lookuptest provides an in-memory fake of the services used by
+package lookup, for deterministic tests without network access.
+
+	srv := lookuptest.NewServer()
+	defer srv.Close()
+	srv.AddVerification(lookup.Verification{GTIN: "04006381333931", ...})
+	client := srv.VerifiedClient()
+*/
+package lookuptest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/lookup"
+)
+
+// APIKey is the key the fake Verified by GS1 API accepts
+const APIKey = "lookuptest"
+
+// Server fakes the Verified by GS1 API, GEPIR and OpenFoodFacts
+type Server struct {
+	URL string
+
+	srv      *httptest.Server
+	requests atomic.Int64
+	quota    atomic.Bool
+
+	mu            sync.Mutex
+	verifications map[string]lookup.Verification
+	parties       map[string]lookup.Party
+	products      map[string]lookup.ProductInfo
+}
+
+// NewServer starts a fake server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		verifications: make(map[string]lookup.Verification),
+		parties:       make(map[string]lookup.Party),
+		products:      make(map[string]lookup.ProductInfo),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/verified", s.verified)
+	mux.HandleFunc("/gepir/party/", s.gepir)
+	mux.HandleFunc("/off/api/v2/product/", s.openFoodFacts)
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		mux.ServeHTTP(w, r)
+	}))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Requests returns the number of requests served
+func (s *Server) Requests() int {
+	return int(s.requests.Load())
+}
+
+// AddVerification adds a Verified by GS1 record, keyed by its GTIN field
+func (s *Server) AddVerification(v lookup.Verification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifications[v.GTIN] = v
+}
+
+// AddParty adds a GEPIR party for a GTIN or a GS1 company prefix
+func (s *Server) AddParty(gtinOrPrefix string, p lookup.Party) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if gt, err := gtin.Atog(gtinOrPrefix); err == nil {
+		s.parties["gtin/"+gt.String()] = p
+		return
+	}
+	s.parties["prefix/"+gtinOrPrefix] = p
+}
+
+// AddProduct adds an OpenFoodFacts product
+func (s *Server) AddProduct(p lookup.ProductInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.products[p.GTIN.Short()] = p
+}
+
+// SetQuotaExceeded makes GEPIR answer HTTP 429 until reset
+func (s *Server) SetQuotaExceeded(exceeded bool) {
+	s.quota.Store(exceeded)
+}
+
+// VerifiedClient returns a client for the fake Verified by GS1 API
+func (s *Server) VerifiedClient() *lookup.VerifiedClient {
+	return &lookup.VerifiedClient{APIKey: APIKey, URL: s.URL + "/verified", HTTPClient: s.srv.Client()}
+}
+
+// GEPIRClient returns a client for the fake GEPIR service
+func (s *Server) GEPIRClient() *lookup.GEPIRClient {
+	return &lookup.GEPIRClient{DefaultURL: s.URL + "/gepir", HTTPClient: s.srv.Client()}
+}
+
+// OpenFoodFacts returns a client for the fake OpenFoodFacts API
+func (s *Server) OpenFoodFacts() *lookup.OpenFoodFacts {
+	return &lookup.OpenFoodFacts{URL: s.URL + "/off", HTTPClient: s.srv.Client()}
+}
+
+func (s *Server) verified(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get("APIKey") != APIKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var gtins []string
+	if err := json.NewDecoder(r.Body).Decode(&gtins); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	result := make([]lookup.Verification, 0, len(gtins))
+	for _, g := range gtins {
+		v, ok := s.verifications[g]
+		if !ok {
+			v = lookup.Verification{GTIN: g}
+		}
+		result = append(result, v)
+	}
+	s.mu.Unlock()
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) gepir(w http.ResponseWriter, r *http.Request) {
+
+	if s.quota.Load() {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	s.mu.Lock()
+	p, ok := s.parties[strings.TrimPrefix(r.URL.Path, "/gepir/party/")]
+	s.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(p)
+}
+
+func (s *Server) openFoodFacts(w http.ResponseWriter, r *http.Request) {
+
+	code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/off/api/v2/product/"), ".json")
+
+	s.mu.Lock()
+	p, ok := s.products[code]
+	s.mu.Unlock()
+	if !ok {
+		json.NewEncoder(w).Encode(map[string]any{"status": 0})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"status": 1,
+		"product": map[string]any{
+			"product_name":    p.Name,
+			"brands":          p.Brand,
+			"image_url":       p.ImageURL,
+			"categories_tags": p.Categories,
+		},
+	})
+}
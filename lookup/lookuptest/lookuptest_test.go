@@ -0,0 +1,49 @@
+package lookuptest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/lookup"
+)
+
+func TestServer(t *testing.T) {
+
+	srv := NewServer()
+	defer srv.Close()
+
+	gt := gtin.MustParse("4006381333931")
+	srv.AddVerification(lookup.Verification{GTIN: gt.String(), Licence: &lookup.Licence{LicenseeName: "STABILO"}})
+	srv.AddParty("4006381", lookup.Party{Name: "STABILO International GmbH"})
+	srv.AddProduct(lookup.ProductInfo{GTIN: gt, Name: "BOSS ORIGINAL", Brand: "STABILO"})
+
+	ctx := context.Background()
+
+	v, err := srv.VerifiedClient().Verify(ctx, gt)
+	if err != nil || v.LicenseeName() != "STABILO" {
+		t.Errorf("wrong verification %v, %v", v, err)
+	}
+	if _, err := srv.VerifiedClient().Verify(ctx, gtin.MustParse("614141000012")); !errors.Is(err, lookup.ErrNotFound) {
+		t.Errorf("wanted %v, got %v", lookup.ErrNotFound, err)
+	}
+
+	p, err := srv.GEPIRClient().PartyByPrefix(ctx, "4006381")
+	if err != nil || p.Name != "STABILO International GmbH" {
+		t.Errorf("wrong party %v, %v", p, err)
+	}
+	srv.SetQuotaExceeded(true)
+	if _, err := srv.GEPIRClient().PartyByPrefix(ctx, "4006381"); !errors.Is(err, lookup.ErrQuotaExceeded) {
+		t.Errorf("wanted %v, got %v", lookup.ErrQuotaExceeded, err)
+	}
+
+	prod, err := srv.OpenFoodFacts().Product(ctx, gt)
+	if err != nil || prod.Name != "BOSS ORIGINAL" || prod.Brand != "STABILO" {
+		t.Errorf("wrong product %v, %v", prod, err)
+	}
+
+	if srv.Requests() != 5 {
+		t.Errorf("wanted 5 requests, got %d", srv.Requests())
+	}
+}
@@ -0,0 +1,174 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// ProductInfo is product data from an open product database, normalized
+// into one schema
+type ProductInfo struct {
+	GTIN       gtin.GTIN
+	Name       string
+	Brand      string
+	ImageURL   string
+	Categories []string
+	Source     string // The database the data came from
+}
+
+// ProductSource is implemented by the product database clients
+type ProductSource interface {
+	Product(ctx context.Context, gt gtin.GTIN) (*ProductInfo, error)
+}
+
+// OpenFoodFactsURL is the base URL of the OpenFoodFacts API
+const OpenFoodFactsURL = "https://world.openfoodfacts.org"
+
+// OpenFoodFacts is a client for the OpenFoodFacts API
+// https://openfoodfacts.github.io/openfoodfacts-server/api/
+type OpenFoodFacts struct {
+	URL        string // Defaults to OpenFoodFactsURL
+	UserAgent  string // OpenFoodFacts asks clients to identify themselves
+	HTTPClient *http.Client
+	Throttle   *Throttle
+}
+
+type offResponse struct {
+	Status  int `json:"status"`
+	Product struct {
+		ProductName string   `json:"product_name"`
+		Brands      string   `json:"brands"`
+		ImageURL    string   `json:"image_url"`
+		Categories  []string `json:"categories_tags"`
+	} `json:"product"`
+}
+
+// Product fetches the product from OpenFoodFacts
+func (c *OpenFoodFacts) Product(ctx context.Context, gt gtin.GTIN) (*ProductInfo, error) {
+
+	base := c.URL
+	if base == "" {
+		base = OpenFoodFactsURL
+	}
+	u := base + "/api/v2/product/" + gt.Short() + ".json?fields=product_name,brands,image_url,categories_tags"
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	var r offResponse
+	if err := doJSON(ctx, c.HTTPClient, c.Throttle, req, &r); err != nil {
+		return nil, err
+	}
+	if r.Status != 1 {
+		return nil, ErrNotFound
+	}
+
+	info := &ProductInfo{
+		GTIN:       gt,
+		Name:       r.Product.ProductName,
+		ImageURL:   r.Product.ImageURL,
+		Categories: r.Product.Categories,
+		Source:     "openfoodfacts",
+	}
+	// Brands is a comma separated list, the first is the main brand
+	info.Brand, _, _ = strings.Cut(r.Product.Brands, ",")
+	info.Brand = strings.TrimSpace(info.Brand)
+	return info, nil
+}
+
+// OpenGTINDBURL is the base URL of the Open EAN/GTIN database
+const OpenGTINDBURL = "https://opengtindb.org"
+
+// OpenGTINDB is a client for the Open EAN/GTIN database. It needs a
+// query ID, which is free for non-commercial use.
+// https://opengtindb.org/api.php
+type OpenGTINDB struct {
+	URL        string // Defaults to OpenGTINDBURL
+	QueryID    string
+	HTTPClient *http.Client
+	Throttle   *Throttle
+}
+
+// Product fetches the product from the Open EAN/GTIN database
+func (c *OpenGTINDB) Product(ctx context.Context, gt gtin.GTIN) (*ProductInfo, error) {
+
+	base := c.URL
+	if base == "" {
+		base = OpenGTINDBURL
+	}
+	q := url.Values{"ean": {gt.String()[1:]}, "cmd": {"query"}, "queryid": {c.QueryID}}
+	req, err := http.NewRequest(http.MethodGet, base+"/?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Throttle.do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode}
+	}
+
+	// The response is ISO-8859-1 encoded key=value lines, where the
+	// first block holds the error code and the next the product
+	fields := make(map[string]string)
+	s := bufio.NewScanner(resp.Body)
+	for s.Scan() {
+		key, value, ok := strings.Cut(latin1(s.Bytes()), "=")
+		if ok {
+			if _, seen := fields[key]; !seen {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	switch fields["error"] {
+	case "0":
+	case "1":
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("lookup: opengtindb error %s", fields["error"])
+	}
+
+	info := &ProductInfo{
+		GTIN:   gt,
+		Name:   fields["name"],
+		Brand:  fields["vendor"],
+		Source: "opengtindb",
+	}
+	if detail := fields["detailname"]; detail != "" {
+		info.Name = detail
+	}
+	for _, key := range []string{"maincat", "subcat"} {
+		if fields[key] != "" {
+			info.Categories = append(info.Categories, fields[key])
+		}
+	}
+	return info, nil
+}
+
+// latin1 decodes ISO-8859-1 bytes
+func latin1(b []byte) string {
+	r := make([]rune, len(b))
+	for n, c := range b {
+		r[n] = rune(c)
+	}
+	return string(r)
+}
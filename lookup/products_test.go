@@ -0,0 +1,67 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestOpenFoodFacts(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/product/3017620422003.json" {
+			w.Write([]byte(`{"status": 0}`))
+			return
+		}
+		w.Write([]byte(`{"status": 1, "product": {
+			"product_name": "Nutella",
+			"brands": "Ferrero, Nutella",
+			"image_url": "https://images.example/nutella.jpg",
+			"categories_tags": ["en:spreads", "en:sweet-spreads"]
+		}}`))
+	}))
+	defer srv.Close()
+
+	c := &OpenFoodFacts{URL: srv.URL}
+	p, err := c.Product(context.Background(), gtin.MustParse("3017620422003"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Nutella" || p.Brand != "Ferrero" || len(p.Categories) != 2 || p.Source != "openfoodfacts" {
+		t.Errorf("wrong product %+v", p)
+	}
+
+	if _, err := c.Product(context.Background(), gtin.MustParse("4006381333931")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("wanted %v, got %v", ErrNotFound, err)
+	}
+}
+
+func TestOpenGTINDB(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ean") != "4006381333931" {
+			w.Write([]byte("error=1\n---\n"))
+			return
+		}
+		// B\xfcro is Büro in ISO-8859-1
+		w.Write([]byte("error=0\n---\nasin=\nname=Textmarker\ndetailname=BOSS ORIGINAL\nvendor=STABILO\nmaincat=B\xfcro\nsubcat=Stifte\n---\n"))
+	}))
+	defer srv.Close()
+
+	c := &OpenGTINDB{URL: srv.URL, QueryID: "400000000"}
+	p, err := c.Product(context.Background(), gtin.MustParse("4006381333931"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "BOSS ORIGINAL" || p.Brand != "STABILO" || p.Categories[0] != "Büro" {
+		t.Errorf("wrong product %+v", p)
+	}
+
+	if _, err := c.Product(context.Background(), gtin.MustParse("3017620422003")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("wanted %v, got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,94 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Refresher periodically downloads the GCP length file, and optionally a
+// GS1 prefix table, and swaps them in. Readers are never blocked; they
+// keep using the previous table until the new one is in place.
+//
+//	r := &lookup.Refresher{Interval: 24 * time.Hour}
+//	go r.Run(ctx)
+type Refresher struct {
+	Interval    time.Duration
+	GCPURL      string // Defaults to GCPLengthsURL
+	PrefixesURL string // Prefix table in gtin.ParseGS1Prefixes format, optional
+	HTTPClient  *http.Client
+
+	// OnError is called when a refresh fails. The previous tables are kept.
+	OnError func(error)
+
+	tick <-chan time.Time // In place of a ticker of Interval, for tests
+}
+
+// Run refreshes the tables at once and then every Interval, until ctx is done
+func (r *Refresher) Run(ctx context.Context) {
+
+	tick := r.tick
+	if tick == nil {
+		t := time.NewTicker(r.Interval)
+		defer t.Stop()
+		tick = t.C
+	}
+
+	for {
+		if err := r.Refresh(ctx); err != nil && r.OnError != nil && ctx.Err() == nil {
+			r.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		}
+	}
+}
+
+// Refresh downloads and swaps in the tables once
+func (r *Refresher) Refresh(ctx context.Context) error {
+
+	lengths, err := FetchGCPLengths(ctx, r.HTTPClient, r.GCPURL)
+	if err != nil {
+		return err
+	}
+
+	var prefixes *gtin.GS1Prefixes
+	if r.PrefixesURL != "" {
+		if prefixes, err = r.fetchPrefixes(ctx); err != nil {
+			return err
+		}
+	}
+
+	gtin.SetGCPLengths(lengths)
+	if prefixes != nil {
+		gtin.SetGS1Prefixes(prefixes)
+	}
+	return nil
+}
+
+func (r *Refresher) fetchPrefixes(ctx context.Context) (*gtin.GS1Prefixes, error) {
+
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.PrefixesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode}
+	}
+	return gtin.ParseGS1Prefixes(resp.Body)
+}
@@ -0,0 +1,59 @@
+package lookup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestRefresher(t *testing.T) {
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		http.ServeFile(w, r, "../testdata/gcpprefixformatlist.json")
+	}))
+	defer srv.Close()
+	defer gtin.SetGCPLengths(nil)
+
+	var failures atomic.Int32
+	tick := make(chan time.Time)
+	r := &Refresher{
+		GCPURL:  srv.URL,
+		OnError: func(error) { failures.Add(1) },
+		tick:    tick,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	// Each tick is taken once the previous refresh is done, so three
+	// refreshes have run when the third is taken
+	for n := 0; n < 3; n++ {
+		tick <- time.Now()
+	}
+	cancel()
+	<-done
+
+	if calls.Load() < 3 {
+		t.Errorf("wanted at least 3 refreshes, got %d", calls.Load())
+	}
+	if failures.Load() != 1 {
+		t.Errorf("wanted 1 failure, got %d", failures.Load())
+	}
+	if got, _ := gtin.MustParse("4006381333931").CompanyPrefix(); got != "4006381" {
+		t.Errorf("wanted 4006381, got %v", got)
+	}
+}
@@ -0,0 +1,153 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Throttle rate limits the requests of a client with a token bucket and
+// retries failed requests with exponential backoff and jitter. Requests
+// are retried on network errors, HTTP 429 and HTTP 5xx; a Retry-After
+// header longer than MaxDelay is not waited for. A nil *Throttle sends
+// every request once, without limits.
+//
+// Give each client its own Throttle to match the limits of its service.
+type Throttle struct {
+	Rate  float64 // Requests per second, zero means no limit
+	Burst int     // Requests allowed at once, defaults to 1
+
+	MaxRetries int
+	BaseDelay  time.Duration // Defaults to 500ms
+	MaxDelay   time.Duration // Defaults to 30s
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// wait blocks until the token bucket allows another request
+func (t *Throttle) wait(ctx context.Context) error {
+
+	if t.Rate <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	burst := float64(max(t.Burst, 1))
+	if t.last.IsZero() {
+		t.tokens = burst
+	} else {
+		t.tokens = min(burst, t.tokens+now.Sub(t.last).Seconds()*t.Rate)
+	}
+	t.last = now
+	// Reserve a token, going into debt if there is none
+	t.tokens--
+	var d time.Duration
+	if t.tokens < 0 {
+		d = time.Duration(-t.tokens / t.Rate * float64(time.Second))
+	}
+	t.mu.Unlock()
+
+	return sleep(ctx, d)
+}
+
+// backoff returns the delay before retry number attempt, with jitter
+func (t *Throttle) backoff(attempt int) time.Duration {
+	base, limit := t.BaseDelay, t.MaxDelay
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	if limit <= 0 {
+		limit = 30 * time.Second
+	}
+	d := base << attempt
+	if d > limit || d <= 0 {
+		d = limit
+	}
+	// Pick a delay in [d/2, d)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retry returns whether to retry after a response or error, and the delay
+func (t *Throttle) retry(ctx context.Context, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+
+	if attempt >= t.MaxRetries || ctx.Err() != nil {
+		return false, 0
+	}
+	if err != nil {
+		return true, t.backoff(attempt)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return false, 0
+	}
+
+	d := t.backoff(attempt)
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		d = time.Duration(s) * time.Second
+		if t.MaxDelay > 0 && d > t.MaxDelay || t.MaxDelay <= 0 && d > 30*time.Second {
+			// Long waits, like daily quotas, are left to the caller
+			return false, 0
+		}
+	}
+	return true, d
+}
+
+// do sends the request, waiting for the rate limit and retrying failures
+func (t *Throttle) do(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if t == nil {
+		return client.Do(req.WithContext(ctx))
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(ctx); err != nil {
+			return nil, err
+		}
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := client.Do(req.WithContext(ctx))
+		again, delay := t.retry(ctx, resp, err, attempt)
+		if !again || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
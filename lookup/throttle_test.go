@@ -0,0 +1,68 @@
+package lookup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestThrottleRetry(t *testing.T) {
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{"gtin": "04006381333931", "gs1Licence": {"licenseeName": "STABILO"}}]`))
+	}))
+	defer srv.Close()
+
+	c := &VerifiedClient{URL: srv.URL, Throttle: &Throttle{MaxRetries: 3, BaseDelay: time.Millisecond}}
+	v, err := c.Verify(context.Background(), gtin.MustParse("4006381333931"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.LicenseeName() != "STABILO" || calls.Load() != 3 {
+		t.Errorf("wanted success after 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestThrottleLongRetryAfter(t *testing.T) {
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := &OpenFoodFacts{URL: srv.URL, Throttle: &Throttle{MaxRetries: 3, MaxDelay: time.Second}}
+	if _, err := c.Product(context.Background(), gtin.MustParse("4006381333931")); err == nil {
+		t.Errorf("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("wanted 1 call, got %d", calls.Load())
+	}
+}
+
+func TestThrottleRate(t *testing.T) {
+
+	th := &Throttle{Rate: 100, Burst: 2}
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := th.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 2 at once, then 4 more at 10ms intervals
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Errorf("rate limit not applied, took %v", d)
+	}
+}
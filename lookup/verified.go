@@ -0,0 +1,104 @@
+//go:build !tinygo
+
+package lookup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/peterstark72/gtin"
+)
+
+// VerifiedURL is the endpoint of the Verified by GS1 API
+const VerifiedURL = "https://grp.gs1.org/grp/v3.1/gtins/verified"
+
+// VerifiedClient is a client for the GS1 "Verified by GS1" API
+// https://www.gs1.org/services/verified-by-gs1
+type VerifiedClient struct {
+	APIKey     string
+	URL        string       // Defaults to VerifiedURL
+	HTTPClient *http.Client // Defaults to http.DefaultClient
+	Throttle   *Throttle
+}
+
+// NewVerifiedClient returns a client using the given API key
+func NewVerifiedClient(apiKey string) *VerifiedClient {
+	return &VerifiedClient{APIKey: apiKey, URL: VerifiedURL}
+}
+
+// LanguageValue is a text in a given language
+type LanguageValue struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+// Licence is the GS1 licence the GTIN was allocated from
+type Licence struct {
+	LicenceKey   string `json:"licenceKey"`
+	LicenceType  string `json:"licenceType"`
+	LicenseeName string `json:"licenseeName"`
+	LicenseeGLN  string `json:"licenseeGLN"`
+	LicensingMO  struct {
+		MOName string `json:"moName"`
+	} `json:"licensingMO"`
+}
+
+// Verification is the registry record of a GTIN
+type Verification struct {
+	GTIN               string          `json:"gtin"`
+	Status             string          `json:"gtinRecordStatus"`
+	IsComplete         bool            `json:"isComplete"`
+	BrandName          []LanguageValue `json:"brandName"`
+	ProductDescription []LanguageValue `json:"productDescription"`
+	ProductImageURL    []LanguageValue `json:"productImageUrl"`
+	GPCCategoryCode    string          `json:"gpcCategoryCode"`
+	Licence            *Licence        `json:"gs1Licence"`
+}
+
+// LicenseeName returns the name of the company the GTIN is licensed to
+func (v *Verification) LicenseeName() string {
+	if v.Licence == nil {
+		return ""
+	}
+	return v.Licence.LicenseeName
+}
+
+// Brand returns the first brand name
+func (v *Verification) Brand() string {
+	if len(v.BrandName) == 0 {
+		return ""
+	}
+	return v.BrandName[0].Value
+}
+
+// Verify looks up the GTIN in the GS1 registry. It returns ErrNotFound if
+// the GTIN is not licensed.
+func (c *VerifiedClient) Verify(ctx context.Context, gt gtin.GTIN) (*Verification, error) {
+
+	body, err := json.Marshal([]string{gt.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.URL
+	if url == "" {
+		url = VerifiedURL
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("APIKey", c.APIKey)
+
+	var result []Verification
+	if err := doJSON(ctx, c.HTTPClient, c.Throttle, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || result[0].Licence == nil {
+		return nil, ErrNotFound
+	}
+	return &result[0], nil
+}
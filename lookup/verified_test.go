@@ -0,0 +1,56 @@
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestVerify(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("APIKey") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var gtins []string
+		json.NewDecoder(r.Body).Decode(&gtins)
+		if gtins[0] != "04006381333931" {
+			w.Write([]byte(`[{"gtin":"` + gtins[0] + `"}]`))
+			return
+		}
+		w.Write([]byte(`[{
+			"gtin": "04006381333931",
+			"gtinRecordStatus": "ACTIVE",
+			"isComplete": true,
+			"brandName": [{"language": "de", "value": "STABILO"}],
+			"gs1Licence": {"licenceKey": "4006381", "licenseeName": "STABILO International GmbH"}
+		}]`))
+	}))
+	defer srv.Close()
+
+	c := &VerifiedClient{APIKey: "secret", URL: srv.URL}
+
+	v, err := c.Verify(context.Background(), gtin.MustParse("4006381333931"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.LicenseeName() != "STABILO International GmbH" || v.Brand() != "STABILO" || v.Status != "ACTIVE" {
+		t.Errorf("wrong result %+v", v)
+	}
+
+	if _, err := c.Verify(context.Background(), gtin.MustParse("614141000012")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("wanted %v, got %v", ErrNotFound, err)
+	}
+
+	c.APIKey = "wrong"
+	var apiErr *APIError
+	if _, err := c.Verify(context.Background(), gtin.MustParse("4006381333931")); !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Errorf("wanted HTTP 401, got %v", err)
+	}
+}
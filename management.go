@@ -0,0 +1,95 @@
+package gtin
+
+import "sort"
+
+// ProductAttributes are the attributes of a trade item that the GS1 GTIN
+// Management Standard bases allocation decisions on. Zero values mean the
+// attribute is not set; dimensions and weights may be in any unit, as long
+// as old and new use the same.
+type ProductAttributes struct {
+	Brand          string  // Primary brand
+	NetContent     float64 // Declared net content
+	NetContentUnit string  // e.g. "g" or "ml"
+	Width          float64 // Dimensions of the item
+	Height         float64
+	Depth          float64
+	GrossWeight    float64
+	Formulation    string   // Identifies the declared functionality or formulation
+	PackQuantity   int      // Number of items in a pack or grouping
+	Certifications []string // Certification marks on the pack
+	Promotion      string   // Time-critical promotion, e.g. "Christmas 2026"
+	PriceOnPack    float64  // Price marked on the pack
+}
+
+// Reason is a GTIN Management Standard rule that requires a new GTIN
+type Reason string
+
+// The reasons for a new GTIN
+// https://www.gs1.org/1/gtinrules/en/
+const (
+	ReasonBrand         Reason = "primary brand change"
+	ReasonNetContent    Reason = "declared net content change"
+	ReasonDimensions    Reason = "dimension change of more than 20%"
+	ReasonGrossWeight   Reason = "gross weight change of more than 20%"
+	ReasonFormulation   Reason = "declared functionality or formulation change"
+	ReasonPackQuantity  Reason = "pack quantity change"
+	ReasonCertification Reason = "certification mark change"
+	ReasonPromotion     Reason = "time-critical promotion"
+	ReasonPriceOnPack   Reason = "price on pack change"
+)
+
+// maxChange is the largest relative change of dimensions and gross weight
+// that keeps the GTIN
+const maxChange = 0.2
+
+// RequiresNewGTIN tells whether changing a trade item from old to new
+// requires a new GTIN under the GS1 GTIN Management Standard, and why.
+// Changes not covered by a rule, such as minor artwork updates, keep the
+// GTIN.
+func RequiresNewGTIN(old, new ProductAttributes) (bool, []Reason) {
+
+	var reasons []Reason
+	add := func(changed bool, reason Reason) {
+		if changed {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	add(old.Brand != new.Brand, ReasonBrand)
+	add(old.NetContent != new.NetContent || old.NetContentUnit != new.NetContentUnit, ReasonNetContent)
+	add(changedMore(old.Width, new.Width) || changedMore(old.Height, new.Height) || changedMore(old.Depth, new.Depth), ReasonDimensions)
+	add(changedMore(old.GrossWeight, new.GrossWeight), ReasonGrossWeight)
+	add(old.Formulation != new.Formulation, ReasonFormulation)
+	add(old.PackQuantity != new.PackQuantity, ReasonPackQuantity)
+	add(!sameSet(old.Certifications, new.Certifications), ReasonCertification)
+	add(new.Promotion != "" && new.Promotion != old.Promotion, ReasonPromotion)
+	add(old.PriceOnPack != new.PriceOnPack, ReasonPriceOnPack)
+
+	return len(reasons) > 0, reasons
+}
+
+// changedMore reports whether a value changed by more than maxChange
+func changedMore(old, new float64) bool {
+	if old == 0 {
+		return new != 0
+	}
+	change := (new - old) / old
+	return change > maxChange || change < -maxChange
+}
+
+// sameSet reports whether a and b hold the same strings, in any order
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for n := range a {
+		if a[n] != b[n] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package gtin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequiresNewGTIN(t *testing.T) {
+
+	old := ProductAttributes{
+		Brand:          "Acme",
+		NetContent:     500,
+		NetContentUnit: "g",
+		Width:          10, Height: 20, Depth: 5,
+		GrossWeight:    520,
+		Formulation:    "v1",
+		PackQuantity:   1,
+		Certifications: []string{"EU Organic", "Fairtrade"},
+	}
+
+	tests := []struct {
+		name   string
+		change func(p *ProductAttributes)
+		want   []Reason
+	}{
+		{"unchanged", func(p *ProductAttributes) {}, nil},
+		{"certifications reordered", func(p *ProductAttributes) { p.Certifications = []string{"Fairtrade", "EU Organic"} }, nil},
+		{"small dimension change", func(p *ProductAttributes) { p.Height = 23 }, nil},
+		{"net content", func(p *ProductAttributes) { p.NetContent = 450 }, []Reason{ReasonNetContent}},
+		{"unit", func(p *ProductAttributes) { p.NetContentUnit = "ml" }, []Reason{ReasonNetContent}},
+		{"large dimension change", func(p *ProductAttributes) { p.Depth = 7 }, []Reason{ReasonDimensions}},
+		{"brand and pack", func(p *ProductAttributes) { p.Brand = "Acme Pro"; p.PackQuantity = 6 }, []Reason{ReasonBrand, ReasonPackQuantity}},
+		{"bonus pack", func(p *ProductAttributes) { p.NetContent = 600; p.GrossWeight = 640; p.Promotion = "20% extra" },
+			[]Reason{ReasonNetContent, ReasonGrossWeight, ReasonPromotion}},
+		{"certification added", func(p *ProductAttributes) { p.Certifications = append(p.Certifications, "MSC") }, []Reason{ReasonCertification}},
+		{"formulation", func(p *ProductAttributes) { p.Formulation = "v2" }, []Reason{ReasonFormulation}},
+		{"price on pack", func(p *ProductAttributes) { p.PriceOnPack = 2.99 }, []Reason{ReasonPriceOnPack}},
+	}
+
+	for _, tt := range tests {
+		new := old
+		new.Certifications = append([]string(nil), old.Certifications...)
+		tt.change(&new)
+		required, reasons := RequiresNewGTIN(old, new)
+		if required != (len(tt.want) > 0) || !reflect.DeepEqual(reasons, tt.want) {
+			t.Errorf("%s: wanted %v, got %v %v", tt.name, tt.want, required, reasons)
+		}
+	}
+
+	// Ending a promotion returns to the regular item
+	promo := old
+	promo.Promotion = "Christmas"
+	if required, _ := RequiresNewGTIN(promo, old); required {
+		t.Error("ending a promotion should not require a new GTIN")
+	}
+}
@@ -0,0 +1,136 @@
+package gtin
+
+import (
+	"errors"
+	"strings"
+)
+
+// The languages of the message catalog
+const (
+	English string = "en"
+	Swedish string = "sv"
+	German  string = "de"
+	French  string = "fr"
+)
+
+// catalog holds user-facing messages for the errors of this package
+var catalog = map[string]map[error]string{
+	English: {
+		ErrLength:            "The GTIN must be 8, 12, 13 or 14 digits long.",
+		ErrDigit:             "The GTIN may only contain digits.",
+		ErrCheckDigit:        "The check digit is wrong.",
+		ErrType:              "The GTIN type is unknown.",
+		ErrPadding:           "The GTIN has digits outside its type.",
+		ErrCarrier:           "The GTIN can not be carried by a barcode.",
+		ErrCompanyPrefix:     "The GTIN has no known GS1 company prefix.",
+		ErrPrefix:            "The GTIN has an unknown GS1 prefix.",
+		ErrNotConvertible:    "The GTIN can not be converted to the requested form.",
+		ErrNotAllowed:        "The GTIN is not allowed by the validation policy.",
+		ErrHierarchy:         "The GTIN does not fit the packaging hierarchy of the base unit.",
+		ErrExhausted:         "All references of the company prefix are used.",
+		ErrCharacter:         "The value contains a character that is not allowed.",
+		ErrRetired:           "The GTIN belongs to a retired trade item and must not be reused.",
+		ErrSymbology:         "The GTIN was scanned from a barcode that must not carry it.",
+		RestrictedPrefix:     "The GTIN uses a prefix reserved for restricted circulation.",
+		CouponPrefix9899:     "The GTIN uses a prefix reserved for coupons.",
+		CouponPrefix05:       "The GTIN uses a prefix reserved for coupons.",
+		GS18RestrictedPrefix: "The GTIN uses a prefix reserved for restricted circulation.",
+		GS18ReservedPrefix:   "The GTIN-8 uses a prefix not allocated by GS1.",
+	},
+	Swedish: {
+		ErrLength:            "GTIN-numret måste vara 8, 12, 13 eller 14 siffror långt.",
+		ErrDigit:             "GTIN-numret får bara innehålla siffror.",
+		ErrCheckDigit:        "Kontrollsiffran är felaktig.",
+		ErrType:              "GTIN-typen är okänd.",
+		ErrPadding:           "GTIN-numret har siffror utanför sin typ.",
+		ErrCarrier:           "GTIN-numret kan inte bäras av en streckkod.",
+		ErrCompanyPrefix:     "GTIN-numret har inget känt GS1-företagsprefix.",
+		ErrPrefix:            "GTIN-numret har ett okänt GS1-prefix.",
+		ErrNotConvertible:    "GTIN-numret kan inte omvandlas till den begärda formen.",
+		ErrNotAllowed:        "GTIN-numret är inte tillåtet enligt valideringsreglerna.",
+		ErrHierarchy:         "GTIN-numret passar inte i basenhetens förpackningshierarki.",
+		ErrExhausted:         "Alla nummer i företagsprefixet är använda.",
+		ErrCharacter:         "Värdet innehåller ett otillåtet tecken.",
+		ErrRetired:           "GTIN-numret tillhör en utgången artikel och får inte återanvändas.",
+		ErrSymbology:         "GTIN-numret lästes från en streckkod som inte får bära det.",
+		RestrictedPrefix:     "GTIN-numret har ett prefix som är reserverat för begränsad användning.",
+		CouponPrefix9899:     "GTIN-numret har ett prefix som är reserverat för kuponger.",
+		CouponPrefix05:       "GTIN-numret har ett prefix som är reserverat för kuponger.",
+		GS18RestrictedPrefix: "GTIN-numret har ett prefix som är reserverat för begränsad användning.",
+		GS18ReservedPrefix:   "GTIN-8-numret har ett prefix som inte har tilldelats av GS1.",
+	},
+	German: {
+		ErrLength:            "Die GTIN muss 8, 12, 13 oder 14 Ziffern lang sein.",
+		ErrDigit:             "Die GTIN darf nur Ziffern enthalten.",
+		ErrCheckDigit:        "Die Prüfziffer ist falsch.",
+		ErrType:              "Der GTIN-Typ ist unbekannt.",
+		ErrPadding:           "Die GTIN hat Ziffern außerhalb ihres Typs.",
+		ErrCarrier:           "Die GTIN kann von keinem Strichcode getragen werden.",
+		ErrCompanyPrefix:     "Die GTIN hat keine bekannte GS1-Basisnummer.",
+		ErrPrefix:            "Die GTIN hat ein unbekanntes GS1-Präfix.",
+		ErrNotConvertible:    "Die GTIN kann nicht in die gewünschte Form umgewandelt werden.",
+		ErrNotAllowed:        "Die GTIN ist nach den Prüfregeln nicht zulässig.",
+		ErrHierarchy:         "Die GTIN passt nicht in die Verpackungshierarchie der Basiseinheit.",
+		ErrExhausted:         "Alle Nummern der Basisnummer sind vergeben.",
+		ErrCharacter:         "Der Wert enthält ein unzulässiges Zeichen.",
+		ErrRetired:           "Die GTIN gehört zu einem ausgelisteten Artikel und darf nicht wiederverwendet werden.",
+		ErrSymbology:         "Die GTIN wurde aus einem Strichcode gelesen, der sie nicht tragen darf.",
+		RestrictedPrefix:     "Die GTIN verwendet ein Präfix für die eingeschränkte Verwendung.",
+		CouponPrefix9899:     "Die GTIN verwendet ein für Coupons reserviertes Präfix.",
+		CouponPrefix05:       "Die GTIN verwendet ein für Coupons reserviertes Präfix.",
+		GS18RestrictedPrefix: "Die GTIN verwendet ein Präfix für die eingeschränkte Verwendung.",
+		GS18ReservedPrefix:   "Die GTIN-8 verwendet ein nicht von GS1 vergebenes Präfix.",
+	},
+	French: {
+		ErrLength:            "Le GTIN doit comporter 8, 12, 13 ou 14 chiffres.",
+		ErrDigit:             "Le GTIN ne doit contenir que des chiffres.",
+		ErrCheckDigit:        "La clé de contrôle est incorrecte.",
+		ErrType:              "Le type de GTIN est inconnu.",
+		ErrPadding:           "Le GTIN a des chiffres en dehors de son type.",
+		ErrCarrier:           "Le GTIN ne peut être porté par aucun code-barres.",
+		ErrCompanyPrefix:     "Le GTIN n'a pas de préfixe d'entreprise GS1 connu.",
+		ErrPrefix:            "Le GTIN a un préfixe GS1 inconnu.",
+		ErrNotConvertible:    "Le GTIN ne peut pas être converti dans la forme demandée.",
+		ErrNotAllowed:        "Le GTIN n'est pas autorisé par les règles de validation.",
+		ErrHierarchy:         "Le GTIN ne correspond pas à la hiérarchie d'emballage de l'unité de base.",
+		ErrExhausted:         "Toutes les références du préfixe d'entreprise sont utilisées.",
+		ErrCharacter:         "La valeur contient un caractère non autorisé.",
+		ErrRetired:           "Le GTIN appartient à un article retiré et ne doit pas être réutilisé.",
+		ErrSymbology:         "Le GTIN a été lu dans un code-barres qui ne doit pas le porter.",
+		RestrictedPrefix:     "Le GTIN utilise un préfixe réservé à la diffusion restreinte.",
+		CouponPrefix9899:     "Le GTIN utilise un préfixe réservé aux coupons.",
+		CouponPrefix05:       "Le GTIN utilise un préfixe réservé aux coupons.",
+		GS18RestrictedPrefix: "Le GTIN utilise un préfixe réservé à la diffusion restreinte.",
+		GS18ReservedPrefix:   "Le GTIN-8 utilise un préfixe non attribué par GS1.",
+	},
+}
+
+// ErrorMessage returns a user-facing message for err in the given language,
+// e.g. "sv" or "sv-SE". Unknown languages fall back to English and errors
+// not in the catalog to err.Error(). Joined errors give one message per line.
+func ErrorMessage(err error, lang string) string {
+
+	if err == nil {
+		return ""
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var lines []string
+		for _, e := range joined.Unwrap() {
+			lines = append(lines, ErrorMessage(e, lang))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	lang, _, _ = strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+	messages, ok := catalog[strings.ToLower(lang)]
+	if !ok {
+		messages = catalog[English]
+	}
+	for target, message := range messages {
+		if errors.Is(err, target) {
+			return message
+		}
+	}
+	return err.Error()
+}
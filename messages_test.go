@@ -0,0 +1,28 @@
+package gtin
+
+import "testing"
+
+func TestErrorMessage(t *testing.T) {
+
+	_, err := Atog("12a4")
+
+	tests := []struct {
+		err  error
+		lang string
+		want string
+	}{
+		{ErrCheckDigit, "sv", "Kontrollsiffran är felaktig."},
+		{ErrCheckDigit, "de-DE", "Die Prüfziffer ist falsch."},
+		{ErrCheckDigit, "fr_FR", "La clé de contrôle est incorrecte."},
+		{ErrCheckDigit, "xx", "The check digit is wrong."},
+		{CouponPrefix05, "en", "The GTIN uses a prefix reserved for coupons."},
+		{err, "en", "The GTIN must be 8, 12, 13 or 14 digits long.\nThe GTIN may only contain digits."},
+		{nil, "en", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorMessage(tt.err, tt.lang); got != tt.want {
+			t.Errorf("wanted %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package gtin
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Transfer records the company that licensed a company prefix for a
+// period. Prefixes move between companies when they are sold, merge or
+// lapse and are reallocated.
+type Transfer struct {
+	Prefix CompanyPrefix
+	Owner  string    // E.g. the GLN or name of the company
+	From   time.Time // Start of the license
+	To     time.Time // End of the license, exclusive; zero while it lasts
+}
+
+// valid reports if the transfer covers the time
+func (t Transfer) valid(at time.Time) bool {
+	return !at.Before(t.From) && (t.To.IsZero() || at.Before(t.To))
+}
+
+// Ownership is a lookup of the owners of company prefixes over time, so
+// that past shipments can be attributed to the company that owned the
+// prefix when they shipped
+type Ownership struct {
+	transfers map[CompanyPrefix][]Transfer // By From
+}
+
+// NewOwnership returns the lookup of the transfers. The periods of a
+// prefix must not overlap.
+func NewOwnership(transfers ...Transfer) (*Ownership, error) {
+
+	o := &Ownership{transfers: make(map[CompanyPrefix][]Transfer)}
+	for _, t := range transfers {
+		if !t.To.IsZero() && !t.To.After(t.From) {
+			return nil, fmt.Errorf("%w: transfer of %s to %s ends before it starts", ErrCompanyPrefix, t.Prefix, t.Owner)
+		}
+		o.transfers[t.Prefix] = append(o.transfers[t.Prefix], t)
+	}
+	for p, ts := range o.transfers {
+		sort.Slice(ts, func(i, j int) bool { return ts[i].From.Before(ts[j].From) })
+		for n := 1; n < len(ts); n++ {
+			if ts[n-1].To.IsZero() || ts[n-1].To.After(ts[n].From) {
+				return nil, fmt.Errorf("%w: transfers of %s to %s and %s overlap", ErrCompanyPrefix, p, ts[n-1].Owner, ts[n].Owner)
+			}
+		}
+	}
+	return o, nil
+}
+
+// Owner returns the transfer of the company prefix of the GTIN that was
+// valid at the time. Of nested prefixes, the longest with an owner at the
+// time wins.
+func (o *Ownership) Owner(gt GTIN, at time.Time) (Transfer, bool) {
+
+	if gt.IsZero() || gt.MinimalType() == GTIN8 {
+		return Transfer{}, false
+	}
+	digits := gt.String()[1:]
+	for length := 12; length >= 4; length-- {
+		for _, t := range o.transfers[CompanyPrefix(digits[:length])] {
+			if t.valid(at) {
+				return t, true
+			}
+		}
+	}
+	return Transfer{}, false
+}
+
+// History returns the transfers of the company prefix, oldest first
+func (o *Ownership) History(p CompanyPrefix) []Transfer {
+	return append([]Transfer(nil), o.transfers[p]...)
+}
@@ -0,0 +1,57 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOwnership(t *testing.T) {
+
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	o, err := NewOwnership(
+		Transfer{"400638", "Acme", date("2001-01-01"), date("2015-07-01")},
+		Transfer{"400638", "Globex", date("2015-07-01"), time.Time{}},
+		Transfer{"4006381", "Initech", date("2020-01-01"), time.Time{}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		gtin  string
+		at    string
+		owner string
+	}{
+		{"4006382000009", "2010-05-01", "Acme"},
+		{"4006382000009", "2015-07-01", "Globex"},
+		{"04006382000009", "2024-01-01", "Globex"}, // Any form of the GTIN
+		{"4006381333931", "2019-12-31", "Globex"},
+		{"4006381333931", "2020-01-01", "Initech"},
+		{"4006382000009", "2000-12-31", ""},
+		{"96385074", "2020-01-01", ""},
+	}
+	for _, tt := range tests {
+		got, ok := o.Owner(MustParse(tt.gtin), date(tt.at))
+		if ok != (tt.owner != "") || got.Owner != tt.owner {
+			t.Errorf("%s at %s: got %v, %v", tt.gtin, tt.at, got, ok)
+		}
+	}
+	if h := o.History("400638"); len(h) != 2 || h[0].Owner != "Acme" {
+		t.Errorf("got %v", h)
+	}
+
+	_, err = NewOwnership(
+		Transfer{"400638", "Acme", date("2001-01-01"), time.Time{}},
+		Transfer{"400638", "Globex", date("2015-07-01"), time.Time{}},
+	)
+	if !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("wanted ErrCompanyPrefix for overlapping transfers, got %v", err)
+	}
+	if _, err := NewOwnership(Transfer{"400638", "Acme", date("2001-01-01"), date("2000-01-01")}); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("wanted ErrCompanyPrefix, got %v", err)
+	}
+}
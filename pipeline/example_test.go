@@ -0,0 +1,35 @@
+//go:build !tinygo
+
+package pipeline_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterstark72/gtin"
+	"github.com/peterstark72/gtin/lookup"
+	"github.com/peterstark72/gtin/lookup/lookuptest"
+	"github.com/peterstark72/gtin/pipeline"
+)
+
+func Example() {
+
+	srv := lookuptest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(lookup.ProductInfo{GTIN: gtin.MustParse("4006381333931"), Name: "Stabilo Boss"})
+	client := srv.OpenFoodFacts()
+
+	ctx := context.Background()
+	inputs := []string{"4006381333931", "4006381333932"}
+
+	codes := pipeline.Strings(ctx, inputs)
+	items := pipeline.Parse[*lookup.ProductInfo](ctx, codes)
+	items = pipeline.Validate(ctx, items, gtin.DefaultPolicy())
+	items = pipeline.Enrich(ctx, items, 8, client.Product)
+	items = pipeline.Filter(ctx, items, pipeline.Valid[*lookup.ProductInfo])
+	for item := range items {
+		fmt.Println(item.GTIN, item.Data.Name)
+	}
+	// Output:
+	// 04006381333931 Stabilo Boss
+}
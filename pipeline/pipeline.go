@@ -0,0 +1,171 @@
+/*
+Package pipeline provides stages for GTIN processing graphs, connected by
+channels:
+
+	codes := pipeline.Strings(ctx, inputs)
+	items := pipeline.Parse[*lookup.ProductInfo](ctx, codes)
+	items = pipeline.Validate(ctx, items, gtin.DefaultPolicy())
+	items = pipeline.Enrich(ctx, items, 8, client.Product)
+	items = pipeline.Filter(ctx, items, pipeline.Valid[*lookup.ProductInfo])
+	for item := range items {
+		...
+	}
+
+Every stage runs in its own goroutines and closes its output when its
+input is closed or the context is cancelled. Items that failed a stage
+pass the later stages unchanged, so failures can be reported at the end;
+drop them with Filter.
+*/
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Item is a code flowing through a pipeline, with what the stages found
+// out about it. Data is set by Enrich.
+type Item[T any] struct {
+	N      int // Position in the input, starting at 0
+	Input  string
+	GTIN   gtin.GTIN
+	Report gtin.Report
+	Data   T
+	Err    error // The error of the stage that failed, if any
+}
+
+// send sends v on out, unless ctx is cancelled first
+func send[V any](ctx context.Context, out chan<- V, v V) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Strings returns a channel of the inputs
+func Strings(ctx context.Context, inputs []string) <-chan string {
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		for _, input := range inputs {
+			if !send(ctx, out, input) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Parse parses the codes of in with gtin.Parse and the options
+func Parse[T any](ctx context.Context, in <-chan string, opts ...gtin.Option) <-chan Item[T] {
+	out := make(chan Item[T])
+	go func() {
+		defer close(out)
+		n := 0
+		for input := range in {
+			item := Item[T]{N: n, Input: input}
+			item.GTIN, item.Err = gtin.Parse(input, opts...)
+			n++
+			if !send(ctx, out, item) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// stage passes the items of in through f, skipping failed ones
+func stage[T any](ctx context.Context, in <-chan Item[T], f func(*Item[T])) <-chan Item[T] {
+	out := make(chan Item[T])
+	go func() {
+		defer close(out)
+		for item := range in {
+			if item.Err == nil {
+				f(&item)
+			}
+			if !send(ctx, out, item) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Validate validates the GTINs with the policy and sets their reports.
+// Items failing an error rule fail with the errors joined.
+func Validate[T any](ctx context.Context, in <-chan Item[T], policy gtin.Policy) <-chan Item[T] {
+	return stage(ctx, in, func(item *Item[T]) {
+		item.Report = policy.Validate(item.GTIN)
+		item.Err = errors.Join(item.Report.Errors...)
+	})
+}
+
+// Filter passes on the items for which keep returns true, including
+// failed ones. See Valid.
+func Filter[T any](ctx context.Context, in <-chan Item[T], keep func(Item[T]) bool) <-chan Item[T] {
+	out := make(chan Item[T])
+	go func() {
+		defer close(out)
+		for item := range in {
+			if keep(item) && !send(ctx, out, item) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Valid keeps the items that did not fail, for Filter
+func Valid[T any](item Item[T]) bool {
+	return item.Err == nil
+}
+
+// Enrich sets the data of the items to the result of lookup, e.g. a
+// product from a lookup client, calling it from up to workers goroutines.
+// The items keep their order. Items fail with the error of lookup.
+func Enrich[T any](ctx context.Context, in <-chan Item[T], workers int, lookup func(context.Context, gtin.GTIN) (T, error)) <-chan Item[T] {
+
+	if workers < 1 {
+		workers = 1
+	}
+	// Each item gets a channel for its result, queued in input order
+	queue := make(chan chan Item[T], workers)
+	busy := make(chan struct{}, workers)
+	go func() {
+		defer close(queue)
+		for item := range in {
+			result := make(chan Item[T], 1)
+			if !send(ctx, queue, result) || !send(ctx, busy, struct{}{}) {
+				return
+			}
+			go func(item Item[T]) {
+				defer func() { <-busy }()
+				if item.Err == nil {
+					item.Data, item.Err = lookup(ctx, item.GTIN)
+				}
+				result <- item
+			}(item)
+		}
+	}()
+
+	out := make(chan Item[T])
+	go func() {
+		defer close(out)
+		for result := range queue {
+			var item Item[T]
+			select {
+			case item = <-result:
+			case <-ctx.Done():
+				return
+			}
+			if !send(ctx, out, item) {
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterstark72/gtin"
+)
+
+func collect[T any](items <-chan Item[T]) []Item[T] {
+	var all []Item[T]
+	for item := range items {
+		all = append(all, item)
+	}
+	return all
+}
+
+func TestPipeline(t *testing.T) {
+
+	ctx := context.Background()
+	inputs := []string{"4006381333931", "4006381333932", "2001234567893", "614141000012", "x"}
+	errLookup := errors.New("no such product")
+
+	var running, most atomic.Int32
+	lookup := func(ctx context.Context, gt gtin.GTIN) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for m := most.Load(); n > m && !most.CompareAndSwap(m, n); m = most.Load() {
+		}
+		// Later items finish first
+		time.Sleep(time.Duration(20-gt.At(12)) * time.Millisecond)
+		if gt.Short() == "614141000012" {
+			return "", errLookup
+		}
+		return "product " + gt.Short(), nil
+	}
+
+	policy := gtin.DefaultPolicy()
+	policy.Errors = append(policy.Errors, gtin.AllowTypes(gtin.GTIN12, gtin.GTIN13))
+	items := collect(Enrich(ctx, Validate(ctx, Parse[string](ctx, Strings(ctx, inputs)), policy), 2, lookup))
+
+	if len(items) != len(inputs) {
+		t.Fatalf("got %+v", items)
+	}
+	for n, item := range items {
+		if item.N != n || item.Input != inputs[n] {
+			t.Errorf("%d: out of order: %+v", n, item)
+		}
+	}
+	if items[0].Err != nil || items[0].Data != "product 4006381333931" {
+		t.Errorf("got %+v", items[0])
+	}
+	if !errors.Is(items[1].Err, gtin.ErrCheckDigit) || items[1].Data != "" {
+		t.Errorf("wanted ErrCheckDigit, got %+v", items[1])
+	}
+	if items[2].Err != nil || len(items[2].Report.Warnings) == 0 {
+		t.Errorf("wanted warnings, got %+v", items[2])
+	}
+	if !errors.Is(items[3].Err, errLookup) {
+		t.Errorf("wanted lookup error, got %+v", items[3])
+	}
+	if items[4].Err == nil {
+		t.Errorf("wanted parse error, got %+v", items[4])
+	}
+	if most.Load() > 2 {
+		t.Errorf("%d concurrent lookups, wanted at most 2", most.Load())
+	}
+
+	valid := collect(Filter(ctx, Parse[string](ctx, Strings(ctx, inputs)), Valid[string]))
+	if len(valid) != 3 || valid[0].N != 0 || valid[1].N != 2 || valid[2].N != 3 {
+		t.Errorf("got %+v", valid)
+	}
+}
+
+func TestCancel(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs := make([]string, 1000)
+	for n := range inputs {
+		inputs[n] = "4006381333931"
+	}
+	block := func(ctx context.Context, gt gtin.GTIN) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	items := Filter(ctx, Enrich(ctx, Validate(ctx, Parse[int](ctx, Strings(ctx, inputs)), gtin.DefaultPolicy()), 4, block), Valid[int])
+
+	cancel()
+	done := make(chan int)
+	go func() {
+		done <- len(collect(items))
+	}()
+	select {
+	case n := <-done:
+		if n > 0 {
+			t.Errorf("got %d items after cancellation", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pipeline did not stop")
+	}
+}
@@ -0,0 +1,151 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule is a check a GTIN must pass. Check returns nil if the GTIN passes.
+// Rules only see well-formed GTINs; the structure of the digits is checked
+// before any rule runs.
+type Rule interface {
+	Check(gt GTIN) error
+}
+
+// RuleFunc adapts a function to a Rule
+type RuleFunc func(gt GTIN) error
+
+// Check calls f(gt)
+func (f RuleFunc) Check(gt GTIN) error {
+	return f(gt)
+}
+
+// The built-in rules
+var (
+	// CheckDigitRule requires a correct check digit
+	CheckDigitRule Rule = RuleFunc(checkCheckDigit)
+
+	// LegalPrefixRule requires a GS1 prefix that is legal in open trade,
+	// failing with the broken LegalityResult. See PrefixRule to allow some
+	// restricted ranges.
+	LegalPrefixRule Rule = PrefixRule{}
+
+	// CarrierRule requires a data carrier
+	CarrierRule Rule = RuleFunc(func(gt GTIN) error {
+		if gt.Carrier() == UNKNOWN {
+			return fmt.Errorf("%w for %s", ErrCarrier, gt.typ)
+		}
+		return nil
+	})
+)
+
+// PrefixRule is a configurable LegalPrefixRule for systems that legitimately
+// use some restricted ranges, e.g. warehouse systems with 2x store codes.
+// GTINs breaking a prefix rule pass if the broken rule is in Allowed, or if
+// they start with one of Ranges.
+type PrefixRule struct {
+	Allowed []LegalityResult
+	Ranges  []string // Allowed prefixes of the GTIN-13 form, or of a GTIN-8
+}
+
+// Check returns the broken LegalityResult, unless it is allowed
+func (r PrefixRule) Check(gt GTIN) error {
+	legality := gt.Legality()
+	if legality == PrefixLegal {
+		return nil
+	}
+	for _, allowed := range r.Allowed {
+		if legality == allowed {
+			return nil
+		}
+	}
+	digits := prefixDigits(gt)
+	for _, prefix := range r.Ranges {
+		if strings.HasPrefix(digits, prefix) {
+			return nil
+		}
+	}
+	return legality
+}
+
+// Legal is like GTIN.Legal, but with the allowed rules and ranges
+func (r PrefixRule) Legal(gt GTIN) bool {
+	return r.Check(gt) == nil
+}
+
+// prefixDigits returns the digits GS1 prefixes are matched against: the
+// GTIN-13 form, or the 8 digits of a GTIN-8
+func prefixDigits(gt GTIN) string {
+	if gt.MinimalType() == GTIN8 {
+		return gt.String()[GTIN_LENGTH-8:]
+	}
+	return gt.String()[1:]
+}
+
+// AllowTypes returns a rule that only lets GTINs of the given types pass
+func AllowTypes(types ...Type) Rule {
+	return RuleFunc(func(gt GTIN) error {
+		for _, typ := range types {
+			if gt.typ == typ {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: type %s", ErrNotAllowed, gt.typ)
+	})
+}
+
+// AllowCompanyPrefixes returns a rule that only lets GTINs starting with
+// one of the prefixes pass. Prefixes are matched against the GTIN-13 form,
+// or the 8 digits of a GTIN-8, so no GCP length table is needed.
+func AllowCompanyPrefixes(prefixes ...string) Rule {
+	return RuleFunc(func(gt GTIN) error {
+		digits := prefixDigits(gt)
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(digits, prefix) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: company prefix of %s", ErrNotAllowed, digits)
+	})
+}
+
+// Policy is a validation profile. GTINs failing any of the Errors rules
+// are invalid; failed Warnings rules flag questionable GTINs.
+type Policy struct {
+	Errors   []Rule
+	Warnings []Rule
+}
+
+// DefaultPolicy returns the policy of GTIN.Validate: the check digit is an
+// error, GS1 prefix rules and missing data carriers are warnings. Extend it
+// to build your own profile.
+func DefaultPolicy() Policy {
+	return Policy{
+		Errors:   []Rule{CheckDigitRule},
+		Warnings: []Rule{LegalPrefixRule, CarrierRule},
+	}
+}
+
+// Validate checks the structure of the GTIN and then runs every rule of
+// the policy, reporting all problems found
+func (p Policy) Validate(gt GTIN) Report {
+
+	var r Report
+
+	if err := checkStructure(gt); err != nil {
+		r.Errors = append(r.Errors, err)
+		// The rules need well-formed digits
+		return r
+	}
+	for _, rule := range p.Errors {
+		if err := rule.Check(gt); err != nil {
+			r.Errors = append(r.Errors, err)
+		}
+	}
+	for _, rule := range p.Warnings {
+		if err := rule.Check(gt); err != nil {
+			r.Warnings = append(r.Warnings, err)
+		}
+	}
+	return r
+}
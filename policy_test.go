@@ -0,0 +1,104 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicy(t *testing.T) {
+
+	policy := DefaultPolicy()
+	policy.Errors = append(policy.Errors, AllowTypes(GTIN13, GTIN14), AllowCompanyPrefixes("4006381", "0614141", "9638"))
+
+	tests := []struct {
+		code     string
+		errors   []error
+		warnings []error
+	}{
+		{"4006381333931", nil, nil},
+		{"4006381333932", []error{ErrCheckDigit}, nil},
+		{"614141000012", []error{ErrNotAllowed}, nil},
+		{"00614141000012", nil, nil},
+		{"96385074", []error{ErrNotAllowed}, nil},
+		{"7350053850019", []error{ErrNotAllowed}, nil},
+		{"50614141000994", nil, nil},
+	}
+
+	for _, tt := range tests {
+		gt, _ := Atog(tt.code)
+		r := policy.Validate(gt)
+		if len(r.Errors) != len(tt.errors) || len(r.Warnings) != len(tt.warnings) {
+			t.Errorf("%s: wanted %v and %v, got %v", tt.code, tt.errors, tt.warnings, r)
+			continue
+		}
+		for n, err := range tt.errors {
+			if !errors.Is(r.Errors[n], err) {
+				t.Errorf("%s: wanted %v, got %v", tt.code, err, r.Errors[n])
+			}
+		}
+	}
+}
+
+func TestPolicyWarnings(t *testing.T) {
+
+	// Internal systems may accept restricted prefixes but not coupons
+	policy := Policy{
+		Errors: []Rule{CheckDigitRule, RuleFunc(func(gt GTIN) error {
+			if gt.Legality() == CouponPrefix05 || gt.Legality() == CouponPrefix9899 {
+				return gt.Legality()
+			}
+			return nil
+		})},
+		Warnings: []Rule{LegalPrefixRule},
+	}
+
+	r := policy.Validate(MustParse("2001234567893"))
+	if !r.OK() || len(r.Warnings) != 1 || r.Warnings[0] != RestrictedPrefix {
+		t.Errorf("wanted restricted prefix warning, got %v", r)
+	}
+	r = policy.Validate(MustParse("9912345678909"))
+	if r.OK() || r.Errors[0] != CouponPrefix9899 {
+		t.Errorf("wanted coupon error, got %v", r)
+	}
+
+	// Structure is checked before any rule
+	r = policy.Validate(GTIN{})
+	if len(r.Errors) != 1 || !errors.Is(r.Errors[0], ErrType) {
+		t.Errorf("wanted type error, got %v", r)
+	}
+}
+
+func TestPrefixRule(t *testing.T) {
+
+	tests := []struct {
+		rule PrefixRule
+		code string
+		want error
+	}{
+		{PrefixRule{}, "2001234567893", RestrictedPrefix},
+		{PrefixRule{Ranges: []string{"2"}}, "2001234567893", nil},
+		{PrefixRule{Ranges: []string{"2"}}, "0212345678909", RestrictedPrefix},
+		{PrefixRule{Allowed: []LegalityResult{RestrictedPrefix}}, "0212345678909", nil},
+		{PrefixRule{Allowed: []LegalityResult{RestrictedPrefix}}, "9912345678909", CouponPrefix9899},
+		{PrefixRule{Ranges: []string{"2"}}, "20000004", nil},
+		{PrefixRule{Ranges: []string{"0"}}, "20000004", GS18RestrictedPrefix},
+		{PrefixRule{}, "4006381333931", nil},
+	}
+
+	for _, tt := range tests {
+		gt := MustParse(tt.code)
+		if got := tt.rule.Check(gt); got != tt.want {
+			t.Errorf("%+v %s: wanted %v, got %v", tt.rule, tt.code, tt.want, got)
+		}
+		if tt.rule.Legal(gt) != (tt.want == nil) {
+			t.Errorf("%+v %s: wrong Legal", tt.rule, tt.code)
+		}
+	}
+
+	// Whitelisted ranges in a policy
+	policy := DefaultPolicy()
+	policy.Warnings[0] = PrefixRule{Ranges: []string{"2"}}
+	if r := policy.Validate(MustParse("2001234567893")); !r.OK() || len(r.Warnings) != 0 {
+		t.Errorf("wanted no warnings, got %v", r)
+	}
+}
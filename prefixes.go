@@ -0,0 +1,67 @@
+package gtin
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+)
+
+// GS1Prefixes maps 3-digit GS1 prefix ranges to the GS1 member
+// organization, or special use, they are allocated to
+// https://www.gs1.org/standards/id-keys/company-prefix
+type GS1Prefixes struct {
+	Date   string
+	ranges []prefixRange // Sorted by from
+}
+
+type prefixRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Name string `json:"name"`
+}
+
+// Lookup returns the name for a number in GTIN-13 format, using its first
+// three digits
+func (t *GS1Prefixes) Lookup(digits string) (string, bool) {
+	if len(digits) < 3 {
+		return "", false
+	}
+	prefix := digits[:3]
+	n := sort.Search(len(t.ranges), func(i int) bool { return t.ranges[i].To >= prefix })
+	if n < len(t.ranges) && t.ranges[n].From <= prefix {
+		return t.ranges[n].Name, true
+	}
+	return "", false
+}
+
+// gs1Prefixes is the table used by MemberOrganization
+var gs1Prefixes atomic.Pointer[GS1Prefixes]
+
+// SetGS1Prefixes replaces the table used by MemberOrganization. Setting
+// nil restores the embedded snapshot.
+func SetGS1Prefixes(t *GS1Prefixes) {
+	gs1Prefixes.Store(t)
+}
+
+// MemberOrganization returns the GS1 member organization that allocated
+// the GS1 prefix of the GTIN, or its special use, e.g. "GS1 Sweden" or
+// "Bookland (ISBN)"
+func (gt GTIN) MemberOrganization() (string, error) {
+	return memberOrganization(currentGS1Prefixes(), gt)
+}
+
+// memberOrganization returns the member organization of the GTIN from the
+// table
+func memberOrganization(t *GS1Prefixes, gt GTIN) (string, error) {
+
+	if gt.IsZero() {
+		return "", ErrType
+	}
+
+	digits := prefixDigits(gt)
+	name, ok := t.Lookup(digits)
+	if !ok {
+		return "", fmt.Errorf("%w %s", ErrPrefix, digits[:3])
+	}
+	return name, nil
+}
@@ -0,0 +1,30 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemberOrganization(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want string
+		err  error
+	}{
+		{"7350053850019", "GS1 Sweden", nil},
+		{"4006381333931", "GS1 Germany", nil},
+		{"614141000012", "GS1 US", nil},
+		{"9780306406157", "Bookland (ISBN)", nil},
+		{"96385074", "GS1 Global Office (GTIN-8)", nil},
+		{"50614141000994", "GS1 US", nil},
+		{"1401234567892", "", ErrPrefix},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.got).MemberOrganization()
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("%v: wanted %v %v, got %v %v", tt.got, tt.want, tt.err, got, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package gtin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Profile is the validation policy of a sales channel for the gtin field of
+// product feeds, and the form it expects GTINs in
+type Profile struct {
+	Name   string
+	Policy Policy
+	Pad    bool // GTINs are submitted as 14 digits
+}
+
+// Format returns the GTIN in the form of the profile: 14 digits if Pad,
+// else the digits of its type
+func (p Profile) Format(gt GTIN) string {
+	if p.Pad {
+		return gt.String()
+	}
+	return gt.String()[GTIN_LENGTH-gt.typ.Len():]
+}
+
+// Names of the built-in profiles
+const (
+	GoogleMerchant = "google"
+	Amazon         = "amazon"
+)
+
+// profiles are the built-in profiles by name
+var profiles = map[string]func() Profile{
+
+	// Google Merchant Center accepts GTIN-8, 12, 13 and 14, including
+	// ISBNs of the Bookland prefixes 978 and 979, and normalizes them to 14
+	// digits. Restricted and coupon prefixes are rejected.
+	GoogleMerchant: func() Profile {
+		return Profile{
+			Name: GoogleMerchant,
+			Policy: Policy{
+				Errors: []Rule{CheckDigitRule, LegalPrefixRule, AllowTypes(GTIN8, GTIN12, GTIN13, GTIN14)},
+			},
+			Pad: true,
+		}
+	},
+
+	// Amazon accepts UPCs, EANs and GTIN-14s whose GS1 prefix is known, and
+	// no GTIN-8. Books with Bookland prefixes are listed with the ISBN
+	// product ID type instead.
+	Amazon: func() Profile {
+		return Profile{
+			Name: Amazon,
+			Policy: Policy{
+				Errors:   []Rule{CheckDigitRule, LegalPrefixRule, AllowTypes(GTIN12, GTIN13, GTIN14), KnownPrefixRule},
+				Warnings: []Rule{booklandRule},
+			},
+		}
+	},
+}
+
+// KnownPrefixRule requires a GS1 prefix of a known member organization,
+// see GTIN.MemberOrganization
+var KnownPrefixRule Rule = RuleFunc(func(gt GTIN) error {
+	_, err := gt.MemberOrganization()
+	return err
+})
+
+// booklandRule flags GTINs of the Bookland prefixes 978 and 979
+var booklandRule Rule = RuleFunc(func(gt GTIN) error {
+	if digits := prefixDigits(gt); gt.MinimalType() != GTIN8 && (strings.HasPrefix(digits, "978") || strings.HasPrefix(digits, "979")) {
+		return fmt.Errorf("%w: Bookland prefix %s, list as ISBN", ErrNotAllowed, digits[:3])
+	}
+	return nil
+})
+
+// LookupProfile returns the built-in profile of the given name,
+// case-insensitive. Each call returns a new profile to extend.
+func LookupProfile(name string) (Profile, bool) {
+	profile, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		return Profile{}, false
+	}
+	return profile(), true
+}
+
+// Profiles returns the names of the built-in profiles
+func Profiles() []string {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
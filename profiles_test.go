@@ -0,0 +1,57 @@
+package gtin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+
+	if want := []string{Amazon, GoogleMerchant}; !reflect.DeepEqual(Profiles(), want) {
+		t.Errorf("wanted %v, got %v", want, Profiles())
+	}
+	if _, ok := LookupProfile("ebay"); ok {
+		t.Error("wanted no ebay profile")
+	}
+
+	google, _ := LookupProfile("Google")
+	amazon, _ := LookupProfile(Amazon)
+
+	for _, test := range []struct {
+		code           string
+		google, amazon error // First error, nil if valid
+	}{
+		{"4006381333931", nil, nil},
+		{"614141000012", nil, nil},
+		{"96385074", nil, ErrNotAllowed},
+		{"9780306406157", nil, nil}, // Bookland, a warning on Amazon
+		{"2001234567893", RestrictedPrefix, RestrictedPrefix},
+		{"9912345678909", CouponPrefix9899, CouponPrefix9899},
+		{"0512345678900", CouponPrefix05, CouponPrefix05},
+		{"4006381333932", ErrCheckDigit, ErrCheckDigit},
+	} {
+		gt, _ := Atog(test.code)
+		for name, want := range map[string]error{GoogleMerchant: test.google, Amazon: test.amazon} {
+			profile, _ := LookupProfile(name)
+			report := profile.Policy.Validate(gt)
+			var got error
+			if len(report.Errors) > 0 {
+				got = report.Errors[0]
+			}
+			if want == nil && got != nil || want != nil && !errors.Is(got, want) {
+				t.Errorf("%s on %s: wanted %v, got %v", test.code, name, want, got)
+			}
+		}
+	}
+
+	report := amazon.Policy.Validate(MustParse("9780306406157"))
+	if len(report.Warnings) != 1 || !errors.Is(report.Warnings[0], ErrNotAllowed) {
+		t.Errorf("wanted a Bookland warning, got %v", report.Warnings)
+	}
+
+	gt := MustParse("614141000012")
+	if google.Format(gt) != "00614141000012" || amazon.Format(gt) != "614141000012" {
+		t.Errorf("got %s and %s", google.Format(gt), amazon.Format(gt))
+	}
+}
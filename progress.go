@@ -0,0 +1,26 @@
+package gtin
+
+import "time"
+
+// Progress is the state of a long-running bulk operation, e.g. a catalog
+// audit, as reported to a ProgressFunc
+type Progress struct {
+	Done    int // Items processed
+	Errors  int // Items that failed so far
+	Total   int // Items in all, or 0 if unknown
+	Elapsed time.Duration
+}
+
+// ProgressFunc is called by bulk operations as items are processed. Calls
+// are never concurrent.
+type ProgressFunc func(Progress)
+
+// ETA returns the estimated time left at the average rate so far. It
+// returns false if the total is unknown or nothing is done yet.
+func (p Progress) ETA() (time.Duration, bool) {
+	if p.Total <= 0 || p.Done <= 0 {
+		return 0, false
+	}
+	left := max(p.Total-p.Done, 0)
+	return p.Elapsed / time.Duration(p.Done) * time.Duration(left), true
+}
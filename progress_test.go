@@ -0,0 +1,24 @@
+package gtin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressETA(t *testing.T) {
+
+	for _, test := range []struct {
+		p   Progress
+		eta time.Duration
+		ok  bool
+	}{
+		{Progress{Done: 25, Total: 100, Elapsed: 10 * time.Second}, 30 * time.Second, true},
+		{Progress{Done: 100, Total: 100, Elapsed: 10 * time.Second}, 0, true},
+		{Progress{Done: 25, Elapsed: 10 * time.Second}, 0, false},
+		{Progress{Total: 100}, 0, false},
+	} {
+		if eta, ok := test.p.ETA(); eta != test.eta || ok != test.ok {
+			t.Errorf("%+v: wanted %v, %v, got %v, %v", test.p, test.eta, test.ok, eta, ok)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package gtin
+
+import (
+	"strings"
+	"sync"
+)
+
+// Registry holds the prefix tables of one tenant, such as a brand owner on
+// a shared platform, in place of the package tables set with
+// SetGS1Prefixes and SetGCPLengths. Registries are independent of each
+// other and safe for concurrent use, but their fields must not be changed
+// after the first lookup.
+type Registry struct {
+	GS1Prefixes *GS1Prefixes // nil uses the package table
+	GCPLengths  *GCPLengths  // nil uses the package table
+
+	// Company prefixes known to the tenant, e.g. from its license
+	// certificates. They override the GCP length table, longest first.
+	Prefixes []CompanyPrefix
+
+	// Restricted ranges and country of Classify and Rule. A nil table
+	// uses DefaultRestrictedRanges.
+	Restricted RestrictedRanges
+	Country    string
+
+	// Number of company prefixes cached, 0 for DefaultRegistryCache
+	CacheSize int
+
+	mu    sync.Mutex
+	cache map[string]string // By GTIN-13 digits
+}
+
+// DefaultRegistryCache is the number of company prefixes a Registry
+// caches by default
+const DefaultRegistryCache = 10000
+
+// CompanyPrefix returns the GS1 Company Prefix of the GTIN, from the
+// registry's prefixes or else its GCP length table
+func (r *Registry) CompanyPrefix(gt GTIN) (string, error) {
+
+	if gt.IsZero() || gt.MinimalType() == GTIN8 {
+		return "", ErrCompanyPrefix
+	}
+	digits := gt.String()[1:]
+
+	r.mu.Lock()
+	prefix, ok := r.cache[digits]
+	r.mu.Unlock()
+	if ok {
+		return prefix, nil
+	}
+
+	prefix = r.known(digits)
+	if prefix == "" {
+		var err error
+		if prefix, err = companyPrefix(r.gcpLengths(), gt); err != nil {
+			return "", err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	size := r.CacheSize
+	if size == 0 {
+		size = DefaultRegistryCache
+	}
+	if r.cache == nil || len(r.cache) >= size {
+		r.cache = make(map[string]string)
+	}
+	r.cache[digits] = prefix
+	return prefix, nil
+}
+
+// SSCCCompanyPrefix returns the GS1 Company Prefix of the SSCC, from the
+// registry's prefixes or else its GCP length table
+func (r *Registry) SSCCCompanyPrefix(s SSCC) (string, error) {
+	if prefix := r.known(s.String()[1:]); prefix != "" && !s.IsZero() {
+		return prefix, nil
+	}
+	return ssccCompanyPrefix(r.gcpLengths(), s)
+}
+
+// ParseCompanyPrefix is like the package ParseCompanyPrefix, but accepts
+// the registry's prefixes and checks others against its GCP length table
+func (r *Registry) ParseCompanyPrefix(input string) (CompanyPrefix, error) {
+	for _, p := range r.Prefixes {
+		if string(p) == input {
+			return p, nil
+		}
+	}
+	return parseCompanyPrefix(r.gcpLengths(), input)
+}
+
+// EPCURI returns the EPC pure identity URI of the SGTIN, with the company
+// prefix of the registry, see SGTIN.EPCURI
+func (r *Registry) EPCURI(s SGTIN) (string, error) {
+	cp, err := r.CompanyPrefix(s.GTIN)
+	if err != nil {
+		return "", err
+	}
+	return s.epcURI(cp), nil
+}
+
+// EPCClassURI returns the EPC class URI of the LGTIN, with the company
+// prefix of the registry, see LGTIN.EPCClassURI
+func (r *Registry) EPCClassURI(l LGTIN) (string, error) {
+	cp, err := r.CompanyPrefix(l.GTIN)
+	if err != nil {
+		return "", err
+	}
+	return l.epcClassURI(cp), nil
+}
+
+// EPCPattern returns the EPC pattern URI of all SGTINs of the GTIN, with
+// the company prefix of the registry, see GTIN.EPCPattern
+func (r *Registry) EPCPattern(gt GTIN) (string, error) {
+	cp, err := r.CompanyPrefix(gt)
+	if err != nil {
+		return "", err
+	}
+	return epcPattern(gt, cp), nil
+}
+
+// MemberOrganization returns the GS1 member organization of the GTIN from
+// the registry's prefix table
+func (r *Registry) MemberOrganization(gt GTIN) (string, error) {
+	if r.GS1Prefixes == nil {
+		return memberOrganization(currentGS1Prefixes(), gt)
+	}
+	return memberOrganization(r.GS1Prefixes, gt)
+}
+
+// Classify returns the restricted range of the GTIN in the registry's
+// country
+func (r *Registry) Classify(gt GTIN) (RestrictedRange, bool) {
+	return r.restricted().Classify(gt, r.Country)
+}
+
+// Rule returns the restricted range rule of the registry's country, see
+// RestrictedRanges.Rule
+func (r *Registry) Rule() Rule {
+	return r.restricted().Rule(r.Country)
+}
+
+// PrefixRule returns a PrefixRule letting the restricted ranges of the
+// registry's country pass, for tenants numbering their own goods in them.
+// Ranges of all countries, such as coupons, are not let through, and
+// without a country the rule is LegalPrefixRule.
+func (r *Registry) PrefixRule() PrefixRule {
+	var rule PrefixRule
+	if r.Country == "" {
+		return rule
+	}
+	for _, rr := range r.restricted() {
+		if strings.EqualFold(rr.Country, r.Country) {
+			rule.Ranges = append(rule.Ranges, rr.Prefix)
+		}
+	}
+	return rule
+}
+
+// KnownPrefixRule is like the package KnownPrefixRule, with the GS1
+// prefix table of the registry
+func (r *Registry) KnownPrefixRule() Rule {
+	return RuleFunc(func(gt GTIN) error {
+		_, err := r.MemberOrganization(gt)
+		return err
+	})
+}
+
+// Policy returns DefaultPolicy with the registry's PrefixRule in place of
+// LegalPrefixRule
+func (r *Registry) Policy() Policy {
+	return Policy{
+		Errors:   []Rule{CheckDigitRule},
+		Warnings: []Rule{r.PrefixRule(), CarrierRule},
+	}
+}
+
+// Reset empties the cache, e.g. after SetGCPLengths changed the package
+// table the registry falls back on
+func (r *Registry) Reset() {
+	r.mu.Lock()
+	r.cache = nil
+	r.mu.Unlock()
+}
+
+// known returns the longest of the registry's prefixes the digits start
+// with, or an empty string
+func (r *Registry) known(digits string) string {
+	var prefix string
+	for _, p := range r.Prefixes {
+		if strings.HasPrefix(digits, string(p)) && len(p) > len(prefix) {
+			prefix = string(p)
+		}
+	}
+	return prefix
+}
+
+func (r *Registry) gcpLengths() *GCPLengths {
+	if r.GCPLengths == nil {
+		return currentGCPLengths()
+	}
+	return r.GCPLengths
+}
+
+func (r *Registry) restricted() RestrictedRanges {
+	if r.Restricted == nil {
+		return DefaultRestrictedRanges
+	}
+	return r.Restricted
+}
@@ -0,0 +1,143 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+
+	f, err := os.Open("testdata/gcpprefixformatlist.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lengths, err := ParseGCPLengths(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two tenants, neither touching the package tables
+	SetGCPLengths(nil)
+	acme := &Registry{GCPLengths: lengths}
+	globex := &Registry{
+		Prefixes:   []CompanyPrefix{"40063", "4006381333"},
+		Restricted: RestrictedRanges{{"DE", "29", CategoryDeposit, "Pfand"}},
+		Country:    "DE",
+		CacheSize:  1,
+	}
+
+	tests := []struct {
+		registry *Registry
+		gtin     string
+		want     string
+	}{
+		{acme, "4006381333931", "4006381"},
+		{acme, "0614141000012", "0614141"},
+		{globex, "4006381333931", "4006381333"},
+		{globex, "4006381333931", "4006381333"}, // Cached
+		{globex, "4006382000009", "40063"},
+		{globex, "5012345678900", ""},
+	}
+	for _, tt := range tests {
+		got, err := tt.registry.CompanyPrefix(MustParse(tt.gtin))
+		if got != tt.want || (tt.want == "") != errors.Is(err, ErrCompanyPrefix) {
+			t.Errorf("%s: wanted %s, got %s, %v", tt.gtin, tt.want, got, err)
+		}
+	}
+	if _, err := MustParse("4006381333931").CompanyPrefix(); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("package table changed: %v", err)
+	}
+
+	if org, err := acme.MemberOrganization(MustParse("7350053850019")); err != nil || org != "GS1 Sweden" {
+		t.Errorf("got %s, %v", org, err)
+	}
+
+	deposit := MustParse("2912345678906")
+	if r, ok := globex.Classify(deposit); !ok || r.Category != CategoryDeposit {
+		t.Errorf("got %v, %v", r, ok)
+	}
+	if _, ok := acme.Classify(deposit); ok {
+		t.Errorf("%s classified without a country", deposit)
+	}
+	var re *RestrictedError
+	if err := globex.Rule().Check(deposit); !errors.As(err, &re) {
+		t.Errorf("wanted *RestrictedError, got %v", err)
+	}
+
+	globex.Reset()
+	if got, _ := globex.CompanyPrefix(MustParse("4006382000009")); got != "40063" {
+		t.Errorf("got %s after Reset", got)
+	}
+
+	// Tenant-scoped rules
+	if r := DefaultPolicy().Validate(deposit); len(r.Warnings) == 0 {
+		t.Errorf("%s: wanted a prefix warning", deposit)
+	}
+	if r := globex.Policy().Validate(deposit); !r.OK() || len(r.Warnings) != 0 {
+		t.Errorf("%s: got %v", deposit, r)
+	}
+	if globex.PrefixRule().Legal(MustParse("2012345678903")) {
+		t.Error("2012345678903 is outside the ranges of globex")
+	}
+	if err := acme.KnownPrefixRule().Check(MustParse("7350053850019")); err != nil {
+		t.Error(err)
+	}
+
+	// Ranges of all countries, coupons and refund receipts, are still
+	// flagged as by DefaultPolicy; Pfandbons only pass in Germany
+	for _, tt := range []struct {
+		registry *Registry
+		gtin     string
+		warn     bool
+	}{
+		{&Registry{}, "9912345678909", true},
+		{&Registry{}, "9801234567892", true},
+		{globex, "9912345678909", true},
+		{globex, "9801234567892", true},
+		{&Registry{Country: "DE"}, "9912345678909", true},
+		{&Registry{Country: "DE"}, "9801234567892", false},
+	} {
+		if report := tt.registry.Policy().Validate(MustParse(tt.gtin)); (len(report.Warnings) > 0) != tt.warn {
+			t.Errorf("%s in %q: got %v", tt.gtin, tt.registry.Country, report.Warnings)
+		}
+	}
+}
+
+func TestRegistryPrefixes(t *testing.T) {
+
+	SetGCPLengths(nil)
+	r := &Registry{Prefixes: []CompanyPrefix{"4006381"}}
+
+	sscc, err := ParseSSCC("340063810000000018")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.SSCCCompanyPrefix(sscc); got != "4006381" || err != nil {
+		t.Errorf("SSCC: got %s, %v", got, err)
+	}
+	if _, err := sscc.CompanyPrefix(); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("package table knows 4006381: %v", err)
+	}
+
+	if _, err := r.ParseCompanyPrefix("4006381"); err != nil {
+		t.Error(err)
+	}
+	if _, err := r.ParseCompanyPrefix("2000001"); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("wanted ErrCompanyPrefix, got %v", err)
+	}
+
+	s, err := NewSGTIN(MustParse("4006381123457"), "6789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.EPCURI(s); got != "urn:epc:id:sgtin:4006381.012345.6789" || err != nil {
+		t.Errorf("EPC URI: got %s, %v", got, err)
+	}
+	if _, err := s.EPCURI(); !errors.Is(err, ErrCompanyPrefix) {
+		t.Errorf("package table knows 4006381: %v", err)
+	}
+}
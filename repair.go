@@ -0,0 +1,68 @@
+package gtin
+
+import (
+	"strings"
+)
+
+// RepairSpreadsheet makes Parse accept GTINs mangled by spreadsheets that
+// took them for numbers: in scientific notation, such as 6.14141E+11, with
+// a trailing .0, or without their leading zeros. The digits are
+// reconstructed and the result is flagged as Repaired. Digits lost to
+// scientific notation come back as zeros, so the check digit of such a
+// value usually fails.
+func RepairSpreadsheet() Option {
+	return func(o *options) {
+		o.repair = true
+	}
+}
+
+// repairSpreadsheet returns the digits of a number formatted by a
+// spreadsheet, zero padded to a GTIN length, and if it changed them
+func repairSpreadsheet(input string) (string, bool) {
+
+	s := strings.TrimSpace(input)
+	s = strings.ReplaceAll(s, ",", ".")
+
+	// Scientific notation, with a positive exponent
+	if mantissa, exp, ok := strings.Cut(strings.ToUpper(s), "E"); ok {
+		exp = strings.TrimPrefix(exp, "+")
+		whole, fraction, _ := strings.Cut(mantissa, ".")
+		n := 0
+		for _, c := range []byte(exp) {
+			if !isDigit(c) || n > GTIN_LENGTH {
+				return input, false
+			}
+			n = n*10 + int(c-'0')
+		}
+		if exp == "" || !allDigits(whole) || !allDigits(fraction) || len(fraction) > n {
+			return input, false
+		}
+		s = whole + fraction + strings.Repeat("0", n-len(fraction))
+	}
+
+	// A decimal point and zeros
+	if whole, fraction, ok := strings.Cut(s, "."); ok && strings.Trim(fraction, "0") == "" {
+		s = whole
+	}
+	if !allDigits(s) || s == "" {
+		return input, false
+	}
+	if !isCandidate(len(s)) {
+		for _, length := range []int{8, 12, 13, 14} {
+			if len(s) < length {
+				s = strings.Repeat("0", length-len(s)) + s
+				break
+			}
+		}
+	}
+	return s, s != input
+}
+
+func allDigits(s string) bool {
+	for n := 0; n < len(s); n++ {
+		if !isDigit(s[n]) {
+			return false
+		}
+	}
+	return true
+}
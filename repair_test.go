@@ -0,0 +1,49 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRepairSpreadsheet(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		want     string
+		repaired bool
+	}{
+		{"6.14141E+11", "", false}, // Digits lost, the check digit fails
+		{"6.14141000012E+11", "00614141000012", true},
+		{"6,14141000012E11", "00614141000012", true},
+		{"4006381333931.0", "04006381333931", true},
+		{" 4006381333931 ", "04006381333931", true},
+		{"614141000012.00", "00614141000012", true},
+		{"61414100001", "", false}, // A wrong check digit after padding
+		{"96385074.0", "00000096385074", true},
+		{"36000291452", "00036000291452", true}, // A UPC-A without its leading zero
+		{"00614141000012", "00614141000012", false},
+		{"4006381333931", "04006381333931", false},
+	}
+	for _, tt := range tests {
+		gt, err := Parse(tt.input, RepairSpreadsheet())
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%q: wanted an error, got %s", tt.input, gt)
+			}
+			continue
+		}
+		if err != nil || gt.String() != tt.want || gt.Repaired() != tt.repaired {
+			t.Errorf("%q: got %s, %v, repaired %v", tt.input, gt, err, gt.Repaired())
+		}
+	}
+
+	if _, err := Parse("6.14141E+11", RepairSpreadsheet()); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+	if _, err := Parse("4006381333931.5", RepairSpreadsheet()); !errors.Is(err, ErrDigit) {
+		t.Errorf("wanted ErrDigit, got %v", err)
+	}
+	if gt, err := Parse("6.14141E+11"); err == nil || gt.Repaired() {
+		t.Errorf("repaired without the option")
+	}
+}
@@ -0,0 +1,99 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Categories of national restricted circulation numbers
+const (
+	CategoryVariableMeasure string = "VARIABLE-MEASURE" // Priced or weighed in store
+	CategoryInStore         string = "IN-STORE"         // Numbered by the retailer
+	CategoryDeposit         string = "DEPOSIT"          // Deposit return, e.g. German Pfand
+	CategoryCoupon          string = "COUPON"
+)
+
+// RestrictedRange is a national use of a restricted range, such as the
+// deposit return receipts of reverse vending machines
+type RestrictedRange struct {
+	Country  string // ISO 3166-1 alpha-2 code, or empty for all countries
+	Prefix   string // Of the GTIN-13 form, or of a GTIN-8
+	Category string
+	Name     string // E.g. Pfand
+}
+
+// RestrictedRanges is a table of the national uses of restricted ranges.
+// GS1 leaves the ranges of the restricted prefixes to each member
+// organization, so they differ between countries.
+type RestrictedRanges []RestrictedRange
+
+// DefaultRestrictedRanges are the refund receipt and coupon prefixes of
+// GS1, the Pfand receipts and in-store numbers of GS1 Germany and the UPC
+// number systems of GS1 US. Retailers printing deposit receipts in their
+// in-store range add it to a copy, e.g. {"DE", "29", CategoryDeposit, "Pfand"}.
+var DefaultRestrictedRanges = RestrictedRanges{
+	{"DE", "980", CategoryDeposit, "Pfandbon"},
+	{"DE", "2", CategoryInStore, "Interne Nummer"},
+	{"US", "02", CategoryVariableMeasure, "UPC number system 2"},
+	{"US", "04", CategoryInStore, "UPC number system 4"},
+	{"US", "05", CategoryCoupon, "UPC number system 5"},
+	{"", "980", CategoryDeposit, "Refund receipt"},
+	{"", "981", CategoryCoupon, "Coupon in a common currency area"},
+	{"", "982", CategoryCoupon, "Coupon in a common currency area"},
+	{"", "983", CategoryCoupon, "Coupon in a common currency area"},
+	{"", "984", CategoryCoupon, "Coupon in a common currency area"},
+	{"", "99", CategoryCoupon, "Coupon"},
+}
+
+// Classify returns the range of the table the GTIN is in, in the given
+// country. The longest matching prefix wins.
+func (t RestrictedRanges) Classify(gt GTIN, country string) (RestrictedRange, bool) {
+
+	digits := prefixDigits(gt)
+	var found RestrictedRange
+	ok := false
+	for _, r := range t {
+		if r.Country != "" && !strings.EqualFold(r.Country, country) {
+			continue
+		}
+		if strings.HasPrefix(digits, r.Prefix) && (!ok || len(r.Prefix) > len(found.Prefix)) {
+			found, ok = r, true
+		}
+	}
+	return found, ok
+}
+
+// RestrictedError is a GTIN of a restricted range with a national use. It
+// matches the LegalityResult of the range with errors.Is.
+type RestrictedError struct {
+	GTIN     GTIN
+	Range    RestrictedRange
+	Legality LegalityResult
+}
+
+func (e *RestrictedError) Error() string {
+	if e.Range.Country == "" {
+		return fmt.Sprintf("%s %s: %s", e.Range.Category, e.GTIN, e.Range.Name)
+	}
+	return fmt.Sprintf("%s %s in %s: %s", e.Range.Category, e.GTIN, e.Range.Country, e.Range.Name)
+}
+
+func (e *RestrictedError) Unwrap() error {
+	return e.Legality
+}
+
+// Rule returns a rule like LegalPrefixRule that reports GTINs breaking a
+// prefix rule as a *RestrictedError when the table classifies them in the
+// country
+func (t RestrictedRanges) Rule(country string) Rule {
+	return RuleFunc(func(gt GTIN) error {
+		legality := gt.Legality()
+		if legality == PrefixLegal {
+			return nil
+		}
+		if r, ok := t.Classify(gt, country); ok {
+			return &RestrictedError{GTIN: gt, Range: r, Legality: legality}
+		}
+		return legality
+	})
+}
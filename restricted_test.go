@@ -0,0 +1,87 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRestrictedRanges(t *testing.T) {
+
+	// Deposit return receipts of German reverse vending machines
+	table := append(RestrictedRanges{
+		{"DE", "2", CategoryInStore, "Interne Nummer"},
+		{"DE", "29", CategoryDeposit, "Pfand"},
+	}, DefaultRestrictedRanges...)
+
+	tests := []struct {
+		gtin     string
+		country  string
+		category string
+	}{
+		{"2912345678906", "DE", CategoryDeposit},
+		{"2112345678900", "de", CategoryInStore},
+		{"2912345678906", "SE", ""},
+		{"0212345678909", "US", CategoryVariableMeasure},
+		{"4006381333931", "DE", ""},
+	}
+	for _, tt := range tests {
+		r, ok := table.Classify(MustParse(tt.gtin), tt.country)
+		if ok != (tt.category != "") || r.Category != tt.category {
+			t.Errorf("%s in %s: got %v, %v", tt.gtin, tt.country, r, ok)
+		}
+	}
+
+	rule := table.Rule("DE")
+	err := rule.Check(MustParse("2912345678906"))
+	var re *RestrictedError
+	if !errors.As(err, &re) || re.Range.Name != "Pfand" || !errors.Is(err, RestrictedPrefix) {
+		t.Errorf("got %v", err)
+	}
+	if ErrorCode(err) != "GTIN_E010_RESTRICTED_PREFIX" {
+		t.Errorf("got code %q", ErrorCode(err))
+	}
+	if err := rule.Check(MustParse("0212345678909")); err != RestrictedPrefix {
+		t.Errorf("wanted RestrictedPrefix outside the US, got %v", err)
+	}
+	if err := rule.Check(MustParse("4006381333931")); err != nil {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestDefaultRestrictedRanges(t *testing.T) {
+
+	tests := []struct {
+		gtin    string
+		country string
+		name    string
+	}{
+		{"9801234567892", "DE", "Pfandbon"},
+		{"9801234567892", "SE", "Refund receipt"},
+		{"9811234567891", "FR", "Coupon in a common currency area"},
+		{"9912345678909", "", "Coupon"},
+		{"2112345678900", "DE", "Interne Nummer"},
+		{"2112345678900", "SE", ""},
+		{"0212345678909", "US", "UPC number system 2"},
+	}
+	for _, tt := range tests {
+		r, ok := DefaultRestrictedRanges.Classify(MustParse(tt.gtin), tt.country)
+		if ok != (tt.name != "") || r.Name != tt.name {
+			t.Errorf("%s in %s: got %v, %v", tt.gtin, tt.country, r, ok)
+		}
+	}
+
+	var re *RestrictedError
+	err := DefaultRestrictedRanges.Rule("DE").Check(MustParse("9801234567892"))
+	if !errors.As(err, &re) || re.Range.Category != CategoryDeposit || !errors.Is(err, CouponPrefix9899) {
+		t.Errorf("got %v", err)
+	}
+	if want := "DEPOSIT 09801234567892 in DE: Pfandbon"; err.Error() != want {
+		t.Errorf("wanted %q, got %q", want, err)
+	}
+
+	// Ranges of all countries name none
+	err = DefaultRestrictedRanges.Rule("SE").Check(MustParse("9912345678909"))
+	if want := "COUPON 09912345678909: Coupon"; err == nil || err.Error() != want {
+		t.Errorf("wanted %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,59 @@
+package gtin
+
+import (
+	"fmt"
+	"time"
+)
+
+// NonReuseDate is the date from which the GS1 GTIN Management Standard
+// forbids allocating the GTIN of a trade item to another trade item, no
+// matter when it was first allocated
+var NonReuseDate = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Reuse periods after the last shipment under the rules before
+// NonReuseDate
+const (
+	legacyReuseMonths  = 48
+	apparelReuseMonths = 30
+)
+
+// Retirement records a trade item taken off the market
+type Retirement struct {
+	GTIN        GTIN
+	LastShipped time.Time // Last shipment of the item
+	Apparel     bool      // Apparel had a shorter reuse period
+}
+
+// ReuseWindow returns the date from which the GTIN could be allocated
+// again under the legacy rules: 48 months after the last shipment, or 30
+// for apparel. Since NonReuseDate the GTIN can never be reused, so it
+// returns false unless the window opened before.
+func (r Retirement) ReuseWindow() (time.Time, bool) {
+	months := legacyReuseMonths
+	if r.Apparel {
+		months = apparelReuseMonths
+	}
+	from := r.LastShipped.AddDate(0, months, 0)
+	return from, from.Before(NonReuseDate)
+}
+
+// RetiredRule returns a rule that flags retired GTINs reappearing, e.g. in
+// a feed, at the given time. GTINs legitimately reused in a legacy reuse
+// window pass.
+func RetiredRule(retired []Retirement, at time.Time) Rule {
+
+	byGTIN := make(map[uint64]Retirement, len(retired))
+	for _, r := range retired {
+		byGTIN[r.GTIN.Uint64()] = r
+	}
+	return RuleFunc(func(gt GTIN) error {
+		r, ok := byGTIN[gt.Uint64()]
+		if !ok {
+			return nil
+		}
+		if from, ok := r.ReuseWindow(); ok && !at.Before(from) {
+			return nil
+		}
+		return fmt.Errorf("%w %s, last shipped %s", ErrRetired, gt, r.LastShipped.Format(time.DateOnly))
+	})
+}
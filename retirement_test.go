@@ -0,0 +1,57 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestReuseWindow(t *testing.T) {
+
+	for _, test := range []struct {
+		r    Retirement
+		from time.Time
+		ok   bool
+	}{
+		{Retirement{LastShipped: date(2012, 3, 1)}, date(2016, 3, 1), true},
+		{Retirement{LastShipped: date(2012, 3, 1), Apparel: true}, date(2014, 9, 1), true},
+		{Retirement{LastShipped: date(2015, 6, 1)}, date(2019, 6, 1), false}, // Window after the rule change
+		{Retirement{LastShipped: date(2016, 12, 1), Apparel: true}, date(2019, 6, 1), false},
+	} {
+		if from, ok := test.r.ReuseWindow(); !from.Equal(test.from) || ok != test.ok {
+			t.Errorf("%+v: wanted %v, %v, got %v, %v", test.r, test.from, test.ok, from, ok)
+		}
+	}
+}
+
+func TestRetiredRule(t *testing.T) {
+
+	reused := MustParse("4006381333931")
+	retired := MustParse("614141000012")
+	rule := RetiredRule([]Retirement{
+		{GTIN: reused, LastShipped: date(2010, 1, 1)},
+		{GTIN: retired, LastShipped: date(2020, 1, 1)},
+	}, date(2026, 10, 1))
+
+	if err := rule.Check(reused); err != nil {
+		t.Errorf("wanted a legacy reuse to pass, got %v", err)
+	}
+	// In any form
+	err := rule.Check(MustParse("00614141000012"))
+	if !errors.Is(err, ErrRetired) || ErrorCode(err) != "GTIN_E019_RETIRED" {
+		t.Errorf("wanted ErrRetired, got %v", err)
+	}
+	if err := rule.Check(MustParse("96385074")); err != nil {
+		t.Errorf("wanted nil, got %v", err)
+	}
+
+	policy := DefaultPolicy()
+	policy.Errors = append(policy.Errors, rule)
+	if report := policy.Validate(retired); report.OK() {
+		t.Error("wanted the retired GTIN invalid")
+	}
+}
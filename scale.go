@@ -0,0 +1,124 @@
+package gtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScaleLayout is a national layout of the variable measure EAN-13 printed
+// by in-store scales, with a GS1 prefix of 20 to 29: the prefix, an item
+// code, an optional price check digit and the price or weight, then the
+// check digit. The widths add up to 12.
+type ScaleLayout struct {
+	Prefix     string // E.g. "23" for items priced by weight
+	ItemWidth  int
+	ValueWidth int  // Digits of the price or weight
+	PriceCheck bool // A check digit of the value, for 4 or 5 digits
+}
+
+// check returns an error unless the layout makes 12 digits
+func (l ScaleLayout) check() error {
+	width := len(l.Prefix) + l.ItemWidth + l.ValueWidth
+	if l.PriceCheck {
+		width++
+		if l.ValueWidth != 4 && l.ValueWidth != 5 {
+			return fmt.Errorf("%w: price check digit of %d digits", ErrLength, l.ValueWidth)
+		}
+	}
+	if width != 12 || l.ItemWidth < 1 || l.ValueWidth < 1 {
+		return fmt.Errorf("%w: scale layout of %d digits", ErrLength, width)
+	}
+	if !strings.HasPrefix(l.Prefix, "2") {
+		return fmt.Errorf("%w: scale layout prefix %q", ErrPrefix, l.Prefix)
+	}
+	for n := 0; n < len(l.Prefix); n++ {
+		if !isDigit(l.Prefix[n]) {
+			return &PositionError{ErrDigit, l.Prefix[n], n}
+		}
+	}
+	return nil
+}
+
+// Encode returns the EAN-13 of the item and the price or weight, in the
+// smallest unit of the layout, e.g. cents or grams
+func (l ScaleLayout) Encode(item, value int) (GTIN, error) {
+
+	if err := l.check(); err != nil {
+		return GTIN{}, err
+	}
+	i, v := strconv.Itoa(item), strconv.Itoa(value)
+	if item < 0 || value < 0 || len(i) > l.ItemWidth || len(v) > l.ValueWidth {
+		return GTIN{}, fmt.Errorf("%w: item %d and value %d for the scale layout", ErrLength, item, value)
+	}
+	i = strings.Repeat("0", l.ItemWidth-len(i)) + i
+	v = strings.Repeat("0", l.ValueWidth-len(v)) + v
+
+	payload := l.Prefix + i
+	if l.PriceCheck {
+		payload += string('0' + priceCheckDigit(v))
+	}
+	payload += v
+	c, err := ComputeCheckDigit(payload)
+	if err != nil {
+		return GTIN{}, err
+	}
+	return Parse(payload + string('0'+c))
+}
+
+// Decode returns the item and the price or weight of an EAN-13 of the
+// layout, checking the price check digit
+func (l ScaleLayout) Decode(gt GTIN) (item, value int, err error) {
+
+	if err := l.check(); err != nil {
+		return 0, 0, err
+	}
+	if !gt.Valid() {
+		return 0, 0, ErrCheckDigit
+	}
+	s := gt.String()[1:13]
+	if gt.MinimalType() != GTIN13 || !strings.HasPrefix(s, l.Prefix) {
+		return 0, 0, fmt.Errorf("%w: %s is not of the scale layout %s", ErrPrefix, gt, l.Prefix)
+	}
+	s = s[len(l.Prefix):]
+	item, _ = strconv.Atoi(s[:l.ItemWidth])
+	s = s[l.ItemWidth:]
+	if l.PriceCheck {
+		if s[0]-'0' != priceCheckDigit(s[1:]) {
+			return 0, 0, fmt.Errorf("%w: price check digit of %s", ErrCheckDigit, gt)
+		}
+		s = s[1:]
+	}
+	value, _ = strconv.Atoi(s)
+	return item, value, nil
+}
+
+// Weighted products of a digit in the price check digit calculation of the
+// GS1 General Specifications
+var (
+	weight2Minus = [10]uint8{0, 2, 4, 6, 8, 9, 1, 3, 5, 7}
+	weight3      = [10]uint8{0, 3, 6, 9, 2, 5, 8, 1, 4, 7}
+	weight5Plus  = [10]uint8{0, 5, 1, 6, 2, 7, 3, 8, 4, 9}
+	weight5Minus = [10]uint8{0, 5, 9, 4, 8, 3, 7, 2, 6, 1}
+)
+
+// priceCheckDigit returns the price check digit of a 4 or 5 digit price
+func priceCheckDigit(price string) uint8 {
+
+	d := func(n int) uint8 { return price[n] - '0' }
+	if len(price) == 4 {
+		// Weights 2-, 2-, 3 and 5-, GS1 General Specifications 7.9.3
+		sum := weight2Minus[d(0)] + weight2Minus[d(1)] + weight3[d(2)] + weight5Minus[d(3)]
+		return sum * 3 % 10
+	}
+
+	// The digit whose 5- product makes the sum a multiple of 10
+	sum := weight5Plus[d(0)] + weight2Minus[d(1)] + weight5Minus[d(2)] + weight5Plus[d(3)] + weight2Minus[d(4)]
+	want := (10 - sum%10) % 10
+	for c := uint8(0); c < 10; c++ {
+		if weight5Minus[c] == want {
+			return c
+		}
+	}
+	return 0
+}
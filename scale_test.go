@@ -0,0 +1,70 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPriceCheckDigit(t *testing.T) {
+
+	tests := []struct {
+		price string
+		want  uint8
+	}{
+		{"2875", 9}, // The example of the GS1 General Specifications
+		{"0000", 0},
+		{"14685", 6},
+	}
+	for _, tt := range tests {
+		if got := priceCheckDigit(tt.price); got != tt.want {
+			t.Errorf("%s: got %d, wanted %d", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestScaleLayout(t *testing.T) {
+
+	layouts := []struct {
+		layout ScaleLayout
+		item   int
+		value  int
+		want   string
+	}{
+		{ScaleLayout{Prefix: "23", ItemWidth: 5, ValueWidth: 5}, 123, 1250, "2300123012507"},
+		{ScaleLayout{Prefix: "2", ItemWidth: 6, ValueWidth: 4, PriceCheck: true}, 4711, 2875, "2004711928750"},
+		{ScaleLayout{Prefix: "21", ItemWidth: 4, ValueWidth: 5, PriceCheck: true}, 42, 14685, "2100426146851"},
+	}
+	for _, tt := range layouts {
+		gt, err := tt.layout.Encode(tt.item, tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gt.String()[1:] != tt.want || !gt.Valid() {
+			t.Errorf("got %s, wanted %s", gt, tt.want)
+		}
+		item, value, err := tt.layout.Decode(gt)
+		if err != nil || item != tt.item || value != tt.value {
+			t.Errorf("%s: got %d, %d, %v", gt, item, value, err)
+		}
+	}
+
+	l := ScaleLayout{Prefix: "2", ItemWidth: 6, ValueWidth: 4, PriceCheck: true}
+	if _, err := l.Encode(1234567, 1); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength, got %v", err)
+	}
+	if _, err := (ScaleLayout{Prefix: "2", ItemWidth: 5, ValueWidth: 5}).Encode(1, 1); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted ErrLength for 11 digits, got %v", err)
+	}
+	if _, err := (ScaleLayout{Prefix: "40", ItemWidth: 5, ValueWidth: 5}).Encode(1, 1); !errors.Is(err, ErrPrefix) {
+		t.Errorf("wanted ErrPrefix, got %v", err)
+	}
+
+	// A wrong price check digit with a valid check digit
+	gt := MustParse("2004711328758")
+	if _, _, err := l.Decode(gt); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+	if _, _, err := l.Decode(MustParse("4006381333931")); !errors.Is(err, ErrPrefix) {
+		t.Errorf("wanted ErrPrefix, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package gtin
+
+// ScanGTINs is a split function for a bufio.Scanner that returns the
+// candidate GTINs of a stream: runs of 8, 12, 13 or 14 digits. Anything
+// else delimits tokens, and runs of other lengths are skipped, so the
+// candidates of logs, exports and OCR output can be scanned alike:
+//
+//	s := bufio.NewScanner(r)
+//	s.Split(gtin.ScanGTINs)
+//	for s.Scan() {
+//		gt, err := gtin.Parse(s.Text())
+//	}
+//
+// The candidates are not validated. A run of digits is held in the buffer
+// until it ends, so a run longer than the buffer of the Scanner fails with
+// bufio.ErrTooLong.
+func ScanGTINs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+
+	start := 0
+	for {
+		for start < len(data) && !isDigit(data[start]) {
+			start++
+		}
+		end := start
+		for end < len(data) && isDigit(data[end]) {
+			end++
+		}
+		if end == len(data) && !atEOF {
+			// The run may go on, ask for more data
+			return start, nil, nil
+		}
+		if start == end {
+			return end, nil, nil
+		}
+		if isCandidate(end - start) {
+			return end, data[start:end], nil
+		}
+		start = end
+	}
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// isCandidate returns true if a run of n digits may be a GTIN
+func isCandidate(n int) bool {
+	return n == 8 || n == 12 || n == 13 || n == 14
+}
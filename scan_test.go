@@ -0,0 +1,41 @@
+package gtin
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func scanAll(r io.Reader) ([]string, error) {
+	s := bufio.NewScanner(r)
+	s.Split(ScanGTINs)
+	var tokens []string
+	for s.Scan() {
+		tokens = append(tokens, s.Text())
+	}
+	return tokens, s.Err()
+}
+
+func TestScanGTINs(t *testing.T) {
+
+	input := "EAN:4006381333931;upc=614141000012\tx96385074,\r\n" +
+		"123456789 10614141000019|00614141000012345 4006381333931"
+	want := []string{"4006381333931", "614141000012", "96385074", "10614141000019", "4006381333931"}
+
+	if got, err := scanAll(strings.NewReader(input)); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v, %v", want, got, err)
+	}
+	// Runs of digits span reads
+	if got, err := scanAll(iotest.OneByteReader(strings.NewReader(input))); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("one byte reads: wanted %v, got %v, %v", want, got, err)
+	}
+
+	for _, input := range []string{"", "no codes here", "1234567 123456789012345"} {
+		if got, err := scanAll(strings.NewReader(input)); err != nil || len(got) > 0 {
+			t.Errorf("%q: got %v, %v", input, got, err)
+		}
+	}
+}
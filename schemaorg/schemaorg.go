@@ -0,0 +1,107 @@
+/*
+Package schemaorg generates schema.org Product markup in JSON-LD, with the
+GTIN in the gtin8, gtin12, gtin13 or gtin14 property that matches its type:
+
+	<script type="application/ld+json">
+	{"@context":"https://schema.org","@type":"Product","name":"Jeans","gtin13":"4006381333931"}
+	</script>
+*/
+package schemaorg
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/peterstark72/gtin"
+)
+
+// ErrNoGTIN is returned for products without GTIN
+var ErrNoGTIN = errors.New("schemaorg: product without GTIN")
+
+// Product is a schema.org Product
+type Product struct {
+	GTIN        gtin.GTIN
+	Name        string
+	Description string
+	Brand       string
+	SKU         string
+	MPN         string
+	Image       []string // URLs
+	URL         string
+}
+
+// Property returns the schema.org property and value of the GTIN, e.g.
+// gtin13 and 4006381333931, from the type the GTIN was parsed as
+func Property(gt gtin.GTIN) (string, string) {
+	s := gt.String()
+	switch gt.Type() {
+	case gtin.GTIN8:
+		return "gtin8", s[6:]
+	case gtin.GTIN12:
+		return "gtin12", s[2:]
+	case gtin.GTIN13:
+		return "gtin13", s[1:]
+	}
+	return "gtin14", s
+}
+
+type brand struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// MarshalJSON returns the product as JSON-LD
+func (p Product) MarshalJSON() ([]byte, error) {
+
+	if p.GTIN.IsZero() {
+		return nil, ErrNoGTIN
+	}
+	doc := struct {
+		Context     string   `json:"@context"`
+		Type        string   `json:"@type"`
+		Name        string   `json:"name,omitempty"`
+		Description string   `json:"description,omitempty"`
+		Brand       *brand   `json:"brand,omitempty"`
+		SKU         string   `json:"sku,omitempty"`
+		MPN         string   `json:"mpn,omitempty"`
+		Image       []string `json:"image,omitempty"`
+		URL         string   `json:"url,omitempty"`
+		GTIN8       string   `json:"gtin8,omitempty"`
+		GTIN12      string   `json:"gtin12,omitempty"`
+		GTIN13      string   `json:"gtin13,omitempty"`
+		GTIN14      string   `json:"gtin14,omitempty"`
+	}{
+		Context:     "https://schema.org",
+		Type:        "Product",
+		Name:        p.Name,
+		Description: p.Description,
+		SKU:         p.SKU,
+		MPN:         p.MPN,
+		Image:       p.Image,
+		URL:         p.URL,
+	}
+	if p.Brand != "" {
+		doc.Brand = &brand{"Brand", p.Brand}
+	}
+	switch property, value := Property(p.GTIN); property {
+	case "gtin8":
+		doc.GTIN8 = value
+	case "gtin12":
+		doc.GTIN12 = value
+	case "gtin13":
+		doc.GTIN13 = value
+	default:
+		doc.GTIN14 = value
+	}
+	return json.Marshal(doc)
+}
+
+// Script returns the product as a JSON-LD script element for an HTML
+// page. The JSON escapes <, > and &, so the element can't be closed early.
+func (p Product) Script() (string, error) {
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return `<script type="application/ld+json">` + string(data) + `</script>`, nil
+}
@@ -0,0 +1,52 @@
+package schemaorg
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestProperty(t *testing.T) {
+
+	tests := []struct {
+		input, property, value string
+	}{
+		{"96385074", "gtin8", "96385074"},
+		{"614141000012", "gtin12", "614141000012"},
+		{"4006381333931", "gtin13", "4006381333931"},
+		{"04006381333931", "gtin14", "04006381333931"},
+		{"10614141000019", "gtin14", "10614141000019"},
+	}
+	for _, tt := range tests {
+		if property, value := Property(gtin.MustParse(tt.input)); property != tt.property || value != tt.value {
+			t.Errorf("%s: got %s %s", tt.input, property, value)
+		}
+	}
+}
+
+func TestScript(t *testing.T) {
+
+	p := Product{
+		GTIN:  gtin.MustParse("4006381333931"),
+		Name:  "Pen </script>",
+		Brand: "Stabilo",
+		Image: []string{"https://example.com/pen.jpg"},
+	}
+	script, err := p.Script()
+	want := `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Product","name":"Pen \u003c/script\u003e","brand":{"@type":"Brand","name":"Stabilo"},"image":["https://example.com/pen.jpg"],"gtin13":"4006381333931"}</script>`
+	if err != nil || script != want {
+		t.Errorf("got %s, %v", script, err)
+	}
+
+	// Products marshal as part of other documents
+	data, err := json.Marshal([]Product{{GTIN: gtin.MustParse("614141000012")}})
+	if err != nil || string(data) != `[{"@context":"https://schema.org","@type":"Product","gtin12":"614141000012"}]` {
+		t.Errorf("got %s, %v", data, err)
+	}
+
+	if _, err := (Product{Name: "Pen"}).Script(); !errors.Is(err, ErrNoGTIN) {
+		t.Errorf("wanted ErrNoGTIN, got %v", err)
+	}
+}
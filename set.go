@@ -0,0 +1,113 @@
+package gtin
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Set is an immutable set of GTINs, e.g. the assortment of a retailer,
+// kept as sorted GTIN-14 numbers, 8 bytes per GTIN. GTINs are members in
+// all their forms: 614141000012 and 00614141000012 are the same. The zero
+// value is the empty set.
+//
+// The operations merge the sorted numbers in one pass, without hashing.
+type Set struct {
+	keys []uint64
+}
+
+// NewSet returns the set of the GTINs. Zero values are left out.
+func NewSet(gts ...GTIN) Set {
+	return Set{sortedKeys(gts)}
+}
+
+// Len returns the number of GTINs in the set
+func (s Set) Len() int {
+	return len(s.keys)
+}
+
+// Contains reports whether the GTIN, in any of its forms, is in the set
+func (s Set) Contains(gt GTIN) bool {
+	n := gt.Uint64()
+	i := sort.Search(len(s.keys), func(i int) bool { return s.keys[i] >= n })
+	return !gt.IsZero() && i < len(s.keys) && s.keys[i] == n
+}
+
+// Each calls fn for each GTIN in ascending order, as GTIN-14, until fn
+// returns false
+func (s Set) Each(fn func(GTIN) bool) {
+	for _, n := range s.keys {
+		if !fn(asGTIN14(n)) {
+			return
+		}
+	}
+}
+
+// EachPrefix calls fn for each GTIN numbered from the company prefix, with
+// any indicator digit, in ascending order, until fn returns false
+func (s Set) EachPrefix(p CompanyPrefix, fn func(GTIN) bool) {
+
+	size := uint64(pow10(GTIN13.Len() - len(p)))
+	base, err := strconv.ParseUint(string(p), 10, 64)
+	if err != nil || size == 0 {
+		return
+	}
+	for indicator := uint64(0); indicator <= 9; indicator++ {
+		lo := indicator*uint64(pow10(GTIN13.Len())) + base*size
+		i := sort.Search(len(s.keys), func(i int) bool { return s.keys[i] >= lo })
+		for ; i < len(s.keys) && s.keys[i] < lo+size; i++ {
+			if gt := asGTIN14(s.keys[i]); p.Contains(gt) && !fn(gt) {
+				return
+			}
+		}
+	}
+}
+
+// GTINs returns the GTINs in ascending order, as GTIN-14s
+func (s Set) GTINs() []GTIN {
+	gts := make([]GTIN, len(s.keys))
+	for i, n := range s.keys {
+		gts[i] = asGTIN14(n)
+	}
+	return gts
+}
+
+// Union returns the GTINs in s or other
+func (s Set) Union(other Set) Set {
+	return Set{merge(s.keys, other.keys, true, true, true)}
+}
+
+// Intersect returns the GTINs in both s and other
+func (s Set) Intersect(other Set) Set {
+	return Set{merge(s.keys, other.keys, false, true, false)}
+}
+
+// Difference returns the GTINs in s but not in other
+func (s Set) Difference(other Set) Set {
+	return Set{merge(s.keys, other.keys, true, false, false)}
+}
+
+// merge merges the sorted values of a and b, keeping the values only in
+// a, in both or only in b
+func merge(a, b []uint64, onlyA, both, onlyB bool) []uint64 {
+	var out []uint64
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || len(a) > 0 && a[0] < b[0]:
+			if onlyA {
+				out = append(out, a[0])
+			}
+			a = a[1:]
+		case len(a) == 0 || b[0] < a[0]:
+			if onlyB {
+				out = append(out, b[0])
+			}
+			b = b[1:]
+		default:
+			if both {
+				out = append(out, a[0])
+			}
+			a, b = a[1:], b[1:]
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package gtin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+
+	strs := func(s Set) []string {
+		var out []string
+		s.Each(func(gt GTIN) bool {
+			out = append(out, gt.String())
+			return true
+		})
+		return out
+	}
+
+	retailer := NewSet(MustParse("4006381333931"), MustParse("614141000012"), MustParse("96385074"), GTIN{})
+	supplier := NewSet(MustParse("00614141000012"), MustParse("10614141000019"), MustParse("96385074"))
+
+	if retailer.Len() != 3 || !retailer.Contains(MustParse("04006381333931")) || retailer.Contains(MustParse("10614141000019")) || retailer.Contains(GTIN{}) {
+		t.Errorf("got %v", strs(retailer))
+	}
+
+	tests := []struct {
+		name string
+		set  Set
+		want []string
+	}{
+		{"union", retailer.Union(supplier), []string{"00000096385074", "00614141000012", "04006381333931", "10614141000019"}},
+		{"intersect", retailer.Intersect(supplier), []string{"00000096385074", "00614141000012"}},
+		{"difference", retailer.Difference(supplier), []string{"04006381333931"}},
+		{"empty", Set{}.Union(Set{}), nil},
+		{"empty intersect", retailer.Intersect(Set{}), nil},
+	}
+	for _, tt := range tests {
+		if got := strs(tt.set); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	// Each stops when fn returns false
+	n := 0
+	retailer.Each(func(GTIN) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("wanted 1 call, got %d", n)
+	}
+	if gts := retailer.GTINs(); len(gts) != 3 || gts[0].Type() != GTIN14 {
+		t.Errorf("got %v", gts)
+	}
+}
+
+func TestSetEachPrefix(t *testing.T) {
+
+	s := NewSet(
+		MustParse("4006381333931"),
+		MustParse("614141000012"),
+		MustParse("10614141000019"),
+		MustParse("614141999996"),
+		MustParse("0614142000011"),
+	)
+	var got []string
+	s.EachPrefix("0614141", func(gt GTIN) bool {
+		got = append(got, gt.String())
+		return true
+	})
+	if want := []string{"00614141000012", "00614141999996", "10614141000019"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+	s.EachPrefix("x", func(gt GTIN) bool {
+		t.Errorf("got %s for an invalid prefix", gt)
+		return true
+	})
+}
@@ -0,0 +1,92 @@
+package gtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SKUFormat maps GTINs plus optional variant parts, e.g. a size, to SKUs
+// and back
+type SKUFormat struct {
+	Prefix    string // e.g. "ACME-"
+	Separator string // Between the GTIN and variant parts, "-" if empty
+	Short     bool   // The GTIN without zero padding, see GTIN.Short
+}
+
+func (f SKUFormat) separator() string {
+	if f.Separator == "" {
+		return "-"
+	}
+	return f.Separator
+}
+
+// Format returns the SKU of the GTIN and variants. Variants must not be
+// empty or contain the separator, so the SKU parses back.
+func (f SKUFormat) Format(gt GTIN, variants ...string) (string, error) {
+
+	code := gt.String()
+	if f.Short {
+		code = gt.Short()
+	}
+	parts := append([]string{f.Prefix + code}, variants...)
+	for _, v := range variants {
+		if v == "" || strings.Contains(v, f.separator()) {
+			return "", fmt.Errorf("%w in variant %q", ErrCharacter, v)
+		}
+	}
+	return strings.Join(parts, f.separator()), nil
+}
+
+// Parse returns the GTIN and variants of a SKU. The GTIN must have a
+// correct check digit.
+func (f SKUFormat) Parse(sku string) (GTIN, []string, error) {
+
+	rest, ok := strings.CutPrefix(sku, f.Prefix)
+	if !ok {
+		return GTIN{}, nil, fmt.Errorf("%w: SKU %q without prefix %q", ErrNotConvertible, sku, f.Prefix)
+	}
+	parts := strings.Split(rest, f.separator())
+	gt, err := Parse(parts[0])
+	if err != nil {
+		return GTIN{}, nil, err
+	}
+	return gt, parts[1:], nil
+}
+
+// Slug returns a URL-safe slug of the GTIN and variants, e.g.
+// 4006381333931-dark_red-xl for "Dark Red" and "XL". Variants are lower
+// case, with runs of other characters than letters and digits replaced by
+// an underscore. ParseSlug returns them in that form.
+func Slug(gt GTIN, variants ...string) string {
+	parts := []string{gt.Short()}
+	for _, v := range variants {
+		if v = slugPart(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseSlug returns the GTIN and variants of a slug made by Slug
+func ParseSlug(slug string) (GTIN, []string, error) {
+	return SKUFormat{Short: true}.Parse(slug)
+}
+
+// slugPart lowercases s and replaces runs of characters other than ASCII
+// letters and digits with an underscore, trimming them at the ends
+func slugPart(s string) string {
+	var b strings.Builder
+	gap := false
+	for _, r := range strings.ToLower(s) {
+		if 'a' <= r && r <= 'z' || '0' <= r && r <= '9' {
+			if gap && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			gap = false
+		} else {
+			gap = true
+		}
+	}
+	return b.String()
+}
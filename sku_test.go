@@ -0,0 +1,54 @@
+package gtin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSKUFormat(t *testing.T) {
+
+	gt := MustParse("614141000012")
+	for _, test := range []struct {
+		f        SKUFormat
+		variants []string
+		want     string
+	}{
+		{SKUFormat{}, nil, "00614141000012"},
+		{SKUFormat{Prefix: "ACME-", Short: true}, []string{"RED", "XL"}, "ACME-614141000012-RED-XL"},
+		{SKUFormat{Separator: "/"}, []string{"32-34"}, "00614141000012/32-34"},
+	} {
+		sku, err := test.f.Format(gt, test.variants...)
+		if err != nil || sku != test.want {
+			t.Errorf("%+v: wanted %s, got %s, %v", test.f, test.want, sku, err)
+			continue
+		}
+		back, variants, err := test.f.Parse(sku)
+		if err != nil || !Equal(back, gt) || len(variants) != len(test.variants) {
+			t.Errorf("%s: got %v, %v, %v", sku, back, variants, err)
+		}
+	}
+
+	if _, err := (SKUFormat{}).Format(gt, "32-34"); !errors.Is(err, ErrCharacter) {
+		t.Errorf("wanted ErrCharacter, got %v", err)
+	}
+	if _, _, err := (SKUFormat{Prefix: "ACME-"}).Parse("00614141000012"); !errors.Is(err, ErrNotConvertible) {
+		t.Errorf("wanted ErrNotConvertible, got %v", err)
+	}
+	if _, _, err := (SKUFormat{}).Parse("00614141000013-XL"); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("wanted ErrCheckDigit, got %v", err)
+	}
+}
+
+func TestSlug(t *testing.T) {
+
+	gt := MustParse("04006381333931")
+	slug := Slug(gt, "Dark Red", " XL ", "--")
+	if want := "4006381333931-dark_red-xl"; slug != want {
+		t.Errorf("wanted %s, got %s", want, slug)
+	}
+	back, variants, err := ParseSlug(slug)
+	if err != nil || !Equal(back, gt) || !reflect.DeepEqual(variants, []string{"dark_red", "xl"}) {
+		t.Errorf("got %v, %v, %v", back, variants, err)
+	}
+}
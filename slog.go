@@ -0,0 +1,58 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// LogValue logs the GTIN as a group of its digits and type, and whether
+// its check digit was corrected
+func (gt GTIN) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("gtin", gt.String()),
+		slog.String("type", string(gt.Type())),
+	}
+	if gt.corrected {
+		attrs = append(attrs, slog.Bool("corrected", true))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// ErrorLogger logs validation failures with structured fields, so pipelines
+// don't format errors themselves
+type ErrorLogger struct {
+	Logger *slog.Logger // slog.Default() if nil
+	Level  slog.Level
+}
+
+// Log logs one record per error in err, unwrapping joined errors, with the
+// raw input, the error code and, for invalid characters, the position.
+// Extra attributes, e.g. a line number, are added to each record.
+func (l ErrorLogger) Log(ctx context.Context, input string, err error, attrs ...slog.Attr) {
+
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if err == nil || !logger.Enabled(ctx, l.Level) {
+		return
+	}
+
+	for _, err := range leafErrors(err) {
+		record := append([]slog.Attr{
+			slog.String("input", input),
+			slog.String("error", err.Error()),
+		}, attrs...)
+		if code := ErrorCode(err); code != "" {
+			record = append(record, slog.String("code", code))
+		}
+		var pos *PositionError
+		if errors.As(err, &pos) {
+			record = append(record, slog.Int("position", pos.Pos))
+		}
+		logger.LogAttrs(ctx, l.Level, "invalid GTIN", record...)
+	}
+}
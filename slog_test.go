@@ -0,0 +1,65 @@
+//go:build !tinygo
+
+package gtin
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	gt, _ := Parse("4006381333932", FixCheckDigit())
+	logger.Info("scanned", "item", gt)
+
+	want := "level=INFO msg=scanned item.gtin=04006381333931 item.type=GTIN-13 item.corrected=true\n"
+	if buf.String() != want {
+		t.Errorf("wanted %q, got %q", want, buf.String())
+	}
+}
+
+func TestErrorLogger(t *testing.T) {
+
+	var buf bytes.Buffer
+	l := ErrorLogger{
+		Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		Level:  slog.LevelWarn,
+	}
+	_, err := Atog("12a")
+	l.Log(context.Background(), "12a", err, slog.Int("line", 7))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wanted a record per error, got %q", buf.String())
+	}
+	for _, want := range []string{`"level":"WARN"`, `"input":"12a"`, `"line":7`, `"code":"GTIN_E001_LENGTH"`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("wanted %s in %s", want, lines[0])
+		}
+	}
+	for _, want := range []string{`"code":"GTIN_E002_DIGIT"`, `"position":2`} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("wanted %s in %s", want, lines[1])
+		}
+	}
+
+	// Below the handler's level nothing is logged
+	buf.Reset()
+	l.Level = slog.LevelDebug
+	l.Log(context.Background(), "12a", err)
+	if buf.Len() != 0 {
+		t.Errorf("wanted nothing logged, got %q", buf.String())
+	}
+}
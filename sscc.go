@@ -0,0 +1,151 @@
+package gtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const SSCC_LENGTH = 18
+
+// SSCC is a Serial Shipping Container Code, identifying a logistic unit
+// such as a pallet: an extension digit, a GS1 Company Prefix, a serial
+// reference and a check digit, 18 digits in all
+type SSCC struct {
+	digits [SSCC_LENGTH]uint8
+}
+
+// ParseSSCC parses an 18-digit SSCC and verifies its check digit
+func ParseSSCC(input string) (SSCC, error) {
+
+	var s SSCC
+	if len(input) != SSCC_LENGTH {
+		return SSCC{}, fmt.Errorf("%w %d", ErrLength, len(input))
+	}
+	for n := 0; n < SSCC_LENGTH; n++ {
+		if input[n] < '0' || input[n] > '9' {
+			return SSCC{}, &PositionError{ErrDigit, input[n], n}
+		}
+		s.digits[n] = input[n] - '0'
+	}
+	if (GS1Mod10{}).CheckDigit(s.digits[:SSCC_LENGTH-1]) != s.digits[SSCC_LENGTH-1] {
+		return SSCC{}, ErrCheckDigit
+	}
+	return s, nil
+}
+
+// String returns the 18 digits of the SSCC
+func (s SSCC) String() string {
+	var b strings.Builder
+	for _, d := range s.digits {
+		b.WriteByte('0' + d)
+	}
+	return b.String()
+}
+
+// ExtensionDigit returns the first digit, used by the company to increase
+// the capacity of the serial reference
+func (s SSCC) ExtensionDigit() uint8 {
+	return s.digits[0]
+}
+
+// CompanyPrefix returns the GS1 Company Prefix of the SSCC, which follows
+// the extension digit. It needs a full GCP length table, see
+// LoadGCPLengths.
+func (s SSCC) CompanyPrefix() (string, error) {
+	return ssccCompanyPrefix(currentGCPLengths(), s)
+}
+
+func ssccCompanyPrefix(t *GCPLengths, s SSCC) (string, error) {
+	digits := s.String()[1:]
+	length, ok := t.Lookup(digits)
+	if s.IsZero() || !ok || length == 0 {
+		return "", ErrCompanyPrefix
+	}
+	return digits[:length], nil
+}
+
+// IsZero returns true for the zero value
+func (s SSCC) IsZero() bool {
+	return s == SSCC{}
+}
+
+// SerialStore hands out serial references. Implementations persist the
+// sequence so that generators never repeat a reference, across restarts
+// or machines.
+type SerialStore interface {
+	// Next returns the next serial reference for key, starting at 0
+	Next(key string) (uint64, error)
+}
+
+// MemorySerialStore is a SerialStore in memory, safe for concurrent use.
+// The zero value starts every sequence at 0.
+type MemorySerialStore struct {
+	mu   sync.Mutex
+	next map[string]uint64
+}
+
+// Next returns the next serial reference for key
+func (m *MemorySerialStore) Next(key string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next == nil {
+		m.next = make(map[string]uint64)
+	}
+	n := m.next[key]
+	m.next[key] = n + 1
+	return n, nil
+}
+
+// Set makes next the next serial reference for key, e.g. to resume a
+// sequence saved elsewhere
+func (m *MemorySerialStore) Set(key string, next uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next == nil {
+		m.next = make(map[string]uint64)
+	}
+	m.next[key] = next
+}
+
+// SSCCGenerator generates SSCCs from an extension digit, a company prefix
+// and increasing serial references from a SerialStore
+type SSCCGenerator struct {
+	Extension     uint8
+	CompanyPrefix string // 4 to 12 digits
+	Store         SerialStore
+}
+
+// Next returns the SSCC with the next serial reference. It returns
+// ErrExhausted once all serial references of the prefix are used.
+func (g *SSCCGenerator) Next() (SSCC, error) {
+
+	if g.Extension > 9 {
+		return SSCC{}, fmt.Errorf("%w: extension digit %d", ErrDigit, g.Extension)
+	}
+	if len(g.CompanyPrefix) < 4 || len(g.CompanyPrefix) > 12 || strings.Trim(g.CompanyPrefix, "0123456789") != "" {
+		return SSCC{}, fmt.Errorf("%w %q", ErrCompanyPrefix, g.CompanyPrefix)
+	}
+
+	key := strconv.Itoa(int(g.Extension)) + g.CompanyPrefix
+	serial, err := g.Store.Next(key)
+	if err != nil {
+		return SSCC{}, err
+	}
+
+	// The serial reference fills the digits between prefix and check digit
+	width := SSCC_LENGTH - 2 - len(g.CompanyPrefix)
+	ref := strconv.FormatUint(serial, 10)
+	if len(ref) > width {
+		return SSCC{}, fmt.Errorf("%w: %d serial references of %s", ErrExhausted, serial, key)
+	}
+	payload := key + strings.Repeat("0", width-len(ref)) + ref
+
+	var s SSCC
+	for n := 0; n < SSCC_LENGTH-1; n++ {
+		s.digits[n] = payload[n] - '0'
+	}
+	s.digits[SSCC_LENGTH-1] = GS1Mod10{}.CheckDigit(s.digits[:SSCC_LENGTH-1])
+	return s, nil
+}
@@ -0,0 +1,89 @@
+package gtin
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestParseSSCC(t *testing.T) {
+
+	s, err := ParseSSCC("106141411234567897")
+	if err != nil || s.String() != "106141411234567897" || s.ExtensionDigit() != 1 {
+		t.Errorf("got %s, %v", s, err)
+	}
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"10614141123456789", ErrLength},
+		{"10614141123456789a", ErrDigit},
+		{"106141411234567898", ErrCheckDigit},
+	}
+	for _, tt := range tests {
+		if _, err := ParseSSCC(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.input, tt.want, err)
+		}
+	}
+}
+
+func TestSSCCGenerator(t *testing.T) {
+
+	store := &MemorySerialStore{}
+	store.Set("10614141", 123456789)
+	g := SSCCGenerator{Extension: 1, CompanyPrefix: "0614141", Store: store}
+	s, err := g.Next()
+	if err != nil || s.String() != "106141411234567897" {
+		t.Errorf("wanted 106141411234567897, got %s, %v", s, err)
+	}
+	s, _ = g.Next()
+	if _, err := ParseSSCC(s.String()); err != nil || s.String()[:17] != "10614141123456790" {
+		t.Errorf("wanted next serial, got %s, %v", s, err)
+	}
+
+	// Sequences are per extension digit and prefix, and never repeat
+	other := SSCCGenerator{Extension: 2, CompanyPrefix: "0614141", Store: store}
+	if s, _ := other.Next(); s.String()[:17] != "20614141000000000" {
+		t.Errorf("wanted a new sequence, got %s", s)
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[SSCC]bool)
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := other.Next()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil || seen[s] {
+				t.Errorf("repeated or failed %s, %v", s, err)
+			}
+			seen[s] = true
+		}()
+	}
+	wg.Wait()
+
+	// A 12-digit prefix has room for 10000 serial references
+	full := SSCCGenerator{Extension: 0, CompanyPrefix: "400638133393", Store: store}
+	store.Set("0400638133393", 9999)
+	if _, err := full.Next(); err != nil {
+		t.Error(err)
+	}
+	if _, err := full.Next(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("wanted ErrExhausted, got %v", err)
+	}
+
+	for _, g := range []SSCCGenerator{
+		{Extension: 10, CompanyPrefix: "0614141", Store: store},
+		{Extension: 1, CompanyPrefix: "061", Store: store},
+		{Extension: 1, CompanyPrefix: "06141a1", Store: store},
+	} {
+		if _, err := g.Next(); err == nil {
+			t.Errorf("%+v: wanted error", g)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package gtin
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+// Stats aggregates a stream of parse results, e.g. of a catalog export.
+// The zero value is ready to use; feed it with
+//
+//	s.Add(gtin.Parse(code))
+type Stats struct {
+	total, invalid int
+	types          map[Type]int
+	carriers       map[string]int
+	errors         map[string]int
+	prefixes       map[string]int
+	counts         map[uint64]int
+	noDuplicates   bool
+}
+
+// StatsReport is a snapshot of Stats. It marshals to JSON as is.
+type StatsReport struct {
+	Total      int            `json:"total"`
+	Valid      int            `json:"valid"`
+	Invalid    int            `json:"invalid"`
+	Types      map[Type]int   `json:"types"`
+	Carriers   map[string]int `json:"carriers"`
+	Errors     map[string]int `json:"errors"`   // By error code
+	Prefixes   map[string]int `json:"prefixes"` // By GS1 member organization
+	Duplicates []Duplicate    `json:"duplicates"`
+}
+
+// Duplicate is a GTIN that occurs more than once
+type Duplicate struct {
+	GTIN  string `json:"gtin"`
+	Count int    `json:"count"`
+}
+
+// errStatsEncoding is returned for malformed binary Stats
+var errStatsEncoding = errors.New("gtin: invalid Stats encoding")
+
+// otherError is the class of errors without a code
+const otherError = "OTHER"
+
+// Add counts one parse result. GTINs are counted by type, carrier and GS1
+// prefix, errors by their codes. The same GTIN in different forms, e.g.
+// 614141000012 and 00614141000012, is a duplicate.
+func (s *Stats) Add(gt GTIN, err error) {
+
+	s.init()
+	s.total++
+	if err != nil {
+		s.invalid++
+		for _, err := range leafErrors(err) {
+			code := ErrorCode(err)
+			if code == "" {
+				code = otherError
+			}
+			s.errors[code]++
+		}
+		return
+	}
+
+	s.types[gt.Type()]++
+	s.carriers[gt.Carrier()]++
+	prefix, err := gt.MemberOrganization()
+	if err != nil {
+		prefix = "unknown"
+	}
+	s.prefixes[prefix]++
+	if !s.noDuplicates {
+		s.counts[gt.Uint64()]++
+	}
+}
+
+// TrackDuplicates sets whether Add counts every GTIN for the Duplicates
+// of Report, which it does by default. Without, Stats stays small however
+// many GTINs it sees, e.g. to checkpoint a job over billions of lines.
+func (s *Stats) TrackDuplicates(on bool) {
+	s.noDuplicates = !on
+}
+
+// init makes the maps of the zero value
+func (s *Stats) init() {
+	if s.counts == nil {
+		s.types = make(map[Type]int)
+		s.carriers = make(map[string]int)
+		s.errors = make(map[string]int)
+		s.prefixes = make(map[string]int)
+		s.counts = make(map[uint64]int)
+	}
+}
+
+// Merge adds the counts of other, e.g. of another file
+func (s *Stats) Merge(other *Stats) {
+
+	s.init()
+	s.total += other.total
+	s.invalid += other.invalid
+	mergeCounts(s.types, other.types)
+	mergeCounts(s.carriers, other.carriers)
+	mergeCounts(s.errors, other.errors)
+	mergeCounts(s.prefixes, other.prefixes)
+	if !s.noDuplicates {
+		mergeCounts(s.counts, other.counts)
+	}
+}
+
+// leafErrors unwraps joined errors
+func leafErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, leafErrors(err)...)
+	}
+	return errs
+}
+
+// Report returns the counts so far, with the top most frequent duplicates,
+// or all of them if top is negative. Duplicates are listed as GTIN-14s.
+func (s *Stats) Report(top int) StatsReport {
+
+	r := StatsReport{
+		Total:      s.total,
+		Valid:      s.total - s.invalid,
+		Invalid:    s.invalid,
+		Types:      copyCounts(s.types),
+		Carriers:   copyCounts(s.carriers),
+		Errors:     copyCounts(s.errors),
+		Prefixes:   copyCounts(s.prefixes),
+		Duplicates: []Duplicate{},
+	}
+
+	var dups []uint64
+	for n, count := range s.counts {
+		if count > 1 {
+			dups = append(dups, n)
+		}
+	}
+	sort.Slice(dups, func(i, j int) bool {
+		a, b := s.counts[dups[i]], s.counts[dups[j]]
+		return a > b || a == b && dups[i] < dups[j]
+	})
+	if top >= 0 && len(dups) > top {
+		dups = dups[:top]
+	}
+	for _, n := range dups {
+		gt, _ := FromUint64(n, GTIN14)
+		r.Duplicates = append(r.Duplicates, Duplicate{gt.String(), s.counts[n]})
+	}
+	return r
+}
+
+func mergeCounts[K comparable](dst, src map[K]int) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
+
+func copyCounts[K comparable](m map[K]int) map[K]int {
+	c := make(map[K]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// statsVersion is the version of the binary encoding of Stats. Version 2
+// added whether duplicates are tracked.
+const statsVersion = 2
+
+// MarshalBinary encodes the counts, e.g. to checkpoint a long-running job
+func (s *Stats) MarshalBinary() ([]byte, error) {
+
+	b := []byte{statsVersion, 0}
+	if s.noDuplicates {
+		b[1] = 1
+	}
+	b = binary.AppendUvarint(b, uint64(s.total))
+	b = binary.AppendUvarint(b, uint64(s.invalid))
+	types := make(map[string]int, len(s.types))
+	for typ, n := range s.types {
+		types[string(typ)] = n
+	}
+	for _, m := range []map[string]int{types, s.carriers, s.errors, s.prefixes} {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b = binary.AppendUvarint(b, uint64(len(keys)))
+		for _, k := range keys {
+			b = binary.AppendUvarint(b, uint64(len(k)))
+			b = append(b, k...)
+			b = binary.AppendUvarint(b, uint64(m[k]))
+		}
+	}
+	keys := make([]uint64, 0, len(s.counts))
+	for k := range s.counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	b = binary.AppendUvarint(b, uint64(len(keys)))
+	for _, k := range keys {
+		b = binary.AppendUvarint(b, k)
+		b = binary.AppendUvarint(b, uint64(s.counts[k]))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes counts encoded by MarshalBinary, replacing the
+// counts of s
+func (s *Stats) UnmarshalBinary(data []byte) error {
+
+	if len(data) == 0 || data[0] < 1 || data[0] > statsVersion {
+		return errStatsEncoding
+	}
+	var noDuplicates bool
+	if data[0] >= 2 {
+		if len(data) < 2 || data[1] > 1 {
+			return errStatsEncoding
+		}
+		noDuplicates = data[1] == 1
+		data = data[1:]
+	}
+	data = data[1:]
+	next := func() int {
+		n, size := binary.Uvarint(data)
+		if size <= 0 {
+			data = nil
+			return -1
+		}
+		data = data[size:]
+		return int(n)
+	}
+
+	var d Stats
+	d.init()
+	d.total, d.invalid = next(), next()
+	types := make(map[string]int)
+	for _, m := range []map[string]int{types, d.carriers, d.errors, d.prefixes} {
+		for n := next(); n > 0 && data != nil; n-- {
+			size := next()
+			if size < 0 || size > len(data) {
+				return errStatsEncoding
+			}
+			k := string(data[:size])
+			data = data[size:]
+			m[k] = next()
+		}
+	}
+	for typ, n := range types {
+		d.types[Type(typ)] = n
+	}
+	for n := next(); n > 0 && data != nil; n-- {
+		k, size := binary.Uvarint(data)
+		if size <= 0 {
+			return errStatsEncoding
+		}
+		data = data[size:]
+		d.counts[k] = next()
+	}
+	if data == nil || len(data) > 0 || d.total < 0 || d.invalid < 0 {
+		return errStatsEncoding
+	}
+	d.noDuplicates = noDuplicates
+	*s = d
+	return nil
+}
@@ -0,0 +1,124 @@
+package gtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+
+	var s Stats
+	for _, code := range []string{
+		"4006381333931",
+		"04006381333931", // Same as above
+		"4006381333931",
+		"614141000012",
+		"614141000012",
+		"96385074",
+		"4006381333932", // Wrong check digit
+		"12345",
+		"40063813339x1",
+	} {
+		s.Add(Parse(code))
+	}
+
+	r := s.Report(-1)
+	if r.Total != 9 || r.Valid != 6 || r.Invalid != 3 {
+		t.Errorf("got total %d, valid %d, invalid %d", r.Total, r.Valid, r.Invalid)
+	}
+	if want := map[Type]int{GTIN13: 2, GTIN14: 1, GTIN12: 2, GTIN8: 1}; !reflect.DeepEqual(r.Types, want) {
+		t.Errorf("types: wanted %v, got %v", want, r.Types)
+	}
+	if want := map[string]int{"GTIN_E001_LENGTH": 1, "GTIN_E002_DIGIT": 1, "GTIN_E003_CHECK_DIGIT": 1}; !reflect.DeepEqual(r.Errors, want) {
+		t.Errorf("errors: wanted %v, got %v", want, r.Errors)
+	}
+	if r.Prefixes["GS1 Germany"] != 3 {
+		t.Errorf("prefixes: got %v", r.Prefixes)
+	}
+	want := []Duplicate{{"04006381333931", 3}, {"00614141000012", 2}}
+	if !reflect.DeepEqual(r.Duplicates, want) {
+		t.Errorf("duplicates: wanted %v, got %v", want, r.Duplicates)
+	}
+	if r = s.Report(1); len(r.Duplicates) != 1 {
+		t.Errorf("wanted top 1 duplicate, got %v", r.Duplicates)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil || !strings.Contains(string(b), `"duplicates":[{"gtin":"04006381333931","count":3}]`) {
+		t.Errorf("got %s, %v", b, err)
+	}
+}
+
+func TestStatsMerge(t *testing.T) {
+
+	var a, b, merged Stats
+	a.Add(Parse("4006381333931"))
+	a.Add(Parse("12345"))
+	b.Add(Parse("04006381333931"))
+	merged.Merge(&a)
+	merged.Merge(&b)
+
+	r := merged.Report(-1)
+	if r.Total != 3 || r.Invalid != 1 || r.Types[GTIN13] != 1 || r.Types[GTIN14] != 1 || r.Errors["GTIN_E001_LENGTH"] != 1 {
+		t.Errorf("got %+v", r)
+	}
+	if len(r.Duplicates) != 1 || r.Duplicates[0].Count != 2 {
+		t.Errorf("wanted a duplicate across stats, got %v", r.Duplicates)
+	}
+}
+
+func TestStatsBinary(t *testing.T) {
+
+	var s Stats
+	for _, code := range []string{"4006381333931", "4006381333931", "614141000012", "4006381333932", "x"} {
+		s.Add(Parse(code))
+	}
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d Stats
+	if err := d.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(d.Report(-1), s.Report(-1)) {
+		t.Errorf("got %+v, %v", d.Report(-1), err)
+	}
+	if again, _ := d.MarshalBinary(); !reflect.DeepEqual(again, data) {
+		t.Error("encoding is not deterministic")
+	}
+
+	// Without duplicates the encoding stays the same size
+	var bounded Stats
+	bounded.TrackDuplicates(false)
+	bounded.Add(Parse("4006381333931"))
+	small, _ := bounded.MarshalBinary()
+	for n := 0; n < 100; n++ {
+		payload := fmt.Sprintf("4006381333%02d", n)
+		check, _ := ComputeCheckDigit(payload)
+		bounded.Add(Parse(fmt.Sprintf("%s%d", payload, check)))
+	}
+	if data, _ := bounded.MarshalBinary(); len(data) != len(small) {
+		t.Errorf("encoding grew from %d to %d bytes", len(small), len(data))
+	}
+	if err := d.UnmarshalBinary(small); err != nil {
+		t.Fatal(err)
+	}
+	d.Add(Parse("4006381333931"))
+	if r := d.Report(-1); r.Total != 2 || len(r.Duplicates) != 0 {
+		t.Errorf("wanted duplicates still off after decoding, got %+v", r)
+	}
+
+	var zero Stats
+	data, _ = zero.MarshalBinary()
+	if err := d.UnmarshalBinary(data); err != nil || d.Report(0).Total != 0 {
+		t.Errorf("got %+v, %v", d.Report(0), err)
+	}
+
+	data, _ = s.MarshalBinary()
+	for _, bad := range [][]byte{nil, {2}, data[:len(data)-1], append(data, 0), {1, 5, 1, 0xFF}} {
+		if err := d.UnmarshalBinary(bad); err == nil {
+			t.Errorf("%v: wanted error", bad)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package gtin
+
+import (
+	"sort"
+)
+
+// Suggestion is a GTIN of a Set close to a code, see Set.Suggest
+type Suggestion struct {
+	GTIN     GTIN
+	Distance int // Edits from the code
+}
+
+// Suggest returns the GTINs of the set within maxDistance edits of the
+// code, the closest first, for "did you mean" suggestions when correcting
+// invalid codes. An edit is a digit inserted, deleted, replaced, or two
+// adjacent digits swapped, the common typing mistakes. GTINs are compared
+// in the form of the length of the code, so zero padding is no edit.
+func (s Set) Suggest(code string, maxDistance int) []Suggestion {
+
+	var found []Suggestion
+	s.Each(func(gt GTIN) bool {
+		digits := gt.String()
+		length := max(len(code), gt.MinimalType().Len())
+		if length < GTIN_LENGTH {
+			digits = digits[GTIN_LENGTH-length:]
+		}
+		if d := editDistance(code, digits, maxDistance); d <= maxDistance {
+			found = append(found, Suggestion{gt, d})
+		}
+		return true
+	})
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Distance < found[j].Distance })
+	return found
+}
+
+// editDistance returns the optimal string alignment distance of a and b,
+// or more than limit once it's clear the distance is
+func editDistance(a, b string, limit int) int {
+
+	if abs(len(a)-len(b)) > limit {
+		return limit + 1
+	}
+	// Rows of the distances of the prefixes of a to those of b
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		best := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			best = min(best, curr[j])
+		}
+		if best > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
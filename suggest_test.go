@@ -0,0 +1,59 @@
+package gtin
+
+import (
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+
+	s := NewSet(MustParse("4006381333931"), MustParse("96385074"), MustParse("036000291452"), MustParse("4006381333900"))
+
+	tests := []struct {
+		code string
+		max  int
+		want []string
+	}{
+		{"4006381333913", 1, []string{"04006381333931"}},                   // Swapped digits
+		{"4006381333932", 1, []string{"04006381333931"}},                   // Wrong check digit
+		{"400638133393", 1, []string{"04006381333931"}},                    // A digit missing
+		{"4006381333932", 2, []string{"04006381333931", "04006381333900"}}, // Closest first
+		{"96385047", 1, []string{"00000096385074"}},
+		{"36000291452", 1, []string{"00036000291452"}}, // The leading zero of a UPC-A dropped
+		{"1234567890128", 2, nil},
+	}
+	for _, tt := range tests {
+		got := s.Suggest(tt.code, tt.max)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, wanted %v", tt.code, got, tt.want)
+			continue
+		}
+		for n, sg := range got {
+			if sg.GTIN.String() != tt.want[n] {
+				t.Errorf("%s: got %v, wanted %v", tt.code, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1234", "1234", 0},
+		{"1234", "1243", 1},
+		{"1234", "124", 1},
+		{"1234", "12345", 1},
+		{"1234", "4321", 3},
+		{"", "12", 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, 10); got != tt.want {
+			t.Errorf("%s %s: got %d, wanted %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if got := editDistance("12345678", "87654321", 2); got != 3 {
+		t.Errorf("got %d past the limit", got)
+	}
+}
@@ -0,0 +1,77 @@
+package gtin
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Symbologies that carry GTINs, besides EAN13, EAN8, UPCA and ITF14
+const (
+	GS1128        string = "GS1-128"
+	GS1DataBar    string = "GS1 DataBar"
+	GS1DataMatrix string = "GS1 DataMatrix"
+	GS1QRCode     string = "GS1 QR Code"
+	GS1DotCode    string = "GS1 DotCode"
+)
+
+// symbologies maps AIM symbology identifiers to the symbologies they
+// report, and the types of GTIN each may carry; nil for all types
+var symbologies = map[string]struct {
+	name  string
+	types []Type
+}{
+	"]E0": {EAN13, []Type{GTIN12, GTIN13}}, // EAN-13, UPC-A and UPC-E
+	"]E3": {EAN13, []Type{GTIN12, GTIN13}}, // With an add-on symbol
+	"]E4": {EAN8, []Type{GTIN8}},
+	"]I0": {ITF14, nil},
+	"]I1": {ITF14, nil},
+	"]C1": {GS1128, nil},
+	"]e0": {GS1DataBar, nil},
+	"]d2": {GS1DataMatrix, nil},
+	"]Q3": {GS1QRCode, nil},
+	"]J1": {GS1DotCode, nil},
+}
+
+// Symbology returns the symbology of an AIM symbology identifier, such as
+// ]E0, as scanners prefix their data with. Only the symbologies that carry
+// GTINs are known.
+func Symbology(id string) (string, bool) {
+	s, ok := symbologies[id]
+	return s.name, ok
+}
+
+// SymbologyError is a GTIN scanned from a symbology that must not carry
+// it, e.g. a GTIN-14 in an EAN-13. It matches ErrSymbology with errors.Is.
+type SymbologyError struct {
+	GTIN       GTIN
+	Type       Type // The type of the value, regardless of padding
+	Identifier string
+	Symbology  string
+}
+
+func (e *SymbologyError) Error() string {
+	return fmt.Sprintf("%v: %s %s in %s (%s)", ErrSymbology, e.Type, e.GTIN, e.Symbology, e.Identifier)
+}
+
+func (e *SymbologyError) Unwrap() error {
+	return ErrSymbology
+}
+
+// CheckSymbology checks that the symbology of the AIM symbology identifier
+// may carry the GTIN under the GS1 General Specifications. EAN-13 and
+// UPC-A carry GTIN-12 and GTIN-13, EAN-8 only GTIN-8; ITF-14 and the GS1
+// symbologies with element strings carry them all. The type is that of the
+// value, so a zero padded GTIN-13 is a GTIN-13. Violations are returned as
+// a *SymbologyError, unknown identifiers wrap ErrSymbology.
+func CheckSymbology(gt GTIN, id string) error {
+
+	s, ok := symbologies[id]
+	if !ok {
+		return fmt.Errorf("%w: unknown symbology identifier %q", ErrSymbology, id)
+	}
+	typ := gt.MinimalType()
+	if s.types == nil || slices.Contains(s.types, typ) {
+		return nil
+	}
+	return &SymbologyError{GTIN: gt, Type: typ, Identifier: id, Symbology: s.name}
+}
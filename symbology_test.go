@@ -0,0 +1,47 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckSymbology(t *testing.T) {
+
+	tests := []struct {
+		gtin string
+		id   string
+		ok   bool
+	}{
+		{"4006381333931", "]E0", true},
+		{"0614141000036", "]E0", true}, // UPC-A, read as 13 digits
+		{"96385074", "]E4", true},
+		{"0000096385074", "]E0", false}, // A GTIN-8 in an EAN-13
+		{"14006381333938", "]E0", false},
+		{"04006381333931", "]E0", true}, // A zero padded GTIN-13
+		{"4006381333931", "]E4", false},
+		{"14006381333938", "]I1", true},
+		{"14006381333938", "]C1", true},
+		{"96385074", "]d2", true},
+	}
+	for _, tt := range tests {
+		err := CheckSymbology(MustParse(tt.gtin), tt.id)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s in %s: got %v", tt.gtin, tt.id, err)
+		}
+	}
+
+	err := CheckSymbology(MustParse("14006381333938"), "]E0")
+	var v *SymbologyError
+	if !errors.As(err, &v) || v.Type != GTIN14 || v.Symbology != EAN13 || !errors.Is(err, ErrSymbology) {
+		t.Errorf("got %#v", err)
+	}
+	if ErrorCode(err) != "GTIN_E020_SYMBOLOGY" {
+		t.Errorf("got code %q", ErrorCode(err))
+	}
+	if err := CheckSymbology(MustParse("4006381333931"), "]C0"); !errors.Is(err, ErrSymbology) || errors.As(err, &v) {
+		t.Errorf("wanted ErrSymbology for Code 128, got %v", err)
+	}
+	if s, ok := Symbology("]e0"); !ok || s != GS1DataBar {
+		t.Errorf("got %q, %v", s, ok)
+	}
+}
@@ -0,0 +1,184 @@
+package tds
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Headers of the binary encodings
+var headers = map[Scheme]uint64{
+	SGTIN96:  0x30,
+	SGTIN198: 0x36,
+	SSCC96:   0x31,
+	GRAI96:   0x33,
+	GIAI96:   0x34,
+	GIAI202:  0x38,
+}
+
+// Bits of the binary encodings, without padding
+var sizes = map[Scheme]int{
+	SGTIN96:  96,
+	SGTIN198: 198,
+	SSCC96:   96,
+	GRAI96:   96,
+	GIAI96:   96,
+	GIAI202:  202,
+}
+
+// partition is a row of a partition table: the bits and digits of the
+// company prefix and of the reference. The reference of a GIAI-202 has up
+// to refDigits characters.
+type partition struct {
+	cpBits, cpDigits, refBits, refDigits int
+}
+
+// partitions are the partition tables by scheme, indexed by partition
+// value, which is 12 minus the digits of the company prefix
+var partitions = map[Scheme][7]partition{
+	SGTIN96:  sgtinPartitions,
+	SGTIN198: sgtinPartitions,
+	SSCC96:   {{40, 12, 18, 5}, {37, 11, 21, 6}, {34, 10, 24, 7}, {30, 9, 28, 8}, {27, 8, 31, 9}, {24, 7, 34, 10}, {20, 6, 38, 11}},
+	GRAI96:   {{40, 12, 4, 0}, {37, 11, 7, 1}, {34, 10, 10, 2}, {30, 9, 14, 3}, {27, 8, 17, 4}, {24, 7, 20, 5}, {20, 6, 24, 6}},
+	GIAI96:   {{40, 12, 42, 13}, {37, 11, 45, 14}, {34, 10, 48, 15}, {30, 9, 52, 16}, {27, 8, 55, 17}, {24, 7, 58, 18}, {20, 6, 62, 19}},
+	GIAI202:  {{40, 12, 148, 18}, {37, 11, 151, 19}, {34, 10, 154, 20}, {30, 9, 158, 21}, {27, 8, 161, 22}, {24, 7, 164, 23}, {20, 6, 168, 24}},
+}
+
+var sgtinPartitions = [7]partition{{40, 12, 4, 1}, {37, 11, 7, 2}, {34, 10, 10, 3}, {30, 9, 14, 4}, {27, 8, 17, 5}, {24, 7, 20, 6}, {20, 6, 24, 7}}
+
+const (
+	serialBits       = 38  // Numeric serial of SGTIN-96 and GRAI-96
+	maxSerial        = 20  // Characters of an SGTIN-198 serial
+	charSerialBits   = 140 // Alphanumeric serial of SGTIN-198
+	charBits         = 7
+	ssccReservedBits = 24
+)
+
+// bits is a big-endian bit string
+type bits struct {
+	n    *big.Int
+	size int
+}
+
+func (b *bits) write(v uint64, size int) {
+	b.n.Lsh(b.n, uint(size))
+	b.n.Or(b.n, new(big.Int).SetUint64(v))
+	b.size += size
+}
+
+// writeChars writes s as 7-bit characters, padded with zeros to size bits
+func (b *bits) writeChars(s string, size int) {
+	for n := 0; n < len(s); n++ {
+		b.write(uint64(s[n]), charBits)
+	}
+	b.write(0, size-charBits*len(s))
+}
+
+// read returns the next size bits after the first pos
+func (b *bits) read(pos, size int) uint64 {
+	v := new(big.Int).Rsh(b.n, uint(b.size-pos-size))
+	return v.And(v, new(big.Int).SetUint64(1<<size-1)).Uint64()
+}
+
+// readChars returns the 7-bit characters of size bits after the first pos,
+// up to the first zero
+func (b *bits) readChars(pos, size int) string {
+	var s strings.Builder
+	for n := 0; n < size/charBits; n++ {
+		c := b.read(pos+n*charBits, charBits)
+		if c == 0 {
+			break
+		}
+		s.WriteByte(byte(c))
+	}
+	return s.String()
+}
+
+// Binary returns the binary encoding of the tag, padded with zeros to a
+// whole number of 16-bit words as written to tag memory
+func (t Tag) Binary() ([]byte, error) {
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	p := 12 - len(t.CompanyPrefix)
+	row := partitions[t.Scheme][p]
+	cp, _ := strconv.ParseUint(t.CompanyPrefix, 10, 64)
+
+	b := bits{n: new(big.Int)}
+	b.write(headers[t.Scheme], 8)
+	b.write(uint64(t.Filter), 3)
+	b.write(uint64(p), 3)
+	b.write(cp, row.cpBits)
+	if t.Scheme == GIAI202 {
+		b.writeChars(t.Reference, row.refBits)
+	} else {
+		ref, _ := strconv.ParseUint(t.Reference, 10, 64)
+		b.write(ref, row.refBits)
+	}
+	switch t.Scheme {
+	case SGTIN96, GRAI96:
+		serial, _ := strconv.ParseUint(t.Serial, 10, 64)
+		b.write(serial, serialBits)
+	case SGTIN198:
+		b.writeChars(t.Serial, charSerialBits)
+	case SSCC96:
+		b.write(0, ssccReservedBits)
+	}
+	b.write(0, (16-b.size%16)%16)
+	return b.n.FillBytes(make([]byte, b.size/8)), nil
+}
+
+// ParseBinary returns the tag of a binary encoding. Padding after the
+// encoding is ignored.
+func ParseBinary(data []byte) (Tag, error) {
+
+	if len(data) == 0 {
+		return Tag{}, fmt.Errorf("%w: empty", ErrSyntax)
+	}
+	var t Tag
+	for scheme, header := range headers {
+		if uint64(data[0]) == header {
+			t.Scheme = scheme
+		}
+	}
+	if t.Scheme == "" {
+		return Tag{}, fmt.Errorf("%w: header %02X", ErrScheme, data[0])
+	}
+	if 8*len(data) < sizes[t.Scheme] {
+		return Tag{}, fmt.Errorf("%w: %d bits for %s", ErrSyntax, 8*len(data), t.Scheme)
+	}
+
+	b := bits{n: new(big.Int).SetBytes(data), size: 8 * len(data)}
+	t.Filter = uint8(b.read(8, 3))
+	p := int(b.read(11, 3))
+	if p > 6 {
+		return Tag{}, fmt.Errorf("%w: partition %d", ErrSyntax, p)
+	}
+	row := partitions[t.Scheme][p]
+	pos := 14
+	t.CompanyPrefix = fmt.Sprintf("%0*d", row.cpDigits, b.read(pos, row.cpBits))
+	pos += row.cpBits
+
+	switch ref := b.read(pos, min(row.refBits, 64)); {
+	case t.Scheme == GIAI202:
+		t.Reference = b.readChars(pos, row.refBits)
+	case t.Scheme == GIAI96:
+		t.Reference = strconv.FormatUint(ref, 10)
+	case row.refDigits > 0 || ref > 0:
+		t.Reference = fmt.Sprintf("%0*d", row.refDigits, ref)
+	}
+	pos += row.refBits
+
+	switch t.Scheme {
+	case SGTIN96, GRAI96:
+		t.Serial = strconv.FormatUint(b.read(pos, serialBits), 10)
+	case SGTIN198:
+		t.Serial = b.readChars(pos, charSerialBits)
+	}
+	if len(t.CompanyPrefix) != row.cpDigits {
+		return Tag{}, fmt.Errorf("%w: company prefix out of range", ErrSyntax)
+	}
+	return t, t.Validate()
+}
@@ -0,0 +1,59 @@
+package tds
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+
+	for _, ex := range examples {
+		tag, err := ParseURI(ex.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := tag.Binary()
+		if got := fmt.Sprintf("%X", b); err != nil || got != ex.binary {
+			t.Errorf("%s: wanted %s, got %s, %v", ex.tag, ex.binary, got, err)
+		}
+		if got, err := ParseBinary(b); err != nil || got != tag {
+			t.Errorf("%s: got %+v, %v", ex.binary, got, err)
+		}
+	}
+
+	// Every partition round-trips
+	for _, cp := range []string{"061414112345", "06141411234", "0614141123", "061414112", "06141411", "0614141", "061414"} {
+		for _, tag := range []Tag{
+			{SGTIN96, 1, cp, "1234567"[:13-len(cp)], "274877906943"},
+			{GRAI96, 0, cp, "123456"[:12-len(cp)], "1"},
+			{GIAI96, 7, cp, "9876543210", ""},
+			{GIAI202, 2, cp, "ABC-/xyz%0123456789!\"_*+"[:30-len(cp)], ""},
+		} {
+			b, err := tag.Binary()
+			if got, err2 := ParseBinary(b); err != nil || err2 != nil || got != tag {
+				t.Errorf("%+v: got %+v, %v, %v", tag, got, err, err2)
+			}
+		}
+	}
+}
+
+func TestParseBinaryInvalid(t *testing.T) {
+
+	tests := []struct {
+		binary string
+		want   error
+	}{
+		{"", ErrSyntax},
+		{"3574257BF7194E4000001A85", ErrScheme},
+		{"3074257BF7194E40", ErrSyntax},
+		{"307C257BF7194E4000001A85", ErrSyntax},
+	}
+	for _, tt := range tests {
+		b, _ := hex.DecodeString(tt.binary)
+		if _, err := ParseBinary(b); !errors.Is(err, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.binary, tt.want, err)
+		}
+	}
+}
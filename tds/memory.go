@@ -0,0 +1,111 @@
+package tds
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// PC word bits of a Gen2 tag
+const (
+	pcLengthShift = 11
+	pcUMI         = 1 << 10 // User memory indicator
+	pcXI          = 1 << 9  // XPC_W1 indicator
+	pcToggle      = 1 << 8  // Set for ISO 15961 AFIs instead of GS1 EPCs
+)
+
+// MemoryBank is the content of the EPC memory bank (bank 01) of a UHF
+// Gen2 tag, without the StoredCRC word, which the tag computes
+type MemoryBank struct {
+	EPC        []byte // Binary encoding, 1 to 31 16-bit words
+	UMI        bool   // The tag has user memory
+	Attributes uint8  // The attribute bits of the PC word
+	XPC        uint16 // XPC_W1, if not zero
+}
+
+// NewMemoryBank returns the memory bank content of the tag
+func NewMemoryBank(t Tag) (MemoryBank, error) {
+	epc, err := t.Binary()
+	return MemoryBank{EPC: epc}, err
+}
+
+// PC returns the Protocol Control word: the length of the EPC in words,
+// the UMI and XI bits and the attribute bits
+func (m MemoryBank) PC() uint16 {
+	pc := uint16(len(m.EPC)/2)<<pcLengthShift | uint16(m.Attributes)
+	if m.UMI {
+		pc |= pcUMI
+	}
+	if m.XPC != 0 {
+		pc |= pcXI
+	}
+	return pc
+}
+
+// CRC returns the StoredCRC word of the content, the CRC-16 of the PC word
+// and the EPC
+func (m MemoryBank) CRC() uint16 {
+	pc := m.PC()
+	return crc16(append([]byte{byte(pc >> 8), byte(pc)}, m.EPC...))
+}
+
+// crc16 is the CRC-16 of EPC Gen2: polynomial 0x1021, preset 0xFFFF and
+// inverted
+func crc16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for n := 0; n < 8; n++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return ^crc
+}
+
+// Hex returns the PC word, XPC_W1 if any and the EPC in upper case hex, in
+// the order tags backscatter them and reader SDKs report and write them,
+// e.g. 30003074257BF7194E4000001A85
+func (m MemoryBank) Hex() string {
+	s := fmt.Sprintf("%04X", m.PC())
+	if m.XPC != 0 {
+		s += fmt.Sprintf("%04X", m.XPC)
+	}
+	return s + strings.ToUpper(hex.EncodeToString(m.EPC))
+}
+
+// ParseMemoryBank parses the hex content returned by Hex. Spaces are
+// ignored. An XPC_W2 is not supported.
+func ParseMemoryBank(s string) (MemoryBank, error) {
+
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil || len(data) < 2 || len(data)%2 != 0 {
+		return MemoryBank{}, fmt.Errorf("%w: memory bank %q is not hex words", ErrSyntax, s)
+	}
+	pc := uint16(data[0])<<8 | uint16(data[1])
+	if pc&pcToggle != 0 {
+		return MemoryBank{}, fmt.Errorf("%w: memory bank holds an ISO AFI, not a GS1 EPC", ErrScheme)
+	}
+	m := MemoryBank{UMI: pc&pcUMI != 0, Attributes: uint8(pc)}
+	data = data[2:]
+	if pc&pcXI != 0 {
+		if len(data) < 2 {
+			return MemoryBank{}, fmt.Errorf("%w: missing XPC_W1", ErrSyntax)
+		}
+		m.XPC = uint16(data[0])<<8 | uint16(data[1])
+		data = data[2:]
+	}
+	if words := int(pc >> pcLengthShift); len(data) != 2*words {
+		return MemoryBank{}, fmt.Errorf("%w: EPC of %d words, PC says %d", ErrSyntax, len(data)/2, words)
+	}
+	m.EPC = data
+	return m, nil
+}
+
+// Tag returns the tag of the EPC
+func (m MemoryBank) Tag() (Tag, error) {
+	return ParseBinary(m.EPC)
+}
@@ -0,0 +1,73 @@
+package tds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestMemoryBank(t *testing.T) {
+
+	s, _ := gtin.NewSGTIN(gtin.MustParse("80614141123458"), "6789")
+	tag, _ := FromSGTIN(s, SGTIN96, 3)
+	m, err := NewMemoryBank(tag)
+	if err != nil || m.Hex() != "30003074257BF7194E4000001A85" {
+		t.Errorf("got %s, %v", m.Hex(), err)
+	}
+
+	got, err := ParseMemoryBank("3000 3074 257B F719 4E40 0000 1A85")
+	if err != nil || got.Hex() != m.Hex() {
+		t.Errorf("got %+v, %v", got, err)
+	}
+	if got, err := got.Tag(); err != nil || got != tag {
+		t.Errorf("got %+v, %v", got, err)
+	}
+
+	// The XPC_W1 follows the PC word
+	m.UMI, m.Attributes, m.XPC = true, 0x01, 0x8000
+	if m.Hex() != "360180003074257BF7194E4000001A85" {
+		t.Errorf("got %s", m.Hex())
+	}
+	if got, err := ParseMemoryBank(m.Hex()); err != nil || got.Hex() != m.Hex() {
+		t.Errorf("got %+v, %v", got, err)
+	}
+
+	// An SGTIN-198 is 13 words long
+	s, _ = gtin.NewSGTIN(gtin.MustParse("80614141123458"), "32a/b")
+	tag, _ = FromSGTIN(s, SGTIN198, 3)
+	if m, _ := NewMemoryBank(tag); m.PC() != 0x6800 {
+		t.Errorf("got %04X", m.PC())
+	}
+}
+
+func TestParseMemoryBankInvalid(t *testing.T) {
+
+	tests := []struct {
+		hex  string
+		want error
+	}{
+		{"", ErrSyntax},
+		{"30003074257BF7194E4000001A8", ErrSyntax},
+		{"3000307G257BF7194E4000001A85", ErrSyntax},
+		{"28003074257BF7194E4000001A85", ErrSyntax},
+		{"3200", ErrSyntax},
+		{"3100E2801105200074257BF7194E", ErrScheme},
+	}
+	for _, tt := range tests {
+		if _, err := ParseMemoryBank(tt.hex); !errors.Is(err, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.hex, tt.want, err)
+		}
+	}
+}
+
+func TestCRC(t *testing.T) {
+	// The check value of CRC-16/GENIBUS
+	if crc := crc16([]byte("123456789")); crc != 0xD64E {
+		t.Errorf("got %04X", crc)
+	}
+	m, _ := ParseMemoryBank("30003074257BF7194E4000001A85")
+	if m.CRC() != crc16([]byte{0x30, 0x00, 0x30, 0x74, 0x25, 0x7B, 0xF7, 0x19, 0x4E, 0x40, 0x00, 0x00, 0x1A, 0x85}) {
+		t.Errorf("got %04X", m.CRC())
+	}
+}
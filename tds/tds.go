@@ -0,0 +1,370 @@
+/*
+Package tds translates between the representations of EPCs defined by the
+GS1 EPC Tag Data Standard, for the SGTIN, SSCC, GRAI and GIAI schemes:
+
+	element string     (01)80614141123458(21)6789
+	pure identity URI  urn:epc:id:sgtin:0614141.812345.6789
+	tag URI            urn:epc:tag:sgtin-96:3.0614141.812345.6789
+	binary             3074257BF7194E4000001A85
+
+A Tag holds the fields all representations share. Element strings don't
+say where the company prefix ends, so translating them needs the GCP
+length table, see gtin.LoadGCPLengths; URIs and binaries carry it.
+
+A MemoryBank adds the PC word to a binary, as RFID readers write it to a
+tag's EPC memory bank.
+*/
+package tds
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Scheme is an EPC binary encoding scheme
+type Scheme string
+
+// The supported schemes
+const (
+	SGTIN96  Scheme = "sgtin-96"
+	SGTIN198 Scheme = "sgtin-198"
+	SSCC96   Scheme = "sscc-96"
+	GRAI96   Scheme = "grai-96"
+	GIAI96   Scheme = "giai-96"
+	GIAI202  Scheme = "giai-202"
+)
+
+var (
+	// ErrScheme is returned for unknown or unsupported schemes
+	ErrScheme = errors.New("tds: unsupported scheme")
+
+	// ErrSyntax is returned for malformed URIs, element strings and
+	// fields
+	ErrSyntax = errors.New("tds: invalid EPC")
+)
+
+// Tag is an EPC in a binary encoding scheme
+type Tag struct {
+	Scheme        Scheme
+	Filter        uint8  // 0-7, e.g. 1 for a point of sale item, 2 for a full case
+	CompanyPrefix string // 6 to 12 digits
+
+	// Reference is the indicator digit and item reference of an SGTIN,
+	// the extension digit and serial reference of an SSCC, the asset type
+	// of a GRAI or the individual asset reference of a GIAI
+	Reference string
+
+	Serial string // Serial number of an SGTIN or GRAI
+}
+
+// identity returns the identity type of the scheme, e.g. sgtin
+func (s Scheme) identity() string {
+	name, _, _ := strings.Cut(string(s), "-")
+	return name
+}
+
+// Validate checks the fields of the tag against its scheme
+func (t Tag) Validate() error {
+
+	table, ok := partitions[t.Scheme]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w %q", ErrScheme, t.Scheme)
+	case t.Filter > 7:
+		return fmt.Errorf("%w: filter %d", ErrSyntax, t.Filter)
+	case len(t.CompanyPrefix) < 6 || len(t.CompanyPrefix) > 12 || !isDigits(t.CompanyPrefix):
+		return fmt.Errorf("%w: company prefix %q", ErrSyntax, t.CompanyPrefix)
+	}
+	row := table[12-len(t.CompanyPrefix)]
+
+	switch t.Scheme {
+	case GIAI96:
+		if !isNumber(t.Reference, row.refBits) {
+			return fmt.Errorf("%w: asset reference %q is not a number below 2^%d without leading zeros", ErrSyntax, t.Reference, row.refBits)
+		}
+	case GIAI202:
+		if len(t.Reference) > row.refDigits {
+			return fmt.Errorf("%w: asset reference of %d characters, want at most %d", ErrSyntax, len(t.Reference), row.refDigits)
+		}
+		if err := checkChars(t.Reference); err != nil {
+			return err
+		}
+	default:
+		if len(t.Reference) != row.refDigits || (t.Reference != "" && !isDigits(t.Reference)) {
+			return fmt.Errorf("%w: reference %q, want %d digits", ErrSyntax, t.Reference, row.refDigits)
+		}
+	}
+
+	switch t.Scheme {
+	case SGTIN96, GRAI96:
+		if !isNumber(t.Serial, serialBits) {
+			return fmt.Errorf("%w: serial %q is not a number below 2^%d without leading zeros", ErrSyntax, t.Serial, serialBits)
+		}
+	case SGTIN198:
+		if len(t.Serial) > maxSerial {
+			return fmt.Errorf("%w: serial of %d characters, want at most %d", ErrSyntax, len(t.Serial), maxSerial)
+		}
+		if err := checkChars(t.Serial); err != nil {
+			return err
+		}
+	default:
+		if t.Serial != "" {
+			return fmt.Errorf("%w: %s with serial", ErrSyntax, t.Scheme)
+		}
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for n := 0; n < len(s); n++ {
+		if s[n] < '0' || s[n] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isNumber returns true if s is a decimal number below 2^bits without
+// leading zeros
+func isNumber(s string, bits int) bool {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return err == nil && n < 1<<bits && strconv.FormatUint(n, 10) == s
+}
+
+// cset82 is the GS1 AI encodable character set 82, used by alphanumeric
+// serials and asset references
+const cset82 = "!\"%&'()*+,-./0123456789:;<=>?ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+// checkChars checks that s is 1 or more characters of GS1 character set 82
+func checkChars(s string) error {
+	if s == "" {
+		return fmt.Errorf("%w: empty", ErrSyntax)
+	}
+	for n := 0; n < len(s); n++ {
+		if strings.IndexByte(cset82, s[n]) < 0 {
+			return &gtin.PositionError{Err: gtin.ErrCharacter, Char: s[n], Pos: n}
+		}
+	}
+	return nil
+}
+
+// checkDigit returns payload with its GS1 check digit appended
+func checkDigit(payload string) string {
+	digits := make([]uint8, len(payload))
+	for n := range payload {
+		digits[n] = payload[n] - '0'
+	}
+	return payload + strconv.Itoa(int(gtin.GS1Mod10{}.CheckDigit(digits)))
+}
+
+// FromSGTIN returns the tag of an SGTIN in scheme SGTIN96 or SGTIN198
+func FromSGTIN(s gtin.SGTIN, scheme Scheme, filter uint8) (Tag, error) {
+	if scheme.identity() != "sgtin" {
+		return Tag{}, fmt.Errorf("%w %q for an SGTIN", ErrScheme, scheme)
+	}
+	cp, err := s.GTIN.CompanyPrefix()
+	if err != nil {
+		return Tag{}, err
+	}
+	return fromSGTIN(s, scheme, filter, cp)
+}
+
+// FromSGTINIn is like FromSGTIN, with the company prefix of a tenant's
+// registry
+func FromSGTINIn(r *gtin.Registry, s gtin.SGTIN, scheme Scheme, filter uint8) (Tag, error) {
+	if scheme.identity() != "sgtin" {
+		return Tag{}, fmt.Errorf("%w %q for an SGTIN", ErrScheme, scheme)
+	}
+	cp, err := r.CompanyPrefix(s.GTIN)
+	if err != nil {
+		return Tag{}, err
+	}
+	return fromSGTIN(s, scheme, filter, cp)
+}
+
+func fromSGTIN(s gtin.SGTIN, scheme Scheme, filter uint8, cp string) (Tag, error) {
+	digits := s.GTIN.String()
+	t := Tag{scheme, filter, cp, digits[:1] + digits[1+len(cp):gtin.GTIN_LENGTH-1], s.Serial}
+	return t, t.Validate()
+}
+
+// FromSSCC returns the SSCC96 tag of an SSCC
+func FromSSCC(s gtin.SSCC, filter uint8) (Tag, error) {
+	cp, err := s.CompanyPrefix()
+	if err != nil {
+		return Tag{}, err
+	}
+	return fromSSCC(s, filter, cp)
+}
+
+// FromSSCCIn is like FromSSCC, with the company prefix of a tenant's
+// registry
+func FromSSCCIn(r *gtin.Registry, s gtin.SSCC, filter uint8) (Tag, error) {
+	cp, err := r.SSCCCompanyPrefix(s)
+	if err != nil {
+		return Tag{}, err
+	}
+	return fromSSCC(s, filter, cp)
+}
+
+func fromSSCC(s gtin.SSCC, filter uint8, cp string) (Tag, error) {
+	digits := s.String()
+	t := Tag{SSCC96, filter, cp, digits[:1] + digits[1+len(cp):gtin.SSCC_LENGTH-1], ""}
+	return t, t.Validate()
+}
+
+// SGTIN returns the SGTIN of the tag, with the check digit computed
+func (t Tag) SGTIN() (gtin.SGTIN, error) {
+
+	if t.Scheme.identity() != "sgtin" {
+		return gtin.SGTIN{}, fmt.Errorf("%w %q is not an SGTIN", ErrScheme, t.Scheme)
+	}
+	gt, err := gtin.Parse(checkDigit(t.Reference[:1] + t.CompanyPrefix + t.Reference[1:]))
+	if err != nil {
+		return gtin.SGTIN{}, err
+	}
+	return gtin.NewSGTIN(gt, t.Serial)
+}
+
+// SSCC returns the SSCC of the tag, with the check digit computed
+func (t Tag) SSCC() (gtin.SSCC, error) {
+
+	if t.Scheme != SSCC96 {
+		return gtin.SSCC{}, fmt.Errorf("%w %q is not an SSCC", ErrScheme, t.Scheme)
+	}
+	return gtin.ParseSSCC(checkDigit(t.Reference[:1] + t.CompanyPrefix + t.Reference[1:]))
+}
+
+// ElementString returns the tag as element string, e.g.
+// (01)80614141123458(21)6789, (00)106141411234567897,
+// (8003)00614141123452400 or (8004)061414112345
+func (t Tag) ElementString() (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+	switch t.Scheme.identity() {
+	case "sscc":
+		s, err := t.SSCC()
+		return "(00)" + s.String(), err
+	case "grai":
+		return "(8003)" + checkDigit("0"+t.CompanyPrefix+t.Reference) + t.Serial, nil
+	case "giai":
+		return "(8004)" + t.CompanyPrefix + t.Reference, nil
+	}
+	s, err := t.SGTIN()
+	return s.ElementString(), err
+}
+
+// ParseElementString returns the tag of an SGTIN or SSCC element string,
+// e.g. (01)80614141123458(21)6789, in the given scheme
+func ParseElementString(es string, scheme Scheme, filter uint8) (Tag, error) {
+
+	if code, ok := strings.CutPrefix(es, "(00)"); ok {
+		s, err := gtin.ParseSSCC(code)
+		if err != nil {
+			return Tag{}, err
+		}
+		if scheme != SSCC96 {
+			return Tag{}, fmt.Errorf("%w %q for an SSCC", ErrScheme, scheme)
+		}
+		return FromSSCC(s, filter)
+	}
+
+	rest, ok := strings.CutPrefix(es, "("+gtin.AIGTIN+")")
+	code, serial, found := strings.Cut(rest, "("+gtin.AISerialNumber+")")
+	if !ok || !found {
+		return Tag{}, fmt.Errorf("%w: element string %q", ErrSyntax, es)
+	}
+	gt, err := gtin.Parse(code)
+	if err != nil {
+		return Tag{}, err
+	}
+	s, err := gtin.NewSGTIN(gt, serial)
+	if err != nil {
+		return Tag{}, err
+	}
+	return FromSGTIN(s, scheme, filter)
+}
+
+// PureURI returns the pure identity URI of the tag, e.g.
+// urn:epc:id:sgtin:0614141.812345.6789
+func (t Tag) PureURI() string {
+	return "urn:epc:id:" + t.Scheme.identity() + ":" + t.fields()
+}
+
+// ParsePureURI returns the tag of a pure identity URI in the given scheme
+func ParsePureURI(uri string, scheme Scheme, filter uint8) (Tag, error) {
+
+	rest, ok := strings.CutPrefix(uri, "urn:epc:id:"+scheme.identity()+":")
+	if !ok {
+		return Tag{}, fmt.Errorf("%w: %q is not a %s pure identity URI", ErrSyntax, uri, scheme)
+	}
+	return parseFields(rest, scheme, filter)
+}
+
+// URI returns the tag URI of the tag, e.g.
+// urn:epc:tag:sgtin-96:3.0614141.812345.6789
+func (t Tag) URI() string {
+	return "urn:epc:tag:" + string(t.Scheme) + ":" + strconv.Itoa(int(t.Filter)) + "." + t.fields()
+}
+
+// fields returns the dot-separated company prefix, reference and serial,
+// if the identity has one, of a URI
+func (t Tag) fields() string {
+	s := t.CompanyPrefix + "." + gtin.EscapeEPC(t.Reference)
+	if t.Scheme.hasSerial() {
+		s += "." + gtin.EscapeEPC(t.Serial)
+	}
+	return s
+}
+
+// hasSerial returns true if the identity of the scheme has a serial
+func (s Scheme) hasSerial() bool {
+	identity := s.identity()
+	return identity == "sgtin" || identity == "grai"
+}
+
+// ParseURI returns the tag of a tag URI
+func ParseURI(uri string) (Tag, error) {
+
+	rest, ok := strings.CutPrefix(uri, "urn:epc:tag:")
+	scheme, rest, found := strings.Cut(rest, ":")
+	filter, rest, dot := strings.Cut(rest, ".")
+	f, err := strconv.ParseUint(filter, 10, 8)
+	if !ok || !found || !dot || err != nil {
+		return Tag{}, fmt.Errorf("%w: %q is not a tag URI", ErrSyntax, uri)
+	}
+	return parseFields(rest, Scheme(scheme), uint8(f))
+}
+
+// parseFields parses the dot-separated company prefix, reference and
+// serial, if the identity has one, of a URI
+func parseFields(fields string, scheme Scheme, filter uint8) (Tag, error) {
+
+	want := 2
+	if scheme.hasSerial() {
+		want = 3
+	}
+	// Serials may contain dots, so they are the rest of the URI
+	parts := strings.SplitN(fields, ".", want)
+	if len(parts) != want {
+		return Tag{}, fmt.Errorf("%w: %q", ErrSyntax, fields)
+	}
+	for n := range parts[1:] {
+		part, err := url.PathUnescape(parts[1+n])
+		if err != nil {
+			return Tag{}, fmt.Errorf("%w: %q", ErrSyntax, parts[1+n])
+		}
+		parts[1+n] = part
+	}
+	t := Tag{Scheme: scheme, Filter: filter, CompanyPrefix: parts[0], Reference: parts[1]}
+	if want == 3 {
+		t.Serial = parts[2]
+	}
+	return t, t.Validate()
+}
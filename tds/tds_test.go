@@ -0,0 +1,167 @@
+package tds
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestMain(m *testing.M) {
+	if err := gtin.LoadGCPLengths("../testdata/gcpprefixformatlist.json"); err != nil {
+		panic(err)
+	}
+	m.Run()
+}
+
+// examples follow the examples of the Tag Data Standard
+var examples = []struct {
+	element, pure, tag, binary string
+	scheme                     Scheme
+}{
+	{
+		"(01)80614141123458(21)6789",
+		"urn:epc:id:sgtin:0614141.812345.6789",
+		"urn:epc:tag:sgtin-96:3.0614141.812345.6789",
+		"3074257BF7194E4000001A85",
+		SGTIN96,
+	},
+	{
+		"(01)80614141123458(21)32a/b",
+		"urn:epc:id:sgtin:0614141.812345.32a%2Fb",
+		"urn:epc:tag:sgtin-198:3.0614141.812345.32a%2Fb",
+		"3674257BF7194E59B2C2BF100000000000000000000000000000",
+		SGTIN198,
+	},
+	{
+		"(00)106141412345678908",
+		"urn:epc:id:sscc:0614141.1234567890",
+		"urn:epc:tag:sscc-96:3.0614141.1234567890",
+		"3174257BF4499602D2000000",
+		SSCC96,
+	},
+	{
+		"(8003)00614141123452400",
+		"urn:epc:id:grai:0614141.12345.400",
+		"urn:epc:tag:grai-96:3.0614141.12345.400",
+		"3374257BF40C0E4000000190",
+		GRAI96,
+	},
+	{
+		"(8003)00614141123452400",
+		"urn:epc:id:grai:061414112345..400",
+		"urn:epc:tag:grai-96:3.061414112345..400",
+		"3360393243F1640000000190",
+		GRAI96,
+	},
+	{
+		"(8004)061414112345400",
+		"urn:epc:id:giai:0614141.12345400",
+		"urn:epc:tag:giai-96:3.0614141.12345400",
+		"3474257BF400000000BC6038",
+		GIAI96,
+	},
+	{
+		"(8004)061414112345400",
+		"urn:epc:id:giai:0614141.12345400",
+		"urn:epc:tag:giai-202:3.0614141.12345400",
+		"3874257BF58B266D1AB460C00000000000000000000000000000",
+		GIAI202,
+	},
+}
+
+func TestRepresentations(t *testing.T) {
+
+	for _, ex := range examples {
+		tag, err := ParseURI(ex.tag)
+		if err != nil {
+			t.Errorf("%s: %v", ex.tag, err)
+			continue
+		}
+		if tag.PureURI() != ex.pure || tag.URI() != ex.tag {
+			t.Errorf("%s: got %s and %s", ex.tag, tag.PureURI(), tag.URI())
+		}
+		if es, err := tag.ElementString(); err != nil || es != ex.element {
+			t.Errorf("%s: got %s, %v", ex.tag, es, err)
+		}
+		if got, err := ParsePureURI(ex.pure, ex.scheme, 3); err != nil || got != tag {
+			t.Errorf("%s: got %+v, %v", ex.pure, got, err)
+		}
+		if !strings.HasPrefix(ex.element, "(0") {
+			continue
+		}
+		if got, err := ParseElementString(ex.element, ex.scheme, 3); err != nil || got != tag {
+			t.Errorf("%s: got %+v, %v", ex.element, got, err)
+		}
+	}
+
+	// Serials may contain dots and escaped characters
+	tag, err := ParseURI("urn:epc:tag:sgtin-198:1.0614141.812345.a.b%25c")
+	if err != nil || tag.Serial != "a.b%c" || tag.URI() != "urn:epc:tag:sgtin-198:1.0614141.812345.a.b%25c" {
+		t.Errorf("got %+v, %v", tag, err)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+
+	tests := []struct {
+		uri  string
+		want error
+	}{
+		{"urn:epc:tag:sgtin-64:3.0614141.812345.6789", ErrScheme},
+		{"urn:epc:id:sgtin:0614141.812345.6789", ErrSyntax},
+		{"urn:epc:tag:sgtin-96:8.0614141.812345.6789", ErrSyntax},
+		{"urn:epc:tag:sgtin-96:3.0614141.81234.6789", ErrSyntax},
+		{"urn:epc:tag:sgtin-96:3.0614141.812345.06789", ErrSyntax},
+		{"urn:epc:tag:sgtin-96:3.0614141.812345.274877906944", ErrSyntax},
+		{"urn:epc:tag:sgtin-96:3.0614141.812345.abc", ErrSyntax},
+		{"urn:epc:tag:sgtin-198:3.0614141.812345.a~b", gtin.ErrCharacter},
+		{"urn:epc:tag:sscc-96:3.0614141.1234567890.1", ErrSyntax},
+		{"urn:epc:tag:grai-96:3.0614141.1234.400", ErrSyntax},
+		{"urn:epc:tag:grai-96:3.0614141.12345", ErrSyntax},
+		{"urn:epc:tag:giai-96:3.0614141.0123", ErrSyntax},
+		{"urn:epc:tag:giai-96:3.061414112345.9999999999999", ErrSyntax},
+		{"urn:epc:tag:giai-96:3.0614141.1.2", ErrSyntax},
+		{"urn:epc:tag:giai-202:3.0614141.1234567890123456789012345", ErrSyntax},
+		{"urn:epc:tag:giai-202:3.0614141.a~b", gtin.ErrCharacter},
+	}
+	for _, tt := range tests {
+		if _, err := ParseURI(tt.uri); !errors.Is(err, tt.want) {
+			t.Errorf("%s: wanted %v, got %v", tt.uri, tt.want, err)
+		}
+	}
+
+	if _, err := ParseElementString("(00)106141412345678908", SGTIN96, 3); !errors.Is(err, ErrScheme) {
+		t.Errorf("wanted ErrScheme, got %v", err)
+	}
+	if _, err := ParseElementString("(01)80614141123458", SGTIN96, 3); !errors.Is(err, ErrSyntax) {
+		t.Errorf("wanted ErrSyntax, got %v", err)
+	}
+	if _, err := ParseElementString("(01)20012345678909(21)1", SGTIN96, 3); !errors.Is(err, gtin.ErrCompanyPrefix) {
+		t.Errorf("wanted ErrCompanyPrefix, got %v", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+
+	r := &gtin.Registry{Prefixes: []gtin.CompanyPrefix{"061414112", "0614141234"}}
+
+	s, err := gtin.NewSGTIN(gtin.MustParse("80614141123458"), "6789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := FromSGTINIn(r, s, SGTIN96, 3)
+	if err != nil || tag.PureURI() != "urn:epc:id:sgtin:061414112.8345.6789" {
+		t.Errorf("got %s, %v", tag.PureURI(), err)
+	}
+
+	sscc, err := gtin.ParseSSCC("106141412345678908")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err = FromSSCCIn(r, sscc, 3)
+	if err != nil || tag.PureURI() != "urn:epc:id:sscc:0614141234.1567890" {
+		t.Errorf("got %s, %v", tag.PureURI(), err)
+	}
+}
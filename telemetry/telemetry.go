@@ -0,0 +1,179 @@
+/*
+Package telemetry gives GTIN spans and metrics the same attributes across
+services, e.g. for tracing order and catalog flows with OpenTelemetry.
+
+	gtin             the GTIN-14, or the raw input if it doesn't parse
+	gtin.type        GTIN-8, GTIN-12, GTIN-13 or GTIN-14
+	gtin.valid       true if validation found no errors
+	gtin.prefix      the GS1 member organization of the prefix
+	gtin.error.code  the code of the first error, e.g. GTIN_E003_CHECK_DIGIT
+
+The package doesn't import OpenTelemetry. Attributes are plain key-value
+pairs holding strings and bools, converted with attribute.String and
+attribute.Bool at the call site:
+
+	gt, attrs, err := telemetry.Validate(input)
+	telemetry.Each(attrs,
+		func(k, v string) { span.SetAttributes(attribute.String(k, v)) },
+		func(k string, v bool) { span.SetAttributes(attribute.Bool(k, v)) })
+
+Metric attributes leave out the GTIN, which would make the cardinality of
+a counter unbounded. Outcomes counts validations by their metric
+attributes, to be reported by an observable counter:
+
+	var outcomes telemetry.Outcomes
+	outcomes.Record(gt, err)
+	...
+	meter.Int64ObservableCounter("gtin.validations", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			outcomes.Each(func(attrs []telemetry.Attribute, n int64) {
+				o.Observe(n, metric.WithAttributes(...))
+			})
+			return nil
+		}))
+*/
+package telemetry
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/peterstark72/gtin"
+)
+
+// Attribute keys
+const (
+	KeyGTIN      = "gtin"
+	KeyType      = "gtin.type"
+	KeyValid     = "gtin.valid"
+	KeyPrefix    = "gtin.prefix"
+	KeyErrorCode = "gtin.error.code"
+)
+
+// Attribute is a span or metric attribute. Value is a string or a bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Validate parses and validates the input with gtin.DefaultPolicy() and
+// returns span attributes of the outcome. The error joins all errors
+// found.
+func Validate(input string) (gtin.GTIN, []Attribute, error) {
+
+	gt, err := gtin.Parse(input)
+	if err == nil {
+		if report := gt.Validate(); !report.OK() {
+			err = errors.Join(report.Errors...)
+		}
+	}
+	attrs := Attributes(gt, err)
+	if gt.IsZero() {
+		attrs = append([]Attribute{{KeyGTIN, input}}, attrs...)
+	}
+	return gt, attrs, err
+}
+
+// Attributes returns the span attributes of a GTIN and the error of its
+// validation, if any. A zero GTIN gets no gtin, type and prefix attributes.
+func Attributes(gt gtin.GTIN, err error) []Attribute {
+	attrs := MetricAttributes(gt, err)
+	if !gt.IsZero() {
+		attrs = append([]Attribute{{KeyGTIN, gt.String()}}, attrs...)
+	}
+	return attrs
+}
+
+// MetricAttributes is Attributes without the GTIN, for counting
+// validation outcomes
+func MetricAttributes(gt gtin.GTIN, err error) []Attribute {
+
+	var attrs []Attribute
+	if !gt.IsZero() {
+		attrs = append(attrs, Attribute{KeyType, string(gt.Type())})
+		if prefix, err := gt.MemberOrganization(); err == nil {
+			attrs = append(attrs, Attribute{KeyPrefix, prefix})
+		}
+	}
+	attrs = append(attrs, Attribute{KeyValid, err == nil})
+	if code := gtin.ErrorCode(err); code != "" {
+		attrs = append(attrs, Attribute{KeyErrorCode, code})
+	}
+	return attrs
+}
+
+// Each calls str for the string attributes and boolean for the bool ones
+func Each(attrs []Attribute, str func(key, value string), boolean func(key string, value bool)) {
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			str(a.Key, v)
+		case bool:
+			boolean(a.Key, v)
+		}
+	}
+}
+
+// Outcomes counts validation outcomes by their metric attributes. The zero
+// value is ready to use and safe for concurrent use.
+type Outcomes struct {
+	mu     sync.Mutex
+	counts map[outcome]int64
+	attrs  map[outcome][]Attribute
+}
+
+// outcome is the key of a set of metric attributes
+type outcome struct {
+	typ, prefix, code string
+	valid             bool
+}
+
+// Record counts the outcome of the validation of a GTIN
+func (o *Outcomes) Record(gt gtin.GTIN, err error) {
+
+	attrs := MetricAttributes(gt, err)
+	var k outcome
+	for _, a := range attrs {
+		switch a.Key {
+		case KeyType:
+			k.typ = a.Value.(string)
+		case KeyPrefix:
+			k.prefix = a.Value.(string)
+		case KeyErrorCode:
+			k.code = a.Value.(string)
+		case KeyValid:
+			k.valid = a.Value.(bool)
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.counts == nil {
+		o.counts = make(map[outcome]int64)
+		o.attrs = make(map[outcome][]Attribute)
+	}
+	if _, ok := o.attrs[k]; !ok {
+		o.attrs[k] = attrs
+	}
+	o.counts[k]++
+}
+
+// Each calls fn with the attributes and count of every outcome recorded so
+// far. The counts are cumulative, as observable counters expect.
+func (o *Outcomes) Each(fn func(attrs []Attribute, n int64)) {
+
+	o.mu.Lock()
+	type count struct {
+		attrs []Attribute
+		n     int64
+	}
+	counts := make([]count, 0, len(o.counts))
+	for k, n := range o.counts {
+		counts = append(counts, count{o.attrs[k], n})
+	}
+	o.mu.Unlock()
+
+	for _, c := range counts {
+		fn(c.attrs, c.n)
+	}
+}
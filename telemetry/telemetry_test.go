@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/peterstark72/gtin"
+)
+
+func TestValidate(t *testing.T) {
+
+	for _, test := range []struct {
+		input string
+		want  []Attribute
+	}{
+		{"4006381333931", []Attribute{
+			{KeyGTIN, "04006381333931"},
+			{KeyType, "GTIN-13"},
+			{KeyPrefix, "GS1 Germany"},
+			{KeyValid, true},
+		}},
+		{"4006381333932", []Attribute{
+			{KeyGTIN, "04006381333932"},
+			{KeyType, "GTIN-13"},
+			{KeyPrefix, "GS1 Germany"},
+			{KeyValid, false},
+			{KeyErrorCode, "GTIN_E003_CHECK_DIGIT"},
+		}},
+		{"12a", []Attribute{
+			{KeyGTIN, "12a"},
+			{KeyValid, false},
+			{KeyErrorCode, "GTIN_E001_LENGTH"},
+		}},
+	} {
+		_, attrs, _ := Validate(test.input)
+		if !reflect.DeepEqual(attrs, test.want) {
+			t.Errorf("%s: wanted %v, got %v", test.input, test.want, attrs)
+		}
+	}
+}
+
+func TestMetricAttributes(t *testing.T) {
+
+	gt := gtin.MustParse("96385074")
+	attrs := MetricAttributes(gt, errors.Join(gtin.ErrNotAllowed))
+	for _, a := range attrs {
+		if a.Key == KeyGTIN {
+			t.Errorf("wanted no GTIN in metric attributes, got %v", attrs)
+		}
+	}
+	if last := attrs[len(attrs)-1]; last != (Attribute{KeyErrorCode, "GTIN_E015_NOT_ALLOWED"}) {
+		t.Errorf("got %v", attrs)
+	}
+}
+
+func TestEach(t *testing.T) {
+
+	_, attrs, _ := Validate("4006381333932")
+	var strs, bools int
+	Each(attrs, func(k, v string) { strs++ }, func(k string, v bool) {
+		if k != KeyValid || v {
+			t.Errorf("got %s=%v", k, v)
+		}
+		bools++
+	})
+	if strs != 4 || bools != 1 {
+		t.Errorf("got %d strings and %d bools", strs, bools)
+	}
+}
+
+func TestOutcomes(t *testing.T) {
+
+	var o Outcomes
+	for _, input := range []string{"4006381333931", "4006381333931", "4006381333932", "12a"} {
+		gt, _, err := Validate(input)
+		o.Record(gt, err)
+	}
+
+	counts := make(map[string]int64)
+	o.Each(func(attrs []Attribute, n int64) {
+		for _, a := range attrs {
+			if a.Key == KeyGTIN {
+				t.Errorf("wanted no GTIN in outcomes, got %v", attrs)
+			}
+		}
+		last := attrs[len(attrs)-1]
+		counts[fmt.Sprint(last.Value)] += n
+	})
+	want := map[string]int64{"true": 2, "GTIN_E003_CHECK_DIGIT": 1, "GTIN_E001_LENGTH": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("wanted %v, got %v", want, counts)
+	}
+}
@@ -0,0 +1,34 @@
+package gtin
+
+import "fmt"
+
+// Uint64 returns the GTIN-14 as a number, e.g. 614141000012 for
+// 00614141000012. The type is not part of the number.
+func (gt GTIN) Uint64() uint64 {
+	var n uint64
+	for _, d := range gt.digits {
+		n = n*10 + uint64(d)
+	}
+	return n
+}
+
+// FromUint64 returns the GTIN of the given type represented by n.
+// It returns an error if n has more digits than the type allows, but it
+// does not check the check digit.
+func FromUint64(n uint64, typ Type) (GTIN, error) {
+
+	length, ok := typeLengths[typ]
+	if !ok {
+		return GTIN{}, fmt.Errorf("%w %q", ErrType, typ)
+	}
+
+	var digits [GTIN_LENGTH]uint8
+	for pos := GTIN_LENGTH - 1; pos >= GTIN_LENGTH-length; pos-- {
+		digits[pos] = uint8(n % 10)
+		n /= 10
+	}
+	if n != 0 {
+		return GTIN{}, fmt.Errorf("%w for %s", ErrLength, typ)
+	}
+	return New(typ, digits)
+}
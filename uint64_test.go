@@ -0,0 +1,36 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUint64(t *testing.T) {
+
+	tests := []struct {
+		got  string
+		want uint64
+	}{
+		{"614141000012", 614141000012},
+		{"50614141000994", 50614141000994},
+		{"96385074", 96385074},
+	}
+
+	for _, tt := range tests {
+		gt := MustParse(tt.got)
+		if gt.Uint64() != tt.want {
+			t.Errorf("wanted %v, got %v", tt.want, gt.Uint64())
+		}
+		back, err := FromUint64(gt.Uint64(), gt.Type())
+		if err != nil {
+			t.Error(err)
+		}
+		if back != gt {
+			t.Errorf("wanted %v, got %v", gt, back)
+		}
+	}
+
+	if _, err := FromUint64(123456789, GTIN8); !errors.Is(err, ErrLength) {
+		t.Errorf("wanted %v, got %v", ErrLength, err)
+	}
+}
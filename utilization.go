@@ -0,0 +1,83 @@
+package gtin
+
+import (
+	"math"
+	"time"
+)
+
+// Allocation is a GTIN allocated by a brand owner, and when
+type Allocation struct {
+	GTIN      GTIN
+	Allocated time.Time // Zero if unknown
+}
+
+// Utilization is the use of a company prefix's item references
+type Utilization struct {
+	Prefix    CompanyPrefix
+	Type      Type
+	Capacity  int     // Item references of the prefix for the type
+	Used      int     // Distinct item references allocated
+	Remaining int     // Capacity - Used
+	Ratio     float64 // Used / Capacity
+	Outside   int     // Allocations not numbered from the prefix
+
+	// Rate is the number of item references allocated per day, from the
+	// earliest dated allocation until now. Exhaustion is the date the
+	// remaining capacity runs out at that rate, or zero if the rate is
+	// unknown or the date is after the year 9999, the last year of
+	// RFC 3339 and JSON.
+	Rate       float64
+	Exhaustion time.Time
+}
+
+// lastExhaustion is the last Exhaustion date that can be marshalled
+var lastExhaustion = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// Utilization computes how much of the prefix's capacity for GTINs of the
+// given type the allocations use, and when it runs out at the current
+// allocation rate. Packaging levels of an item share its item reference,
+// so a GTIN-13 and its GTIN-14s count once.
+func (p CompanyPrefix) Utilization(typ Type, allocations []Allocation, now time.Time) Utilization {
+
+	u := Utilization{Prefix: p, Type: typ, Capacity: p.Capacity(typ)}
+
+	seen := make(map[[GTIN_LENGTH - 2]uint8]bool)
+	var earliest time.Time
+	dated := 0
+	for _, a := range allocations {
+		if !p.Contains(a.GTIN) {
+			u.Outside++
+			continue
+		}
+		ref := [GTIN_LENGTH - 2]uint8(a.GTIN.digits[1 : GTIN_LENGTH-1])
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		if !a.Allocated.IsZero() {
+			dated++
+			if earliest.IsZero() || a.Allocated.Before(earliest) {
+				earliest = a.Allocated
+			}
+		}
+	}
+
+	u.Used = len(seen)
+	u.Remaining = max(u.Capacity-u.Used, 0)
+	if u.Capacity > 0 {
+		u.Ratio = float64(u.Used) / float64(u.Capacity)
+	}
+
+	if days := now.Sub(earliest).Hours() / 24; dated > 0 && days > 0 {
+		u.Rate = float64(dated) / days
+		// Whole days first, as a time.Duration only spans 292 years
+		days, frac := math.Modf(float64(u.Remaining) / u.Rate)
+		if days < 1e7 {
+			u.Exhaustion = now.AddDate(0, 0, int(days)).Add(time.Duration(frac * 24 * float64(time.Hour)))
+		}
+		if u.Exhaustion.After(lastExhaustion) {
+			u.Exhaustion = time.Time{}
+		}
+	}
+	return u
+}
@@ -0,0 +1,59 @@
+package gtin
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestUtilization(t *testing.T) {
+
+	// A 10-digit prefix has 100 item references
+	p := CompanyPrefix("4006381333")
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	start := now.AddDate(0, 0, -40)
+
+	var allocations []Allocation
+	for n := 0; n < 20; n++ {
+		payload := fmt.Sprintf("%s%02d", p, n)
+		check, _ := ComputeCheckDigit(payload)
+		gt := MustParse(fmt.Sprintf("%s%d", payload, check))
+		allocations = append(allocations, Allocation{gt, start.AddDate(0, 0, 2*n)})
+		if n < 5 {
+			// Packaging levels share the item reference
+			case14, _ := NewGTIN14(gt, 1)
+			allocations = append(allocations, Allocation{GTIN: case14})
+		}
+	}
+	allocations = append(allocations, Allocation{GTIN: MustParse("614141000012")})
+
+	u := p.Utilization(GTIN13, allocations, now)
+	if u.Capacity != 100 || u.Used != 20 || u.Remaining != 80 || u.Ratio != 0.2 || u.Outside != 1 {
+		t.Errorf("got %+v", u)
+	}
+	// 20 references in 40 days is half a reference per day, so 80 last
+	// another 160 days
+	if u.Rate != 0.5 || !u.Exhaustion.Equal(now.AddDate(0, 0, 160)) {
+		t.Errorf("wanted rate 0.5 and exhaustion %v, got %v and %v", now.AddDate(0, 0, 160), u.Rate, u.Exhaustion)
+	}
+
+	// A 7-digit prefix with one allocation a year lasts 99999 years
+	sparse := CompanyPrefix("4006381")
+	u = sparse.Utilization(GTIN13, []Allocation{{MustParse("4006381333931"), now.AddDate(-1, 0, 0)}}, now)
+	if u.Remaining != 99999 || !u.Exhaustion.IsZero() {
+		t.Errorf("wanted no exhaustion, got %+v", u)
+	}
+
+	// One in ten days lasts some 2700 years, past the span of a
+	// time.Duration
+	u = sparse.Utilization(GTIN13, []Allocation{{MustParse("4006381333931"), now.AddDate(0, 0, -10)}}, now)
+	if want := now.AddDate(0, 0, 999990); !u.Exhaustion.Equal(want) {
+		t.Errorf("wanted %v, got %v", want, u.Exhaustion)
+	}
+
+	// Without dates there is no projection
+	u = p.Utilization(GTIN13, []Allocation{{GTIN: allocations[0].GTIN}}, now)
+	if u.Used != 1 || u.Rate != 0 || !u.Exhaustion.IsZero() {
+		t.Errorf("got %+v", u)
+	}
+}
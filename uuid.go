@@ -0,0 +1,51 @@
+package gtin
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+)
+
+// UUID is an RFC 9562 UUID
+type UUID [16]byte
+
+// urlNamespace is the RFC 9562 namespace for URLs
+var urlNamespace = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// UUIDNamespace is the namespace of GTIN UUIDs, the UUIDv5 of the URL
+// https://github.com/peterstark72/gtin, 5a3aec89-b108-5508-a1f3-675add0e2c92
+var UUIDNamespace = uuidV5(urlNamespace, "https://github.com/peterstark72/gtin")
+
+// UUID returns the UUIDv5 of the GTIN-14 in UUIDNamespace. All forms of a
+// GTIN have the same UUID, and every system derives the same UUID without
+// coordination.
+func (gt GTIN) UUID() UUID {
+	return uuidV5(UUIDNamespace, gt.String())
+}
+
+// uuidV5 returns the name-based UUID of name in the namespace, using SHA-1
+func uuidV5(namespace UUID, name string) UUID {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	var u UUID
+	copy(u[:], h.Sum(nil))
+	u[6] = u[6]&0x0f | 0x50 // Version 5
+	u[8] = u[8]&0x3f | 0x80 // RFC 9562 variant
+	return u
+}
+
+// String returns the UUID in the canonical form, e.g.
+// 395fde69-d077-5230-bb86-6f76e911dc6d
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
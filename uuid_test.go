@@ -0,0 +1,21 @@
+package gtin
+
+import "testing"
+
+func TestUUID(t *testing.T) {
+
+	if want := "5a3aec89-b108-5508-a1f3-675add0e2c92"; UUIDNamespace.String() != want {
+		t.Errorf("wanted namespace %s, got %s", want, UUIDNamespace)
+	}
+
+	u := MustParse("4006381333931").UUID()
+	if want := "395fde69-d077-5230-bb86-6f76e911dc6d"; u.String() != want {
+		t.Errorf("wanted %s, got %s", want, u)
+	}
+	if MustParse("04006381333931").UUID() != u {
+		t.Error("wanted the same UUID for all forms")
+	}
+	if MustParse("614141000012").UUID() == u {
+		t.Error("wanted different UUIDs for different GTINs")
+	}
+}
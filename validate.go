@@ -0,0 +1,58 @@
+package gtin
+
+import (
+	"fmt"
+)
+
+// Report is the outcome of Validate. Errors make the GTIN unusable,
+// warnings flag values that are well-formed but questionable.
+type Report struct {
+	Errors   []error
+	Warnings []error
+}
+
+// OK returns true if the report has no errors
+func (r Report) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// typeLengths maps GTIN types to their number of significant digits
+var typeLengths = map[Type]int{
+	GTIN8:  8,
+	GTIN12: 12,
+	GTIN13: 13,
+	GTIN14: 14,
+}
+
+// Len returns the number of significant digits of the type, or 0 for an
+// unknown type
+func (t Type) Len() int {
+	return typeLengths[t]
+}
+
+// checkStructure returns an error if the digits don't fit the GTIN type
+func checkStructure(gt GTIN) error {
+
+	length, ok := typeLengths[gt.typ]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrType, gt.typ)
+	}
+	for n, d := range gt.digits {
+		if n < GTIN_LENGTH-length && d != 0 {
+			return fmt.Errorf("%w for %s", ErrPadding, gt.typ)
+		}
+		if d > 9 {
+			return ErrDigit
+		}
+	}
+	return nil
+}
+
+// Validate runs every check of DefaultPolicy on the GTIN and reports all
+// problems found:
+//
+//   - structure and check digit problems are errors
+//   - GS1 prefix rules and missing data carriers are warnings
+func (gt GTIN) Validate() Report {
+	return DefaultPolicy().Validate(gt)
+}
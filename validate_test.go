@@ -0,0 +1,35 @@
+package gtin
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		got      GTIN
+		errors   int
+		warnings int
+	}{
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 4, 0, 0, 6, 3, 8, 1, 3, 3, 3, 9, 3, 1}}, 0, 0},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 4, 0, 0, 6, 3, 8, 1, 3, 3, 3, 9, 3, 2}}, 1, 0},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 2, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 3}}, 0, 1},
+		{GTIN{typ: GTIN12, digits: [GTIN_LENGTH]uint8{1, 0, 6, 1, 4, 1, 4, 1, 0, 0, 0, 0, 1, 2}}, 1, 0},
+		{GTIN{typ: GTIN13, digits: [GTIN_LENGTH]uint8{0, 4, 0, 0, 6, 3, 8, 1, 3, 3, 3, 9, 3, 10}}, 1, 0},
+		{GTIN{typ: "", digits: [GTIN_LENGTH]uint8{}}, 1, 0},
+	}
+
+	for _, tt := range tests {
+		r := tt.got.Validate()
+		if len(r.Errors) != tt.errors || len(r.Warnings) != tt.warnings {
+			t.Errorf("%v: wanted %d errors and %d warnings, got %v", tt.got, tt.errors, tt.warnings, r)
+		}
+		if r.OK() != (tt.errors == 0) {
+			t.Errorf("%v: wrong result", tt.got)
+		}
+	}
+}
+
+func TestTypeLen(t *testing.T) {
+	if GTIN8.Len() != 8 || GTIN14.Len() != 14 || Type("").Len() != 0 {
+		t.Errorf("wrong length")
+	}
+}